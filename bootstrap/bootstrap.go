@@ -27,6 +27,7 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	bootstrapHandlers "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/handlers"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/registration"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
@@ -107,6 +108,17 @@ func RunAndReturnWaitGroup(
 		})
 	}
 
+	// Check if service provided a HealthCheckRegistry to use. If not create one and add it to the DIC so handlers
+	// can register the components probed by the readiness handler.
+	if container.HealthCheckRegistryFrom(dic.Get) == nil {
+		healthCheckRegistry := bootstrapHandlers.NewHealthCheckRegistry()
+		dic.Update(di.ServiceConstructorMap{
+			container.HealthCheckRegistryName: func(get di.Get) interface{} {
+				return healthCheckRegistry
+			},
+		})
+	}
+
 	translateInterruptToCancel(ctx, &wg, cancel)
 
 	envVars := environment.NewVariables(lc)
@@ -187,6 +199,8 @@ func RunAndReturnWaitGroup(
 
 				// TODO: use this same approach to register future service metric controlled by other components
 			}
+
+			registerMetrics(metricsManager, configProcessor.GetMetricsToRegister(), lc)
 		} else {
 			lc.Warn("MetricsManager not available. General common service metrics will not be reported. ")
 		}
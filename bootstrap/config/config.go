@@ -16,22 +16,26 @@
 package config
 
 import (
+	"bytes"
 	"context"
 	"errors"
 	"fmt"
+	"io/fs"
 	"math"
-	"os"
+	"math/rand"
+	"net/url"
 	"path/filepath"
 	"reflect"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
 	"github.com/mitchellh/copystructure"
-	"gopkg.in/yaml.v3"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 
@@ -39,6 +43,7 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 
@@ -53,28 +58,102 @@ const (
 	allServicesKey    = "all-services"
 	appServicesKey    = "app-services"
 	deviceServicesKey = "device-services"
+
+	// DefaultMaxConfigFileSize is the default maximum size, in bytes, of a configuration file that will be read
+	DefaultMaxConfigFileSize int64 = 4 * 1024 * 1024
+
+	// DefaultCommonConfigPollJitter is the default maximum random jitter added to each interval of the polling for the
+	// common configuration to be ready
+	DefaultCommonConfigPollJitter = time.Second
+
+	defaultWatchRetryInterval = time.Second
+	maxWatchRetryInterval     = 30 * time.Second
 )
 
 // UpdatedStream defines the stream type that is notified by ListenForChanges when a configuration update is received.
 type UpdatedStream chan struct{}
 
 type Processor struct {
-	lc                 logger.LoggingClient
-	flags              flags.Common
-	envVars            *environment.Variables
-	startupTimer       startup.Timer
-	ctx                context.Context
-	wg                 *sync.WaitGroup
-	configUpdated      UpdatedStream
-	dic                *di.Container
-	overwriteConfig    bool
-	providerHasConfig  bool
-	commonConfigClient configuration.Client
-	appConfigClient    configuration.Client
-	deviceConfigClient configuration.Client
-}
-
-// NewProcessor creates a new configuration Processor
+	lc                     logger.LoggingClient
+	flags                  flags.Common
+	envVars                *environment.Variables
+	startupTimer           startup.Timer
+	ctx                    context.Context
+	wg                     *sync.WaitGroup
+	configUpdated          UpdatedStream
+	dic                    *di.Container
+	overwriteConfig        bool
+	providerHasConfig      bool
+	serviceType            string
+	omitEmptyCustom        bool
+	maxConfigFileSize      int64
+	watchRetryInterval     time.Duration
+	commonPollInterval     time.Duration
+	commonPollJitter       time.Duration
+	fileLoadTimeout        time.Duration
+	fileLoadRetryInterval  time.Duration
+	providerLoadTimeout    time.Duration
+	providerRetryInterval  time.Duration
+	requiredCommonSections []string
+	configSources          []prioritizedConfigSource
+	watchedWritablePaths   []string
+	publishConfigChanges   bool
+	restartRequiredPaths   []string
+	restartRequired        RestartRequiredStream
+	keepUnknownSettings    bool
+	lazySections           []string
+	persistedPaths         []string
+	pushFailurePolicy      PushFailurePolicy
+	deprecatedSettings     map[string]string
+	trackProvenance        bool
+	provenance             map[string]string
+	unknownSettings        map[string]string
+	metrics                processorMetrics
+	serviceConfig          interfaces.Configuration
+	baseConfig             interfaces.Configuration
+	writableMutex          sync.Mutex
+	configStem             string
+	serviceKey             string
+	baseKey                string
+	result                 ProcessResult
+	clock                  clock
+	commonConfigClient     configuration.Client
+	privateConfigClient    configuration.Client
+	appConfigClient        configuration.Client
+	deviceConfigClient     configuration.Client
+	configClientFactory    container.ConfigClientFactory
+	cancelWatchers         context.CancelFunc
+	watchersWg             sync.WaitGroup
+	watchersMutex          sync.Mutex
+	runningWatchers        map[string]int
+	updateQueueSize        int
+	updates                *updateQueue
+	updateWorkerOnce       sync.Once
+
+	configChangedMutex     sync.Mutex
+	configChangedCallbacks []func()
+
+	lazyMutex        sync.Mutex
+	deferredSections map[string]bool
+
+	logLevelMutex     sync.Mutex
+	logLevelSettle    time.Duration
+	logLevelMinDwell  time.Duration
+	logLevelTimer     stoppable
+	logLevelChangedAt time.Time
+
+	connectionMutex         sync.Mutex
+	connectionState         ConnectionEvent
+	connectionCallbacks     []ConnectionEventCallback
+	connectionProbeInterval time.Duration
+	refreshOnReconnect      bool
+	reconnectRefresh        func() error
+
+	completedMutex       sync.RWMutex
+	bootstrapCompletedAt time.Time
+}
+
+// NewProcessor creates a new configuration Processor, which logs using the LoggingClient in the DIC
 func NewProcessor(
 	flags flags.Common,
 	envVars *environment.Variables,
@@ -84,16 +163,33 @@ func NewProcessor(
 	configUpdated UpdatedStream,
 	dic *di.Container,
 ) *Processor {
-	return &Processor{
-		lc:            container.LoggingClientFrom(dic.Get),
-		flags:         flags,
-		envVars:       envVars,
-		startupTimer:  startupTimer,
-		ctx:           ctx,
-		wg:            wg,
-		configUpdated: configUpdated,
-		dic:           dic,
+	// The watchers get their own cancelable context so they can be stopped via Shutdown independent of the service.
+	ctx, cancel := context.WithCancel(ctx)
+	cp := &Processor{
+		lc:              container.LoggingClientFrom(dic.Get),
+		flags:           flags,
+		envVars:         envVars,
+		startupTimer:    startupTimer,
+		ctx:             ctx,
+		wg:              wg,
+		configUpdated:   configUpdated,
+		dic:             dic,
+		cancelWatchers:  cancel,
+		runningWatchers: make(map[string]int),
+		metrics:         newProcessorMetrics(),
+		clock:           realClock{},
+
+		commonPollJitter: DefaultCommonConfigPollJitter,
+	}
+
+	// Set the log level specified on the command-line now so it applies to the logging while processing the configuration
+	if logLevel := logLevelFlag(flags); len(logLevel) > 0 {
+		if err := cp.lc.SetLogLevel(logLevel); err != nil {
+			cp.lc.Errorf("unable to set log level from command-line: %s", err.Error())
+		}
 	}
+
+	return cp
 }
 
 func NewProcessorForCustomConfig(
@@ -101,13 +197,183 @@ func NewProcessorForCustomConfig(
 	ctx context.Context,
 	wg *sync.WaitGroup,
 	dic *di.Container) *Processor {
+	ctx, cancel := context.WithCancel(ctx)
 	return &Processor{
-		lc:    container.LoggingClientFrom(dic.Get),
-		flags: flags,
-		ctx:   ctx,
-		wg:    wg,
-		dic:   dic,
+		lc:              container.LoggingClientFrom(dic.Get),
+		flags:           flags,
+		ctx:             ctx,
+		wg:              wg,
+		dic:             dic,
+		cancelWatchers:  cancel,
+		runningWatchers: make(map[string]int),
+		metrics:         newProcessorMetrics(),
+		clock:           realClock{},
+
+		commonPollJitter: DefaultCommonConfigPollJitter,
+	}
+}
+
+// Shutdown stops all the configuration watchers started by the Processor and waits for them to exit. An error is
+// returned, and the watchers still running are logged, if they have not all exited before the passed in context is done.
+// The configuration updates already received by the watchers are applied before returning. The settings set by
+// SetPersistOnShutdown are then written to the Configuration Provider, once the watchers have stopped so the writes
+// don't trigger updates.
+func (cp *Processor) Shutdown(ctx context.Context) error {
+	cp.cancelWatchers()
+
+	done := make(chan struct{})
+	go func() {
+		cp.watchersWg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+		cp.lc.Info("All configuration watchers have stopped")
+		// Apply the updates queued by the watchers after the update worker exited
+		if cp.updates != nil {
+			cp.applyQueuedUpdates()
+		}
+	case <-ctx.Done():
+		running := cp.runningWatcherNames()
+		cp.lc.Errorf("timed out waiting for configuration watchers to stop. Still running: %s", strings.Join(running, ", "))
+		err = fmt.Errorf("timed out waiting for %d configuration watcher(s) to stop: %w", len(running), ctx.Err())
+	}
+
+	if persistErr := cp.persistSettings(); persistErr != nil {
+		cp.lc.Error(persistErr.Error())
+		err = errors.Join(err, persistErr)
+	}
+
+	return err
+}
+
+// startWatcher runs the watcher function in a go routine which is tracked by both the service's wait group and the
+// Processor's watcher wait group so that Shutdown can wait for it to exit.
+func (cp *Processor) startWatcher(name string, watcher func()) {
+	cp.watchersMutex.Lock()
+	cp.runningWatchers[name]++
+	cp.watchersMutex.Unlock()
+
+	cp.wg.Add(1)
+	cp.watchersWg.Add(1)
+	go func() {
+		defer func() {
+			cp.watchersMutex.Lock()
+			cp.runningWatchers[name]--
+			if cp.runningWatchers[name] <= 0 {
+				delete(cp.runningWatchers, name)
+			}
+			cp.watchersMutex.Unlock()
+
+			cp.watchersWg.Done()
+			cp.wg.Done()
+		}()
+
+		watcher()
+	}()
+}
+
+// runningWatcherNames returns the sorted names of the watchers that have not yet exited
+func (cp *Processor) runningWatcherNames() []string {
+	cp.watchersMutex.Lock()
+	defer cp.watchersMutex.Unlock()
+
+	names := make([]string, 0, len(cp.runningWatchers))
+	for name := range cp.runningWatchers {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// ProcessResult describes what was loaded by Process, i.e. to report in an introspection endpoint
+type ProcessResult struct {
+	// Source is the name of the source the private configuration was loaded from, i.e. "Configuration Provider"
+	Source string
+	// ProviderUrl is the URL of the Configuration Provider. Empty when the Configuration Provider isn't used.
+	ProviderUrl string
+	// CommonSections are the sections of the common configuration merged into the configuration, i.e. "all-services"
+	// and "app-services". Empty when no common configuration is used.
+	CommonSections []string
+	// CommonOverrideCount is the number of environment variable overrides applied to the common configuration
+	CommonOverrideCount int
+	// PrivateOverrideCount is the number of environment variable overrides applied to the private configuration,
+	// including those from custom sources
+	PrivateOverrideCount int
+	// CustomSources are the names of the custom sources merged into the configuration, in the order merged
+	CustomSources []string
+	// SecurityEnabled is whether the service runs in secure mode, i.e. with the secret store
+	SecurityEnabled bool
+	// Watchers are the names of the watchers started for the changes in the Configuration Provider. Empty when the
+	// Configuration Provider isn't used.
+	Watchers []string
+}
+
+// LogFields returns the result as the key/value pairs of a structured log entry, i.e. for the startup report logged by
+// Process. The Configuration Provider URL is redacted so no credentials are logged.
+func (r ProcessResult) LogFields() []any {
+	return []any{
+		"source", r.Source,
+		"providerUrl", redactUrl(r.ProviderUrl),
+		"commonSections", strings.Join(r.CommonSections, ","),
+		"commonOverrides", r.CommonOverrideCount,
+		"privateOverrides", r.PrivateOverrideCount,
+		"customSources", strings.Join(r.CustomSources, ","),
+		"securityEnabled", r.SecurityEnabled,
+		"watchersActive", len(r.Watchers) > 0,
+		"watchers", strings.Join(r.Watchers, ","),
+	}
+}
+
+// Result returns the description of what was loaded by the last call to Process, as also returned by
+// ProcessWithResult
+func (cp *Processor) Result() ProcessResult {
+	return cp.result
+}
+
+// redactUrl returns the URL with its password and query values redacted, so it can be logged or reported
+func redactUrl(rawUrl string) string {
+	parsedUrl, err := url.Parse(rawUrl)
+	if err != nil {
+		return rawUrl
 	}
+
+	if len(parsedUrl.RawQuery) > 0 {
+		query := parsedUrl.Query()
+		for key := range query {
+			query.Set(key, "xxxxx")
+		}
+		parsedUrl.RawQuery = query.Encode()
+	}
+
+	return parsedUrl.Redacted()
+}
+
+// operationLogger returns a LoggingClient which tags the log messages with the service key, once known, and the
+// operation being performed, along with the additional key/value pairs
+func (cp *Processor) operationLogger(operation string, keyValues ...string) logger.LoggingClient {
+	pairs := []string{"operation", operation}
+	if len(cp.serviceKey) > 0 {
+		pairs = append([]string{"service", cp.serviceKey}, pairs...)
+	}
+
+	return utils.NewContextLogger(cp.lc, append(pairs, keyValues...)...)
+}
+
+// ProcessWithResult is the same as Process, but also returns a description of what was loaded. The result describes
+// what was loaded before the failure when an error is returned.
+func (cp *Processor) ProcessWithResult(
+	serviceKey string,
+	serviceType string,
+	configStem string,
+	serviceConfig interfaces.Configuration,
+	secretProvider interfaces.SecretProviderExt) (ProcessResult, error) {
+
+	err := cp.Process(serviceKey, serviceType, configStem, serviceConfig, secretProvider)
+	return cp.result, err
 }
 
 func (cp *Processor) Process(
@@ -117,6 +383,26 @@ func (cp *Processor) Process(
 	serviceConfig interfaces.Configuration,
 	secretProvider interfaces.SecretProviderExt) error {
 
+	// Tag the log messages with the service key and the operation being performed. The Processor's own logger is left
+	// untagged, so processing again doesn't accumulate the tags.
+	lc := utils.NewContextLogger(cp.lc, "service", serviceKey, "operation", "Process")
+	loadStarted := time.Now()
+
+	cp.serviceType = serviceType
+	cp.envVars.SetServiceType(serviceType)
+	if secretProvider != nil {
+		// Allows overrides to reference a secret rather than hold sensitive values in the environment
+		cp.envVars.SetSecretProvider(secretProvider)
+	}
+	cp.serviceConfig = serviceConfig
+	cp.configStem = configStem
+	cp.serviceKey = serviceKey
+	cp.baseKey = utils.BuildBaseKey(configStem, serviceKey)
+	cp.result = ProcessResult{}
+	cp.provenance = nil
+	if cp.trackProvenance {
+		cp.provenance = make(map[string]string)
+	}
 	cp.overwriteConfig = cp.flags.OverwriteConfig()
 	configProviderUrl := cp.flags.ConfigProviderUrl()
 
@@ -127,9 +413,12 @@ func (cp *Processor) Process(
 	}
 
 	useProvider := configProviderInfo.UseProvider()
+	if useProvider {
+		cp.result.ProviderUrl = redactUrl(configProviderInfo.ServiceConfig().GetUrl())
+	}
+	cp.result.SecurityEnabled = secret.IsSecurityEnabled()
 
 	var privateConfigClient configuration.Client
-	var privateServiceConfig interfaces.Configuration
 
 	if useProvider {
 		getAccessToken, err := cp.getAccessTokenCallback(serviceKey, secretProvider, err, configProviderInfo)
@@ -137,17 +426,32 @@ func (cp *Processor) Process(
 			return err
 		}
 
-		if err := cp.loadCommonConfig(configStem, getAccessToken, configProviderInfo, serviceConfig, serviceType, CreateProviderClient); err != nil {
+		createProvider := createProviderCallback(CreateProviderClient)
+		factory := container.ConfigClientFactoryFrom(cp.dic.Get)
+		if factory != nil {
+			lc.Info("Using the Configuration Provider client factory injected into the DIC")
+			createProvider = injectedProviderCallback(factory)
+		} else {
+			factory = providerClientFactory(lc, getAccessToken, configProviderInfo.ServiceConfig())
+		}
+		cp.configClientFactory = factory
+
+		commonStarted := time.Now()
+		if err := cp.loadCommonConfig(lc, configStem, getAccessToken, configProviderInfo, serviceConfig, serviceType, createProvider); err != nil {
 			return err
 		}
+		cp.metrics.commonConfigLoadDuration.Update(millisecondsSince(commonStarted))
+		cp.result.CommonSections = commonConfigSections(serviceType)
 
-		cp.lc.Info("Common configuration loaded from the Configuration Provider. No overrides applied")
+		lc.Info("Common configuration loaded from the Configuration Provider. No overrides applied")
 
-		privateConfigClient, err = CreateProviderClient(cp.lc, serviceKey, configStem, getAccessToken, configProviderInfo.ServiceConfig())
+		privateConfigClient, err = createProvider(lc, serviceKey, configStem, getAccessToken, configProviderInfo.ServiceConfig())
 		if err != nil {
-			return fmt.Errorf("failed to create Configuration Provider client: %s", err.Error())
+			return newProcessError(ErrProviderUnavailable, "failed to create Configuration Provider client: %w", err)
 		}
 
+		cp.privateConfigClient = privateConfigClient
+
 		// TODO: figure out what uses the dic - this will not have the common config info!!
 		// is this potentially custom config for app/device services?
 		cp.dic.Update(di.ServiceConstructorMap{
@@ -158,100 +462,119 @@ func (cp *Processor) Process(
 
 		cp.providerHasConfig, err = privateConfigClient.HasConfiguration()
 		if err != nil {
-			return fmt.Errorf("failed check for Configuration Provider has private configiuration: %s", err.Error())
+			return newProcessError(ErrProviderUnavailable, "failed check for Configuration Provider has private configiuration: %w", err)
 		}
 
-		if cp.providerHasConfig && !cp.overwriteConfig {
-			privateServiceConfig, err = copyConfigurationStruct(serviceConfig)
-			if err != nil {
-				return err
-			}
-			if err := cp.loadConfigFromProvider(privateServiceConfig, privateConfigClient); err != nil {
-				return err
-			}
-			configKeys, err := privateConfigClient.GetConfigurationKeys("")
-			if err != nil {
-				return err
-			}
-
-			// Must remove any settings in the config that are not actually present in the Config Provider
-			privateConfigKeys := utils.StringSliceToMap(configKeys)
-			privateConfigMap, err := utils.RemoveUnusedSettings(privateServiceConfig, utils.BuildBaseKey(configStem, serviceKey), privateConfigKeys)
-			if err != nil {
-				return fmt.Errorf("could not remove unused settings from private configurations: %s", err.Error())
-			}
-
-			// Now merge only the actual present value with the existing configuration from common.
-			if err := utils.MergeValues(serviceConfig, privateConfigMap); err != nil {
-				return fmt.Errorf("could not merge common and private configurations: %s", err.Error())
-			}
-
-			cp.lc.Info("Private configuration loaded from the Configuration Provider. No overrides applied")
-		}
 	} else {
 		// Now load common configuration from local file if not using config provider and -cc/--commonConfig flag is used.
 		// NOTE: Some security services don't use any common configuration and don't use the configuration provider.
-		commonConfigLocation := environment.GetCommonConfigFileName(cp.lc, cp.flags.CommonConfig())
+		commonConfigLocation := environment.GetCommonConfigFileName(lc, cp.flags.CommonConfig())
 		if commonConfigLocation != "" {
-			err := cp.loadCommonConfigFromFile(commonConfigLocation, serviceConfig, serviceType)
+			commonStarted := time.Now()
+			err := cp.loadCommonConfigFromFile(lc, commonConfigLocation, serviceConfig, serviceType)
 			if err != nil {
 				return err
 			}
+			cp.metrics.commonConfigLoadDuration.Update(millisecondsSince(commonStarted))
+			cp.result.CommonSections = commonConfigSections(serviceType)
 
 			overrideCount, err := cp.envVars.OverrideConfiguration(serviceConfig)
-			if err != nil {
+			if err := cp.checkRejectedOverrides(lc, err); err != nil {
 				return err
 			}
-			cp.lc.Infof("Common configuration loaded from file with %d overrides applied", overrideCount)
+			cp.result.CommonOverrideCount = overrideCount
+			if cp.trackProvenance {
+				overriddenPaths, err := cp.envVars.OverriddenPaths(serviceConfig)
+				if err != nil {
+					return err
+				}
+				cp.recordOverrideProvenance(overriddenPaths)
+			}
+			lc.Infof("Common configuration loaded from file with %d overrides applied", overrideCount)
 		}
 	}
 
-	// Now load the private config from a local file if any of these conditions are true
-	if !useProvider || !cp.providerHasConfig || cp.overwriteConfig {
-		filePath := GetConfigFileLocation(cp.lc, cp.flags)
-		configMap, err := cp.loadConfigYamlFromFile(filePath)
-		if err != nil {
-			return err
+	// The private config is loaded from the Configuration Provider if it already has it, otherwise from a local file
+	var privateSource ConfigSource
+	var fileSource *fileConfigSource
+	if useProvider && cp.providerHasConfig && !cp.overwriteConfig {
+		privateSource = &providerConfigSource{
+			cp:            cp,
+			lc:            lc,
+			serviceConfig: serviceConfig,
+			client:        privateConfigClient,
+			baseKey:       cp.baseKey,
 		}
+	} else {
+		fileSource = &fileConfigSource{cp: cp, lc: lc, serviceType: serviceType}
+		privateSource = fileSource
+	}
 
-		// apply overrides - Now only done when loaded from file and values will get pushed into Configuration Provider (if used)
-		overrideCount, err := cp.envVars.OverrideConfigMapValues(configMap)
-		if err != nil {
-			return err
-		}
-		cp.lc.Infof("Private configuration loaded from file with %d overrides applied", overrideCount)
+	// Keep the configuration the private configuration is merged over, so it can be reset to the file's defaults
+	cp.baseConfig, err = copyConfigurationStruct(serviceConfig)
+	if err != nil {
+		return err
+	}
 
-		if err := utils.MergeValues(serviceConfig, configMap); err != nil {
-			return err
-		}
+	if err := cp.loadConfigSources(lc, serviceConfig, privateSource); err != nil {
+		return err
+	}
 
-		if useProvider {
-			if err := privateConfigClient.PutConfigurationMap(configMap, cp.overwriteConfig); err != nil {
-				return fmt.Errorf("could not push private configuration into Configuration Provider: %s", err.Error())
+	if fileSource != nil && useProvider {
+		if cp.noProviderSeed() {
+			lc.Info("Private configuration loaded from file has not been pushed into Configuration Provider: seeding is disabled")
+		} else {
+			if err := cp.pushConfigurationMap(lc, privateConfigClient, fileSource.configMap, cp.overwriteConfig); err != nil {
+				return newProcessError(ErrProviderUnavailable, "could not push private configuration into Configuration Provider: %w", err)
 			}
 
-			cp.lc.Info("Private configuration has been pushed to into Configuration Provider with overrides applied")
+			lc.Info("Private configuration has been pushed to into Configuration Provider with overrides applied")
 		}
 	}
 
+	cp.metrics.configLoadDuration.Update(millisecondsSince(loadStarted))
+	lc.Debugf("Configuration loaded in %dms", cp.metrics.configLoadDuration.Value())
+
+	if err := cp.checkUnmatchedOverrides(serviceConfig); err != nil {
+		return err
+	}
+
+	// The secret references are resolved once the configuration has been fully merged, so they can come from any source
+	if err := cp.resolveSecretReferences(lc, serviceConfig, secretProvider); err != nil {
+		return err
+	}
+
+	// Now that the configuration has been fully merged, enforce any service specific invariants
+	if err := validateConfiguration("configuration", serviceConfig); err != nil {
+		return err
+	}
+	if _, ok := serviceConfig.(interfaces.Validator); ok {
+		lc.Debug("Configuration passed validation")
+	}
+
 	// listen for changes on Writable
 	if useProvider {
-		cp.listenForPrivateChanges(serviceConfig, privateConfigClient, utils.BuildBaseKey(configStem, serviceKey))
-		cp.lc.Infof("listening for private config changes")
+		cp.listenForPrivateChanges(serviceConfig, privateConfigClient, cp.baseKey)
+		lc.Infof("listening for private config changes")
 		cp.listenForCommonChanges(serviceConfig, cp.commonConfigClient, privateConfigClient, utils.BuildBaseKey(configStem, common.CoreCommonConfigServiceKey, allServicesKey))
-		cp.lc.Infof("listening for all services common config changes")
+		lc.Infof("listening for all services common config changes")
 		if cp.appConfigClient != nil {
 			cp.listenForCommonChanges(serviceConfig, cp.appConfigClient, privateConfigClient, utils.BuildBaseKey(configStem, common.CoreCommonConfigServiceKey, appServicesKey))
-			cp.lc.Infof("listening for application service common config changes")
+			lc.Infof("listening for application service common config changes")
 		}
 		if cp.deviceConfigClient != nil {
 			cp.listenForCommonChanges(serviceConfig, cp.deviceConfigClient, privateConfigClient, utils.BuildBaseKey(configStem, common.CoreCommonConfigServiceKey, deviceServicesKey))
-			cp.lc.Infof("listening for device service common config changes")
+			lc.Infof("listening for device service common config changes")
 		}
 	}
 
-	// Now that configuration has been loaded and overrides applied the log level can be set as configured.
-	err = cp.lc.SetLogLevel(serviceConfig.GetLogLevel())
+	// Now that configuration has been loaded and overrides applied the log level can be set as configured, unless
+	// it was specified on the command-line.
+	logLevel := serviceConfig.GetLogLevel()
+	if flagLogLevel := logLevelFlag(cp.flags); len(flagLogLevel) > 0 {
+		logLevel = flagLogLevel
+	}
+	err = lc.SetLogLevel(logLevel)
 
 	if cp.flags.InDevMode() {
 		// Dev mode is for when running service with Config Provider in hybrid mode (all other service running in Docker).
@@ -282,7 +605,105 @@ func (cp *Processor) Process(
 		}
 	}
 
-	return err
+	if err != nil {
+		return err
+	}
+
+	cp.completedMutex.Lock()
+	cp.bootstrapCompletedAt = time.Now()
+	cp.completedMutex.Unlock()
+	cp.registerMetrics(lc)
+	if watchers := cp.runningWatcherNames(); len(watchers) > 0 {
+		cp.result.Watchers = watchers
+	}
+	lc.Info("Configuration startup report", cp.result.LogFields()...)
+	lc.Info("Configuration processing completed")
+
+	return nil
+}
+
+// strictOverrides returns whether startup must fail when environment variable overrides don't match any setting or
+// the type of their setting, as specified by the --strictOverrides flag
+func (cp *Processor) strictOverrides() bool {
+	strict, ok := cp.flags.(flags.StrictOverridesOption)
+	return ok && strict.StrictOverrides()
+}
+
+// checkRejectedOverrides returns the error from applying the environment variable overrides, unless it only reports
+// overrides rejected due to their values not matching the types of their settings and strict overrides aren't enabled.
+// In which case, each rejected override is logged as a warning and the configuration is used without them.
+func (cp *Processor) checkRejectedOverrides(lc logger.LoggingClient, err error) error {
+	var rejectedErr *environment.OverrideRejectedError
+	if err == nil || !errors.As(err, &rejectedErr) || cp.strictOverrides() {
+		return err
+	}
+
+	for _, rejection := range rejectedErr.Rejections {
+		lc.Warnf("Environment variable override %s ignored since its value '%s' is not a valid %s",
+			rejection.Name, rejection.Value, rejection.TargetType)
+	}
+	return nil
+}
+
+// checkUnmatchedOverrides returns an error listing the environment variable overrides which don't match any setting
+// in the serviceConfig when strict overrides are enabled
+func (cp *Processor) checkUnmatchedOverrides(serviceConfig interfaces.Configuration) error {
+	if !cp.strictOverrides() {
+		return nil
+	}
+
+	unmatched, err := cp.envVars.UnmatchedOverrides(serviceConfig)
+	if err != nil {
+		return fmt.Errorf("unable to check for unmatched environment overrides: %s", err.Error())
+	}
+	if len(unmatched) > 0 {
+		return fmt.Errorf("environment variable override(s) %s don't match any configuration setting", strings.Join(unmatched, ", "))
+	}
+	return nil
+}
+
+// noProviderSeed returns whether the configuration loaded from file must not be pushed into the Configuration Provider,
+// as specified by the --noProviderSeed flag
+func (cp *Processor) noProviderSeed() bool {
+	seedOption, ok := cp.flags.(flags.ProviderSeedOption)
+	return ok && seedOption.NoProviderSeed()
+}
+
+// ConfigStem returns the configuration stem, i.e. "edgex/v3", passed to Process. Empty if Process has not been called.
+func (cp *Processor) ConfigStem() string {
+	return cp.configStem
+}
+
+// BaseKey returns the base key, i.e. "edgex/v3/core-data", of the service's private configuration in the
+// Configuration Provider, as used by Process. Empty if Process has not been called.
+func (cp *Processor) BaseKey() string {
+	return cp.baseKey
+}
+
+// logLevelFlag returns the log level specified on the command-line, if the flags support it and one was specified
+func logLevelFlag(f flags.Common) string {
+	if option, ok := f.(flags.LogLevelOption); ok {
+		return option.LogLevel()
+	}
+
+	return ""
+}
+
+// BootstrapCompletedAt returns the time at which Process last completed successfully, i.e. the configuration has been
+// loaded, the secrets are ready and the configuration watchers have been started. The zero time is returned if Process
+// has not yet completed successfully.
+func (cp *Processor) BootstrapCompletedAt() time.Time {
+	cp.completedMutex.RLock()
+	defer cp.completedMutex.RUnlock()
+
+	return cp.bootstrapCompletedAt
+}
+
+// CommonConfigReadyPath returns the full path of the key in the Configuration Provider which the common configuration
+// bootstrapper sets once it has loaded the common configuration, i.e. "edgex/v3/core-common-config-bootstrapper/IsCommonConfigReady",
+// which Process waits on before loading the common configuration. The configStem may have a trailing "/".
+func CommonConfigReadyPath(configStem string) string {
+	return utils.BuildBaseKey(strings.TrimSuffix(configStem, utils.PathSep), common.CoreCommonConfigServiceKey, config.CommonConfigDone)
 }
 
 type createProviderCallback func(
@@ -297,6 +718,7 @@ type createProviderCallback func(
 // - serviceTypeConfig: if the service is an app or device service, this will have the type specific common config
 // if there are separate configs, these will get merged into the serviceConfig
 func (cp *Processor) loadCommonConfig(
+	lc logger.LoggingClient,
 	configStem string,
 	getAccessToken types.GetAccessTokenCallback,
 	configProviderInfo *ProviderInfo,
@@ -308,134 +730,194 @@ func (cp *Processor) loadCommonConfig(
 	// check that common config is loaded into the provider
 	// this need a separate config provider client here because the config ready variable is stored at the common config level
 	// load the all services section of the common config
-	cp.commonConfigClient, err = createProvider(cp.lc, utils.BuildBaseKey(common.CoreCommonConfigServiceKey, allServicesKey), configStem, getAccessToken, configProviderInfo.ServiceConfig())
+	cp.commonConfigClient, err = createProvider(lc, utils.BuildBaseKey(common.CoreCommonConfigServiceKey, allServicesKey), configStem, getAccessToken, configProviderInfo.ServiceConfig())
 	if err != nil {
-		return fmt.Errorf("failed to create provider for %s: %s", allServicesKey, err.Error())
+		return newProcessError(ErrProviderUnavailable, "failed to create provider for %s: %w", allServicesKey, err)
 	}
-	// build the path for the common configuration ready value
-	commonConfigReadyPath := fmt.Sprintf("%s/%s/%s", configStem, common.CoreCommonConfigServiceKey, config.CommonConfigDone)
-	if err := cp.waitForCommonConfig(cp.commonConfigClient, commonConfigReadyPath); err != nil {
+	if err := cp.waitForCommonConfig(lc, cp.commonConfigClient, CommonConfigReadyPath(configStem)); err != nil {
 		return err
 	}
 	err = cp.loadConfigFromProvider(serviceConfig, cp.commonConfigClient)
 	if err != nil {
-		return fmt.Errorf("failed to load the common configuration for %s: %s", allServicesKey, err.Error())
+		return fmt.Errorf("failed to load the common configuration for %s: %w", allServicesKey, err)
+	}
+	if cp.trackProvenance {
+		if err := cp.recordProviderProvenance(ProvenanceCommon, serviceConfig, cp.commonConfigClient,
+			utils.BuildBaseKey(configStem, common.CoreCommonConfigServiceKey, allServicesKey)); err != nil {
+			return err
+		}
 	}
 
 	// use the service type to determine which additional sections to load into the common configuration
-	var serviceTypeConfig interfaces.Configuration
-	var serviceTypeConfigKeys []string
-	var serviceTypeSectionKey string
+	var serviceTypeConfigClient configuration.Client
+	var serviceTypeKey string
 
 	switch serviceType {
 	case config.ServiceTypeApp:
-		serviceTypeSectionKey = utils.BuildBaseKey(common.CoreCommonConfigServiceKey, appServicesKey)
-		cp.lc.Infof("loading the common configuration for service type %s", serviceType)
-		serviceTypeConfig, err = copyConfigurationStruct(serviceConfig)
-		if err != nil {
-			return fmt.Errorf("failed to copy the configuration structure for %s: %s", appServicesKey, err.Error())
-		}
-		cp.appConfigClient, err = createProvider(cp.lc, serviceTypeSectionKey, configStem, getAccessToken, configProviderInfo.ServiceConfig())
-		if err != nil {
-			return fmt.Errorf("failed to create provider for %s: %s", appServicesKey, err.Error())
-		}
-		err = cp.loadConfigFromProvider(serviceTypeConfig, cp.appConfigClient)
-		if err != nil {
-			return fmt.Errorf("failed to load the common configuration for %s: %s", appServicesKey, err.Error())
-		}
-		serviceTypeConfigKeys, err = cp.appConfigClient.GetConfigurationKeys("")
+		serviceTypeKey = appServicesKey
+		cp.appConfigClient, err = createProvider(lc, utils.BuildBaseKey(common.CoreCommonConfigServiceKey, appServicesKey), configStem, getAccessToken, configProviderInfo.ServiceConfig())
 		if err != nil {
-			return fmt.Errorf("failed to load the common configuration keys for %s: %s", deviceServicesKey, err.Error())
+			return newProcessError(ErrProviderUnavailable, "failed to create provider for %s: %w", appServicesKey, err)
 		}
+		serviceTypeConfigClient = cp.appConfigClient
 
 	case config.ServiceTypeDevice:
-		serviceTypeSectionKey = utils.BuildBaseKey(common.CoreCommonConfigServiceKey, deviceServicesKey)
-		cp.lc.Infof("loading the common configuration for service type %s", serviceType)
-		serviceTypeConfig, err = copyConfigurationStruct(serviceConfig)
-		if err != nil {
-			return fmt.Errorf("failed to copy the configuration structure for %s: %s", deviceServicesKey, err.Error())
-		}
-		cp.deviceConfigClient, err = createProvider(cp.lc, serviceTypeSectionKey, configStem, getAccessToken, configProviderInfo.ServiceConfig())
-		if err != nil {
-			return fmt.Errorf("failed to create provider for %s: %s", deviceServicesKey, err.Error())
-		}
-		err = cp.loadConfigFromProvider(serviceTypeConfig, cp.deviceConfigClient)
-		if err != nil {
-			return fmt.Errorf("failed to load the common configuration for %s: %s", deviceServicesKey, err.Error())
-		}
-		serviceTypeConfigKeys, err = cp.deviceConfigClient.GetConfigurationKeys("")
+		serviceTypeKey = deviceServicesKey
+		cp.deviceConfigClient, err = createProvider(lc, utils.BuildBaseKey(common.CoreCommonConfigServiceKey, deviceServicesKey), configStem, getAccessToken, configProviderInfo.ServiceConfig())
 		if err != nil {
-			return fmt.Errorf("failed to load the common configuration keys for %s: %s", deviceServicesKey, err.Error())
+			return newProcessError(ErrProviderUnavailable, "failed to create provider for %s: %w", deviceServicesKey, err)
 		}
+		serviceTypeConfigClient = cp.deviceConfigClient
 
 	default:
 		// this case is covered by the initial call to get the common config for all-services
 	}
 
 	// merge together the common config and the service type config
-	if serviceTypeConfig != nil {
-		// Must remove any settings in the config that are not actually present in the Config Provider
-		serviceTypeConfigMap, err := utils.RemoveUnusedSettings(serviceTypeConfig, utils.BuildBaseKey(configStem, serviceTypeSectionKey), utils.StringSliceToMap(serviceTypeConfigKeys))
+	if serviceTypeConfigClient != nil {
+		lc.Infof("loading the common configuration for service type %s", serviceType)
+		serviceTypeConfigMap, err := cp.loadServiceTypeCommonConfig(serviceConfig, serviceTypeConfigClient, configStem, serviceTypeKey)
 		if err != nil {
-			return fmt.Errorf("failed to remove unused setting from %s common config: %s", serviceType, err.Error())
+			return err
 		}
 
 		// merge common config and the service type common config's actually used settings
 		if err := utils.MergeValues(serviceConfig, serviceTypeConfigMap); err != nil {
-			return fmt.Errorf("failed to merge %s config with common config: %s", serviceType, err.Error())
+			return newProcessError(ErrMergeFailed, "failed to merge %s config with common config: %w", serviceType, err)
 		}
+		cp.recordProvenance(commonProvenanceLabel(serviceType), serviceTypeConfigMap)
 	}
 
 	return nil
 }
 
+// loadServiceTypeCommonConfig loads the service type's section of the common config, i.e. app-services, from the
+// Configuration Provider and returns only the settings actually present in it, so they can be merged over the all
+// services section
+func (cp *Processor) loadServiceTypeCommonConfig(
+	serviceConfig interfaces.Configuration,
+	configClient configuration.Client,
+	configStem string,
+	serviceTypeKey string) (map[string]any, error) {
+	serviceTypeConfig, err := copyConfigurationStruct(serviceConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to copy the configuration structure for %s: %s", serviceTypeKey, err.Error())
+	}
+
+	if err := cp.loadConfigFromProvider(serviceTypeConfig, configClient); err != nil {
+		return nil, fmt.Errorf("failed to load the common configuration for %s: %w", serviceTypeKey, err)
+	}
+
+	serviceTypeConfigKeys, err := configClient.GetConfigurationKeys("")
+	if err != nil {
+		return nil, newProcessError(ErrProviderUnavailable, "failed to load the common configuration keys for %s: %w", serviceTypeKey, err)
+	}
+
+	// Must remove any settings in the config that are not actually present in the Config Provider
+	serviceTypeSectionKey := utils.BuildBaseKey(configStem, common.CoreCommonConfigServiceKey, serviceTypeKey)
+	serviceTypeConfigMap, err := utils.RemoveUnusedSettings(serviceTypeConfig, serviceTypeSectionKey, utils.StringSliceToMap(serviceTypeConfigKeys))
+	if err != nil {
+		return nil, newProcessError(ErrMergeFailed, "failed to remove unused setting from %s common config: %w", serviceTypeKey, err)
+	}
+
+	return serviceTypeConfigMap, nil
+}
+
 // loadCommonConfigFromFile will pull up the common config from the provided file and load it into the passed in interface
 func (cp *Processor) loadCommonConfigFromFile(
+	lc logger.LoggingClient,
 	configFile string,
 	serviceConfig interfaces.Configuration,
 	serviceType string) error {
 
 	var err error
 
-	commonConfig, err := cp.loadConfigYamlFromFile(configFile)
+	commonConfig, err := cp.loadConfigYamlFromFile(lc, configFile)
 	if err != nil {
 		return err
 	}
 	// separate out the necessary sections
 	allServicesConfig, ok := commonConfig[allServicesKey].(map[string]any)
 	if !ok {
-		return fmt.Errorf("could not find %s section in common config %s", allServicesKey, configFile)
+		return newProcessError(ErrConfigParse, "could not find %s section in common config %s", allServicesKey, configFile)
 	}
 	// use the service type to separate out the necessary sections
 	var serviceTypeConfig map[string]any
 	switch serviceType {
 	case config.ServiceTypeApp:
-		cp.lc.Infof("loading the common configuration for service type %s", serviceType)
+		lc.Infof("loading the common configuration for service type %s", serviceType)
 		serviceTypeConfig, ok = commonConfig[appServicesKey].(map[string]any)
 		if !ok {
-			return fmt.Errorf("could not find %s section in common config %s", appServicesKey, configFile)
+			return newProcessError(ErrConfigParse, "could not find %s section in common config %s", appServicesKey, configFile)
 		}
 	case config.ServiceTypeDevice:
-		cp.lc.Infof("loading the common configuration for service type %s", serviceType)
+		lc.Infof("loading the common configuration for service type %s", serviceType)
 		serviceTypeConfig, ok = commonConfig[deviceServicesKey].(map[string]any)
 		if !ok {
-			return fmt.Errorf("could not find %s section in common config %s", deviceServicesKey, configFile)
+			return newProcessError(ErrConfigParse, "could not find %s section in common config %s", deviceServicesKey, configFile)
 		}
 	default:
 		// this case is covered by the initial call to get the common config for all-services
 	}
 
+	cp.recordProvenance(ProvenanceCommon, allServicesConfig)
 	if serviceType == config.ServiceTypeApp || serviceType == config.ServiceTypeDevice {
+		cp.recordProvenance(commonProvenanceLabel(serviceType), serviceTypeConfig)
 		utils.MergeMaps(allServicesConfig, serviceTypeConfig)
 	}
 
+	if missing := findMissingSections(allServicesConfig, cp.requiredCommonSections); len(missing) > 0 {
+		return newProcessError(ErrConfigParse, "common config %s is missing required section(s): %s", configFile, strings.Join(missing, ", "))
+	}
+
 	if err := utils.ConvertFromMap(allServicesConfig, serviceConfig); err != nil {
-		return fmt.Errorf("failed to convert common configuration into service's configuration: %v", err)
+		return newProcessError(ErrConfigParse, "failed to convert common configuration into service's configuration: %w", err)
 	}
 
 	return err
 }
 
+// commonConfigSections returns the sections of the common configuration used by the type of service
+func commonConfigSections(serviceType string) []string {
+	switch serviceType {
+	case config.ServiceTypeApp:
+		return []string{allServicesKey, appServicesKey}
+	case config.ServiceTypeDevice:
+		return []string{allServicesKey, deviceServicesKey}
+	default:
+		return []string{allServicesKey}
+	}
+}
+
+// findMissingSections returns the paths, using "/" as the separator, which don't exist in the configuration map
+func findMissingSections(configMap map[string]any, paths []string) []string {
+	var missing []string
+	for _, path := range paths {
+		if _, found := getMapValue(configMap, path); !found {
+			missing = append(missing, path)
+		}
+	}
+
+	return missing
+}
+
+// getMapValue returns the value at the path, using "/" as the separator, and whether it exists in the configuration map
+func getMapValue(configMap map[string]any, path string) (any, bool) {
+	var value any = configMap
+	for _, key := range strings.Split(path, "/") {
+		// A value rather than a map is only valid for the last key in the path
+		current, _ := value.(map[string]any)
+		item, exists := current[key]
+		if !exists {
+			return nil, false
+		}
+
+		value = item
+	}
+
+	return value, true
+}
+
 func (cp *Processor) getAccessTokenCallback(serviceKey string, secretProvider interfaces.SecretProviderExt, err error, configProviderInfo *ProviderInfo) (types.GetAccessTokenCallback, error) {
 	var accessToken string
 	var getAccessToken types.GetAccessTokenCallback
@@ -462,112 +944,215 @@ func (cp *Processor) getAccessTokenCallback(serviceKey string, secretProvider in
 	return getAccessToken, err
 }
 
+// injectedProviderCallback returns a createProviderCallback which creates the clients with the factory injected into
+// the DIC by container.InjectConfigClientFactory, for the same base paths as CreateProviderClient
+func injectedProviderCallback(factory container.ConfigClientFactory) createProviderCallback {
+	return func(_ logger.LoggingClient, serviceKey string, configStem string, _ types.GetAccessTokenCallback,
+		_ types.ServiceConfig) (configuration.Client, error) {
+		return factory(providerBasePath(configStem, serviceKey))
+	}
+}
+
+// SetServiceType sets the type of the service, which determines the default configuration file name when none is
+// specified. This is only needed for a Processor created by NewProcessorForCustomConfig, since Process sets it.
+func (cp *Processor) SetServiceType(serviceType string) {
+	cp.serviceType = serviceType
+}
+
+// SetOmitEmptyCustomConfig sets whether empty values, i.e. empty strings, zero numbers and nil maps, are omitted when
+// LoadCustomConfigSection seeds the Configuration Provider with the custom configuration. By default all values are pushed.
+func (cp *Processor) SetOmitEmptyCustomConfig(enabled bool) {
+	cp.omitEmptyCustom = enabled
+}
+
+// SetMaxConfigFileSize sets the maximum size, in bytes, of a configuration file that will be read. This guards against
+// a configuration path mis-mounted to a huge or device file. A size of zero or less uses DefaultMaxConfigFileSize.
+func (cp *Processor) SetMaxConfigFileSize(size int64) {
+	cp.maxConfigFileSize = size
+}
+
+// SetRequiredCommonConfigSections sets the paths of the sections, i.e. "Writable" or "Clients/core-metadata", that must
+// exist in the common configuration loaded from file. Paths are relative to the all-services section merged with the
+// service type's section. By default no sections are required.
+func (cp *Processor) SetRequiredCommonConfigSections(paths ...string) {
+	cp.requiredCommonSections = paths
+}
+
+// SetWatchedWritablePaths sets the paths of the Writable sub-sections, i.e. "Telemetry" or "InsecureSecrets/DB", the
+// service is interested in. Writable updates are still applied to the service's configuration, but the side effects
+// and configuration updated signal only occur when a value within one of these paths has changed. By default all
+// Writable updates are processed.
+func (cp *Processor) SetWatchedWritablePaths(paths ...string) {
+	cp.watchedWritablePaths = paths
+}
+
+// SetCommonConfigPollInterval sets the interval of the polling for the common configuration to be ready in the
+// Configuration Provider, which is independent of the polling for the Configuration Provider to be available. A random
+// jitter of up to the passed in jitter is added to each interval, so services restarted together don't all poll in
+// lockstep. An interval of zero or less uses the interval set by SetProviderLoadRetryBudget, or the startup timer's,
+// and a jitter of zero or less disables the jitter. By default the startup timer's interval and
+// DefaultCommonConfigPollJitter are used. The values specified on
+// the command-line, if any, take precedence.
+func (cp *Processor) SetCommonConfigPollInterval(interval time.Duration, jitter time.Duration) {
+	cp.commonPollInterval = interval
+	cp.commonPollJitter = jitter
+}
+
+// SetFileLoadRetryBudget sets the time the loading of each configuration file is retried for, at the interval, when it
+// fails with a transient I/O error, i.e. the file not existing yet while its volume is still being mounted. The budget
+// is independent of the Configuration Provider's, so a slow filesystem doesn't starve the loading from the
+// Configuration Provider. An interval of zero or less uses the startup timer's interval. By default the loading of the
+// configuration files isn't retried.
+func (cp *Processor) SetFileLoadRetryBudget(timeout time.Duration, interval time.Duration) {
+	cp.fileLoadTimeout = timeout
+	cp.fileLoadRetryInterval = interval
+}
+
+// SetProviderLoadRetryBudget sets the time the waiting for the Configuration Provider to be available, and for the
+// common configuration to be ready in it, is retried for, at the interval, independent of the retry budget of the
+// configuration files. A timeout or interval of zero or less uses the startup timer's. By default the startup timer is
+// used, which has been running since the service started.
+func (cp *Processor) SetProviderLoadRetryBudget(timeout time.Duration, interval time.Duration) {
+	cp.providerLoadTimeout = timeout
+	cp.providerRetryInterval = interval
+}
+
+// SetKeepUnknownSettings sets whether the private settings in the Configuration Provider that the service's
+// configuration struct doesn't have, i.e. those added by a newer version of the service during a rolling upgrade, are
+// kept when the configuration is loaded from the Configuration Provider. They are available from UnknownSettings and
+// are never removed from or written back to the Configuration Provider. By default they are ignored.
+func (cp *Processor) SetKeepUnknownSettings(enabled bool) {
+	cp.keepUnknownSettings = enabled
+}
+
+// UnknownSettings returns the raw values of the private settings kept from the Configuration Provider when enabled by
+// SetKeepUnknownSettings, keyed by their path relative to the service's base key, i.e. "Writable/NewSetting".
+func (cp *Processor) UnknownSettings() map[string]string {
+	settings := make(map[string]string, len(cp.unknownSettings))
+	for path, value := range cp.unknownSettings {
+		settings[path] = value
+	}
+	return settings
+}
+
 // LoadCustomConfigSection loads the specified custom configuration section from file or Configuration provider.
 // Section will be seed if Configuration provider does yet have it. This is used for structures custom configuration
 // in App and Device services
 func (cp *Processor) LoadCustomConfigSection(updatableConfig interfaces.UpdatableConfig, sectionName string) error {
+	lc := cp.operationLogger("LoadCustomConfigSection", "section", sectionName)
+
 	if cp.envVars == nil {
-		cp.envVars = environment.NewVariables(cp.lc)
+		cp.envVars = environment.NewVariables(lc)
+		cp.envVars.SetServiceType(cp.serviceType)
 	}
 
 	configClient := container.ConfigClientFrom(cp.dic.Get)
 	if configClient == nil {
-		cp.lc.Info("Skipping use of Configuration Provider for custom configuration: Provider not available")
-		filePath := GetConfigFileLocation(cp.lc, cp.flags)
-		configMap, err := cp.loadConfigYamlFromFile(filePath)
+		lc.Info("Skipping use of Configuration Provider for custom configuration: Provider not available")
+		configMap, err := cp.loadPrivateConfigFile(lc, cp.serviceType)
 		if err != nil {
 			return err
 		}
 
 		err = utils.ConvertFromMap(configMap, updatableConfig)
 		if err != nil {
-			return fmt.Errorf("failed to convert custom configuration into service's configuration: %v", err)
+			return newProcessError(ErrConfigParse, "failed to convert custom configuration into service's configuration: %w", err)
 		}
 	} else {
-		cp.lc.Infof("Checking if custom configuration ('%s') exists in Configuration Provider", sectionName)
+		lc.Infof("Checking if custom configuration ('%s') exists in Configuration Provider", sectionName)
 
 		exists, err := configClient.HasSubConfiguration(sectionName)
 		if err != nil {
-			return fmt.Errorf(
-				"unable to determine if custom configuration exists in Configuration Provider: %s",
-				err.Error())
+			return newProcessError(ErrProviderUnavailable,
+				"unable to determine if custom configuration exists in Configuration Provider: %w", err)
 		}
 
 		if exists && !cp.flags.OverwriteConfig() {
 			rawConfig, err := configClient.GetConfiguration(updatableConfig)
 			if err != nil {
-				return fmt.Errorf(
-					"unable to get custom configuration from Configuration Provider: %s", err.Error())
+				return newProcessError(ErrProviderUnavailable,
+					"unable to get custom configuration from Configuration Provider: %w", err)
 			}
 
 			err = utils.MergeValues(updatableConfig, rawConfig)
 			if err != nil {
-				return fmt.Errorf("unable to merge custom configuration from Configuration Provider")
+				return newProcessError(ErrMergeFailed, "unable to merge custom configuration from Configuration Provider: %w", err)
 			}
 
-			cp.lc.Info("Loaded custom configuration from Configuration Provider, no overrides applied")
+			lc.Info("Loaded custom configuration from Configuration Provider, no overrides applied")
 		} else {
-			filePath := GetConfigFileLocation(cp.lc, cp.flags)
-			configMap, err := cp.loadConfigYamlFromFile(filePath)
+			configMap, err := cp.loadPrivateConfigFile(lc, cp.serviceType)
 			if err != nil {
 				return err
 			}
 
 			if err := utils.MergeValues(updatableConfig, configMap); err != nil {
-				return err
+				return newProcessError(ErrMergeFailed, "unable to merge custom configuration from file: %w", err)
 			}
 
 			// Must apply override before pushing into Configuration Provider
 			overrideCount, err := cp.envVars.OverrideConfiguration(updatableConfig)
-			if err != nil {
+			if err := cp.checkRejectedOverrides(lc, err); err != nil {
 				return fmt.Errorf("unable to apply environment overrides: %s", err.Error())
 			}
 
-			cp.lc.Infof("Loaded custom configuration from File (%d envVars overrides applied)", overrideCount)
+			lc.Infof("Loaded custom configuration from File (%d envVars overrides applied)", overrideCount)
+
+			if cp.noProviderSeed() {
+				lc.Info("Custom configuration loaded from file has not been pushed into Configuration Provider: seeding is disabled")
+				return nil
+			}
 
 			mapToPush := make(map[string]any)
-			err = utils.ConvertToMap(updatableConfig, &mapToPush)
+			if cp.omitEmptyCustom {
+				err = utils.ConvertToMapOmitEmpty(updatableConfig, &mapToPush)
+			} else {
+				err = utils.ConvertToMap(updatableConfig, &mapToPush)
+			}
 			if err != nil {
 				return err
 			}
 
-			err = configClient.PutConfigurationMap(mapToPush, true)
+			err = cp.pushConfigurationMap(lc, configClient, mapToPush, true)
 			if err != nil {
-				return fmt.Errorf("error pushing custom config to Configuration Provider: %s", err.Error())
+				return newProcessError(ErrProviderUnavailable, "error pushing custom config to Configuration Provider: %w", err)
 			}
 
 			var overwriteMessage = ""
 			if exists && cp.flags.OverwriteConfig() {
 				overwriteMessage = "(overwritten)"
 			}
-			cp.lc.Infof("Custom Config loaded from file and pushed to Configuration Provider %s", overwriteMessage)
+			lc.Infof("Custom Config loaded from file and pushed to Configuration Provider %s", overwriteMessage)
 		}
 	}
 
 	return nil
 }
 
+// StopFunc stops a single configuration watcher. It may be called more than once.
+type StopFunc func()
+
 // ListenForCustomConfigChanges listens for changes to the specified custom configuration section. When changes occur it
-// applies the changes to the custom configuration section and signals the changes have occurred.
+// applies the changes to the custom configuration section and signals the changes have occurred. Each section is
+// watched with its own Configuration Provider client, so the returned StopFunc stops that client watching for changes
+// to just this section, while the other watchers keep running. changedCallback is called
+// one update at a time, in the order received, with the other configuration updates (see SetUpdateQueueSize).
 func (cp *Processor) ListenForCustomConfigChanges(
 	configToWatch any,
 	sectionName string,
-	changedCallback func(any)) {
-	configClient := container.ConfigClientFrom(cp.dic.Get)
-	if configClient == nil {
-		cp.lc.Warnf("unable to watch custom configuration for changes: Configuration Provider not enabled")
-		return
+	changedCallback func(any)) StopFunc {
+	lc := cp.operationLogger("WatchCustomConfig", "section", sectionName)
+	configClient, err := cp.newWatchClient()
+	if err != nil {
+		lc.Warnf("unable to watch custom configuration for changes: %v", err)
+		return func() {}
 	}
 
-	cp.wg.Add(1)
-	go func() {
-		defer cp.wg.Done()
+	watchCtx, stopWatch := context.WithCancel(cp.ctx)
 
+	cp.startWatcher(fmt.Sprintf("custom '%s'", sectionName), func() {
 		errorStream := make(chan error)
-		defer close(errorStream)
-
 		updateStream := make(chan any)
-		defer close(updateStream)
 
 		configClient.WatchForChanges(updateStream, errorStream, configToWatch, sectionName)
 
@@ -575,13 +1160,13 @@ func (cp *Processor) ListenForCustomConfigChanges(
 
 		for {
 			select {
-			case <-cp.ctx.Done():
-				configClient.StopWatching()
-				cp.lc.Infof("Watching for '%s' configuration changes has stopped", sectionName)
+			case <-watchCtx.Done():
+				lc.Infof("Watching for '%s' configuration changes has stopped", sectionName)
+				stopWatchStreams(configClient, updateStream, errorStream)
 				return
 
 			case ex := <-errorStream:
-				cp.lc.Error(ex.Error())
+				lc.Error(ex.Error())
 
 			case raw := <-updateStream:
 				// Config Provider sends an update as soon as the watcher is connected even though there are not
@@ -592,13 +1177,131 @@ func (cp *Processor) ListenForCustomConfigChanges(
 					continue
 				}
 
-				cp.lc.Infof("Updated custom configuration '%s' has been received from the Configuration Provider", sectionName)
-				changedCallback(raw)
+				lc.Infof("Updated custom configuration '%s' has been received from the Configuration Provider", sectionName)
+				cp.queueUpdate(lc, fmt.Sprintf("custom '%s'", sectionName), func() {
+					changedCallback(raw)
+				})
 			}
 		}
-	}()
+	})
+
+	lc.Infof("Watching for custom configuration changes has started for `%s`", sectionName)
+
+	return StopFunc(stopWatch)
+}
+
+// newWatchClient creates a dedicated Configuration Provider client rooted at the service's base path for a watcher, so
+// the watcher can stop watching without stopping the other watchers. The clients are created with the factory used by
+// Process, or the one injected into the DIC when Process hasn't used the Configuration Provider.
+func (cp *Processor) newWatchClient() (configuration.Client, error) {
+	factory := cp.configClientFactory
+	if factory == nil {
+		factory = container.ConfigClientFactoryFrom(cp.dic.Get)
+	}
+	if factory == nil || len(cp.configStem) == 0 {
+		return nil, errors.New("Configuration Provider not enabled")
+	}
+
+	return factory(providerBasePath(cp.configStem, cp.serviceKey))
+}
+
+// stopWatchStreams stops the client watching for changes and then closes the streams it was sending to
+func stopWatchStreams(configClient configuration.Client, updateStream chan any, errorStream chan error) {
+	configClient.StopWatching()
+	close(updateStream)
+	close(errorStream)
+}
+
+// PutConfigurationValue writes a single value to the Configuration Provider at the specified path, which is relative
+// to the service's base path, i.e. "Writable/LogLevel". Unlike PutConfigurationMap only the target key is written,
+// so changes made elsewhere in the configuration tree are not clobbered. The value is read back after writing to
+// verify it was stored. Only scalar values are supported, use PutConfigurationMap for writing whole sections.
+func (cp *Processor) PutConfigurationValue(path string, value any) error {
+	configClient := container.ConfigClientFrom(cp.dic.Get)
+	if configClient == nil {
+		return errors.New("unable to put configuration value: Configuration Provider not available")
+	}
+
+	if len(path) == 0 {
+		return errors.New("unable to put configuration value: path must not be empty")
+	}
+
+	encoded, err := encodeConfigurationValue(value)
+	if err != nil {
+		return fmt.Errorf("unable to put configuration value for '%s': %v", path, err)
+	}
+
+	if err := configClient.PutConfigurationValue(path, []byte(encoded)); err != nil {
+		return fmt.Errorf("unable to put configuration value for '%s': %v", path, err)
+	}
+
+	stored, err := configClient.GetConfigurationValue(path)
+	if err != nil {
+		return fmt.Errorf("unable to read back configuration value for '%s': %v", path, err)
+	}
+
+	if string(stored) != encoded {
+		return fmt.Errorf("configuration value read back for '%s' doesn't match value written: expected '%s', got '%s'",
+			path, encoded, string(stored))
+	}
+
+	cp.lc.Debugf("Configuration value for '%s' written to Configuration Provider", path)
+
+	return nil
+}
+
+// HasWritableConfig returns whether the service's Writable section exists in the Configuration Provider, i.e. so a
+// service can confirm it's present before watching it for changes. An error wrapping ErrProviderUnavailable is
+// returned when no Configuration Provider is configured or it can't be queried.
+func (cp *Processor) HasWritableConfig() (bool, error) {
+	configClient := container.ConfigClientFrom(cp.dic.Get)
+	if configClient == nil {
+		return false, newProcessError(ErrProviderUnavailable,
+			"unable to check for Writable configuration: no Configuration Provider is configured")
+	}
 
-	cp.lc.Infof("Watching for custom configuration changes has started for `%s`", sectionName)
+	exists, err := configClient.HasSubConfiguration(writableKey)
+	if err != nil {
+		return false, newProcessError(ErrProviderUnavailable,
+			"unable to check for Writable configuration in Configuration Provider: %w", err)
+	}
+
+	return exists, nil
+}
+
+// encodeConfigurationValue encodes a scalar value the same way the Configuration Provider client encodes the values
+// when pushing a configuration map.
+func encodeConfigurationValue(value any) (string, error) {
+	if value == nil {
+		return "", nil
+	}
+
+	switch typed := value.(type) {
+	case string:
+		return typed, nil
+	case []byte:
+		return string(typed), nil
+	case fmt.Stringer:
+		return typed.String(), nil
+	}
+
+	reflected := reflect.ValueOf(value)
+	switch reflected.Kind() {
+	case reflect.String:
+		return reflected.String(), nil
+	case reflect.Bool:
+		return strconv.FormatBool(reflected.Bool()), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(reflected.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(reflected.Uint(), 10), nil
+	case reflect.Float32:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, 32), nil
+	case reflect.Float64:
+		return strconv.FormatFloat(reflected.Float(), 'f', -1, 64), nil
+	default:
+		return "", fmt.Errorf("unsupported value type '%T', only scalar values are supported", value)
+	}
 }
 
 // CreateProviderClient creates and returns a configuration.Client instance and logs Client connection information
@@ -609,130 +1312,279 @@ func CreateProviderClient(
 	getAccessToken types.GetAccessTokenCallback,
 	providerConfig types.ServiceConfig) (configuration.Client, error) {
 
-	var err error
+	return providerClientFactory(lc, getAccessToken, providerConfig)(providerBasePath(configStem, serviceKey))
+}
+
+// providerClientFactory returns a ConfigClientFactory which creates the Configuration Provider clients for the
+// providerConfig rooted at the base path passed to it
+func providerClientFactory(
+	lc logger.LoggingClient,
+	getAccessToken types.GetAccessTokenCallback,
+	providerConfig types.ServiceConfig) container.ConfigClientFactory {
+	return func(basePath string) (configuration.Client, error) {
+		var err error
+
+		providerConfig.BasePath = basePath
+		if getAccessToken != nil {
+			providerConfig.AccessToken, err = getAccessToken()
+			if err != nil {
+				return nil, err
+			}
+			providerConfig.GetAccessToken = getAccessToken
+		}
 
+		lc.Info(fmt.Sprintf(
+			"Using Configuration provider (%s) from: %s with base path of %s",
+			providerConfig.Type,
+			providerConfig.GetUrl(),
+			providerConfig.BasePath))
+
+		return configuration.NewConfigurationClient(providerConfig)
+	}
+}
+
+// providerBasePath returns the base path in the Configuration Provider of the configuration for the serviceKey
+func providerBasePath(configStem string, serviceKey string) string {
 	// The passed in configStem already contains the trailing '/' in most cases so must verify and add if missing.
 	if configStem[len(configStem)-1] != '/' {
 		configStem = configStem + "/"
 	}
 
 	// Note: Can't use filepath.Join as it uses `\` on Windows which Consul doesn't recognize as a path separator.
-	providerConfig.BasePath = fmt.Sprintf("%s%s", configStem, serviceKey)
-	if getAccessToken != nil {
-		providerConfig.AccessToken, err = getAccessToken()
-		if err != nil {
-			return nil, err
-		}
-		providerConfig.GetAccessToken = getAccessToken
-	}
-
-	lc.Info(fmt.Sprintf(
-		"Using Configuration provider (%s) from: %s with base path of %s",
-		providerConfig.Type,
-		providerConfig.GetUrl(),
-		providerConfig.BasePath))
-
-	return configuration.NewConfigurationClient(providerConfig)
+	return fmt.Sprintf("%s%s", configStem, serviceKey)
 }
 
-// loadConfigYamlFromFile attempts to read the specified configuration yaml file
-func (cp *Processor) loadConfigYamlFromFile(yamlFile string) (map[string]any, error) {
-	cp.lc.Infof("Loading configuration file from %s", yamlFile)
-	contents, err := os.ReadFile(yamlFile)
+// LoadConfigFile reads and parses the specified configuration file, in the same way as the service's configuration
+// file, returning the raw contents of the file along with the parsed map, so callers can compute a content hash of the
+// file for change detection or caching. The file is limited to DefaultMaxConfigFileSize.
+func LoadConfigFile(path string) ([]byte, map[string]any, error) {
+	var contents bytes.Buffer
+	data, err := decodeConfigFile(path, DefaultMaxConfigFileSize, &contents)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read configuration file %s: %s", yamlFile, err.Error())
+		return nil, nil, err
 	}
 
-	data := make(map[string]any)
+	return contents.Bytes(), data, nil
+}
 
-	err = yaml.Unmarshal(contents, &data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to unmarshall configuration file %s: %s", yamlFile, err.Error())
+// loadConfigYamlFromFile attempts to read the specified configuration yaml file, decoding it as it is read rather than
+// reading it in full first. Anchors, aliases and merge keys are resolved by the decoder, so each alias in the returned
+// map is a separate copy of the concrete anchored values.
+func (cp *Processor) loadConfigYamlFromFile(lc logger.LoggingClient, yamlFile string) (map[string]any, error) {
+	lc.Infof("Loading configuration file from %s", yamlFile)
+	started := time.Now()
+	defer func() {
+		cp.metrics.configFileLoadDuration.Update(millisecondsSince(started))
+	}()
+
+	interval := cp.fileLoadRetryInterval
+	if interval <= 0 {
+		interval = cp.startupTimer.Interval()
 	}
-	return data, nil
+	timer := startup.NewDurationTimer(cp.fileLoadTimeout, interval)
+	for {
+		data, err := decodeConfigFile(yamlFile, cp.maxConfigFileSize, nil)
+		if err == nil || !isTransientFileError(err) || !timer.HasNotElapsed() {
+			return data, err
+		}
+
+		lc.Warnf("Retrying loading configuration file %s in %s: %s", yamlFile, interval, err.Error())
+		select {
+		case <-cp.ctx.Done():
+			return nil, err
+		case <-time.After(interval):
+		}
+	}
+}
+
+// isTransientFileError returns whether the error from loading a configuration file may be resolved by retrying, i.e.
+// the file not existing yet while its volume is still being mounted
+func isTransientFileError(err error) bool {
+	return errors.Is(err, fs.ErrNotExist) || errors.Is(err, syscall.ESTALE) || errors.Is(err, syscall.EIO) ||
+		errors.Is(err, syscall.EAGAIN) || errors.Is(err, syscall.EINTR)
 }
 
 // GetConfigFileLocation uses the environment variables and flags to determine the location of the configuration
 func GetConfigFileLocation(lc logger.LoggingClient, flags flags.Common) string {
+	return GetConfigFileLocationForServiceType(lc, flags, config.ServiceTypeOther)
+}
+
+// GetConfigFileLocationForServiceType uses the environment variables and flags to determine the location of the
+// configuration. When the file name is not specified by either, the default name for the serviceType is used.
+func GetConfigFileLocationForServiceType(lc logger.LoggingClient, flags flags.Common, serviceType string) string {
 	configDir := environment.GetConfigDir(lc, flags.ConfigDirectory())
 	profileDir := environment.GetProfileDir(lc, flags.Profile())
-	configFileName := environment.GetConfigFileName(lc, flags.ConfigFileName())
+	configFileName := getConfigFileName(lc, flags, serviceType)
 
 	return filepath.Join(configDir, profileDir, configFileName)
 }
 
+// getConfigFileName uses the environment variables and flags to determine the name of the configuration file.
+// When the name is not specified by either, the default name for the service type is used.
+func getConfigFileName(lc logger.LoggingClient, commonFlags flags.Common, serviceType string) string {
+	configFileName := commonFlags.ConfigFileName()
+
+	specified := configFileName != flags.DefaultConfigFile
+	if specifier, ok := commonFlags.(flags.ConfigFileSpecifier); ok {
+		specified = specifier.ConfigFileNameSpecified()
+	}
+
+	if !specified {
+		configFileName = defaultConfigFileName(serviceType)
+	}
+
+	return environment.GetConfigFileName(lc, configFileName)
+}
+
+// defaultConfigFileName returns the configuration file name used by the service type when none is specified
+func defaultConfigFileName(serviceType string) string {
+	switch serviceType {
+	case config.ServiceTypeApp:
+		return flags.DefaultAppConfigFile
+	case config.ServiceTypeDevice:
+		return flags.DefaultDeviceConfigFile
+	default:
+		return flags.DefaultConfigFile
+	}
+}
+
 // listenForPrivateChanges leverages the Configuration Provider client's WatchForChanges() method to receive changes to and update the
 // service's configuration writable sub-struct.  It's assumed the log level is universally part of the
 // writable struct and this function explicitly updates the loggingClient's log level when new configuration changes
 // are received.
 func (cp *Processor) listenForPrivateChanges(serviceConfig interfaces.Configuration, configClient configuration.Client, baseKey string) {
-	lc := cp.lc
-	isFirstUpdate := true
+	lc := cp.operationLogger("WatchPrivateConfig")
 
-	cp.wg.Add(1)
-	go func() {
-		defer cp.wg.Done()
+	cp.connectionMutex.Lock()
+	cp.reconnectRefresh = func() error {
+		return cp.refreshPrivateWritable(lc, serviceConfig, configClient, baseKey)
+	}
+	cp.connectionMutex.Unlock()
 
-		errorStream := make(chan error)
-		defer close(errorStream)
+	cp.startWatcher(fmt.Sprintf("private %s", utils.BuildBaseKey(baseKey, writableKey)), func() {
+		initialRetryInterval := cp.watchRetryInterval
+		if initialRetryInterval <= 0 {
+			initialRetryInterval = defaultWatchRetryInterval
+		}
 
-		updateStream := make(chan any)
-		defer close(updateStream)
+		retryInterval := initialRetryInterval
+		resync := false
+		for {
+			interrupted, receivedUpdates := cp.watchPrivateChanges(lc, serviceConfig, configClient, baseKey, resync)
+			if !interrupted {
+				return
+			}
+
+			// Only back off further when the previous attempt never received any updates
+			if receivedUpdates {
+				retryInterval = initialRetryInterval
+			}
 
-		go configClient.WatchForChanges(updateStream, errorStream, serviceConfig.EmptyWritablePtr(), writableKey)
+			lc.Warnf("Watching for '%s' configuration changes was interrupted. Re-establishing the watch in %s", writableKey, retryInterval)
 
-		for {
 			select {
 			case <-cp.ctx.Done():
 				configClient.StopWatching()
 				lc.Infof("Watching for '%s' configuration changes has stopped", writableKey)
 				return
+			case <-time.After(retryInterval):
+			}
 
-			case ex := <-errorStream:
-				lc.Errorf("error occurred during listening to the configuration changes: %s", ex.Error())
+			retryInterval *= 2
+			if retryInterval > maxWatchRetryInterval {
+				retryInterval = maxWatchRetryInterval
+			}
 
-			case raw, ok := <-updateStream:
-				if !ok {
-					return
-				}
+			// The writable section must be re-synced once the watch is re-established, since changes may have been
+			// missed while it was down.
+			resync = true
+		}
+	})
+}
 
-				usedKeys, err := configClient.GetConfigurationKeys(writableKey)
-				if err != nil {
-					lc.Errorf("failed to get list of private configuration keys for %s: %v", writableKey, err)
-				}
+// watchPrivateChanges watches the private writable section until the context is done or the Configuration Provider
+// closes the update stream, in which case interrupted is true so the watch can be re-established. When resync is true
+// the first update re-syncs the writable section rather than being ignored.
+func (cp *Processor) watchPrivateChanges(
+	lc logger.LoggingClient,
+	serviceConfig interfaces.Configuration,
+	configClient configuration.Client,
+	baseKey string,
+	resync bool) (interrupted bool, receivedUpdates bool) {
+	isFirstUpdate := true
 
-				rawMap, err := utils.RemoveUnusedSettings(raw, utils.BuildBaseKey(baseKey, writableKey), utils.StringSliceToMap(usedKeys))
-				if err != nil {
-					lc.Errorf("failed to remove unused private settings in %s: %v", writableKey, err)
-				}
+	errorStream := make(chan error)
+	updateStream := make(chan any)
 
-				// Config Provider sends an update as soon as the watcher is connected even though there are not
-				// any changes to the configuration. This causes an issue during start-up if there is an
-				// envVars override of one of the Writable fields, so we must ignore the first update.
-				if isFirstUpdate {
-					isFirstUpdate = false
-					continue
+	go configClient.WatchForChanges(updateStream, errorStream, serviceConfig.EmptyWritablePtr(), writableKey)
+
+	for {
+		select {
+		case <-cp.ctx.Done():
+			configClient.StopWatching()
+			close(errorStream)
+			close(updateStream)
+			lc.Infof("Watching for '%s' configuration changes has stopped", writableKey)
+			return false, receivedUpdates
+
+		case ex := <-errorStream:
+			lc.Errorf("error occurred during listening to the configuration changes: %s", ex.Error())
+			cp.reportWatcherError(lc, configClient)
+
+		case raw, ok := <-updateStream:
+			if !ok {
+				// The error stream is left open since the dropped watch may still report an error on it
+				lc.Errorf("Configuration Provider closed the watch for '%s' configuration changes", writableKey)
+				return true, receivedUpdates
+			}
+
+			receivedUpdates = true
+			cp.reportConnected(lc)
+
+			if isFirstUpdate && resync {
+				isFirstUpdate = false
+				lc.Infof("Watch for '%s' configuration changes re-established. Re-syncing configuration", writableKey)
+				if err := cp.refreshPrivateWritable(lc, serviceConfig, configClient, baseKey); err != nil {
+					lc.Errorf("failed to re-sync Writable configuration after re-establishing the watch: %v", err)
 				}
-				cp.applyWritableUpdates(serviceConfig, rawMap)
+				continue
 			}
+
+			usedKeys, err := configClient.GetConfigurationKeys(writableKey)
+			if err != nil {
+				lc.Errorf("failed to get list of private configuration keys for %s: %v", writableKey, err)
+			}
+
+			rawMap, err := utils.RemoveUnusedSettings(raw, utils.BuildBaseKey(baseKey, writableKey), utils.StringSliceToMap(usedKeys))
+			if err != nil {
+				lc.Errorf("failed to remove unused private settings in %s: %v", writableKey, err)
+			}
+
+			// Config Provider sends an update as soon as the watcher is connected even though there are not
+			// any changes to the configuration. This causes an issue during start-up if there is an
+			// envVars override of one of the Writable fields, so we must ignore the first update.
+			if isFirstUpdate {
+				isFirstUpdate = false
+				continue
+			}
+			cp.queueUpdate(lc, "private", func() {
+				cp.applyWritableUpdates(lc, serviceConfig, rawMap, "private")
+			})
 		}
-	}()
+	}
 }
 
 // listenForCommonChanges leverages the Configuration Provider client's WatchForChanges() method to receive changes to and update the
 // service's common configuration writable sub-struct.
 func (cp *Processor) listenForCommonChanges(fullServiceConfig interfaces.Configuration, commonConfigClient configuration.Client,
 	privateConfigClient configuration.Client, baseKey string) {
-	lc := cp.lc
+	lc := cp.operationLogger("WatchCommonConfig")
 	isFirstUpdate := true
 	baseKey = utils.BuildBaseKey(baseKey, writableKey)
 
-	cp.wg.Add(1)
-	go func(fullServiceConfig interfaces.Configuration,
-		commonConfigClient configuration.Client,
-		privateConfigClient configuration.Client, baseKey string) {
-		defer cp.wg.Done()
-
+	cp.startWatcher(fmt.Sprintf("common %s", baseKey), func() {
+		// previousCommonWritable is only updated by the queued updates once the first update has been received, so
+		// updates coalesced while queued are compared with the last common writable applied
 		var previousCommonWritable any
 
 		errorStream := make(chan error)
@@ -752,17 +1604,18 @@ func (cp *Processor) listenForCommonChanges(fullServiceConfig interfaces.Configu
 
 			case ex := <-errorStream:
 				lc.Errorf("error occurred during listening to the configuration changes: %s", ex.Error())
+				cp.reportWatcherError(lc, commonConfigClient)
 
 			case raw, ok := <-updateStream:
 				if !ok {
 					return
 				}
 
+				cp.reportConnected(lc)
+
 				usedKeys, err := commonConfigClient.GetConfigurationKeys(writableKey)
 				if err != nil {
-					if err != nil {
-						lc.Errorf("failed to get list of common configuration keys for %s: %v", writableKey, err)
-					}
+					lc.Errorf("failed to get list of common configuration keys for %s: %v", writableKey, err)
 				}
 
 				rawMap, err := utils.RemoveUnusedSettings(raw, baseKey, utils.StringSliceToMap(usedKeys))
@@ -780,65 +1633,101 @@ func (cp *Processor) listenForCommonChanges(fullServiceConfig interfaces.Configu
 					continue
 				}
 
-				if err := cp.processCommonConfigChange(fullServiceConfig, previousCommonWritable, rawMap, privateConfigClient); err != nil {
-					lc.Error(err.Error())
-				}
+				cp.queueUpdate(lc, "common", func() {
+					if err := cp.processCommonConfigChange(lc, fullServiceConfig, previousCommonWritable, rawMap, privateConfigClient); err != nil {
+						lc.Error(err.Error())
+					}
 
-				// ensure that the local copy of the common writable gets updated no matter what
-				previousCommonWritable = raw
+					// ensure that the local copy of the common writable gets updated no matter what
+					previousCommonWritable = rawMap
+				})
 			}
 		}
-	}(fullServiceConfig, commonConfigClient, privateConfigClient, baseKey)
+	})
 }
 
-func (cp *Processor) processCommonConfigChange(fullServiceConfig interfaces.Configuration, previousCommonWritable any, raw any, privateConfigClient configuration.Client) error {
+func (cp *Processor) processCommonConfigChange(lc logger.LoggingClient, fullServiceConfig interfaces.Configuration, previousCommonWritable any, raw any, privateConfigClient configuration.Client) error {
 	// check if changed value is a private override
-	if cp.isPrivateOverride(previousCommonWritable, raw, privateConfigClient) {
+	if cp.isPrivateOverride(lc, previousCommonWritable, raw, privateConfigClient) {
 		return nil
 	}
 
-	cp.applyWritableUpdates(fullServiceConfig, raw)
+	cp.applyWritableUpdates(lc, fullServiceConfig, raw, "common")
 	return nil
 }
 
-func (cp *Processor) isPrivateOverride(previous any, updated any, privateConfigClient configuration.Client) bool {
-	var changedKey string
+// isPrivateOverride returns whether the common Writable changes are all to settings overridden by the private
+// configuration, in which case the changes must not be applied.
+func (cp *Processor) isPrivateOverride(lc logger.LoggingClient, previous any, updated any, privateConfigClient configuration.Client) bool {
 	var previousMap, updatedMap map[string]any
 	if err := utils.ConvertToMap(previous, &previousMap); err != nil {
-		cp.lc.Errorf("could not convert previous interface to map: %s", err.Error())
+		lc.Errorf("could not convert previous interface to map: %s", err.Error())
 		return true
 	}
 	if err := utils.ConvertToMap(updated, &updatedMap); err != nil {
-		cp.lc.Errorf("could not convert updated interface to map: %s", err.Error())
+		lc.Errorf("could not convert updated interface to map: %s", err.Error())
 		return true
 	}
-	changedKey = walkMapForChange(previousMap, updatedMap, "")
-	if changedKey == "" {
-		// look the other way around to see if an item was removed
-		changedKey = walkMapForChange(updatedMap, previousMap, "")
-		if changedKey == "" {
-			cp.lc.Error("could not find updated writable key or an error occurred")
-			return true
-		}
-	}
-	// check to see if that setting is in the private config
-	if cp.isKeyInPrivate(privateConfigClient, changedKey) {
-		cp.lc.Infof("ignoring changed writable key %s overwritten in private writable", changedKey)
+
+	diffs := utils.DiffMaps(previousMap, updatedMap)
+	if len(diffs) == 0 {
+		lc.Error("could not find updated writable key or an error occurred")
 		return true
 	}
-	return false
+
+	// check to see if the changed settings are in the private config
+	for _, diff := range diffs {
+		if !cp.isKeyInPrivate(lc, privateConfigClient, diff.Path) {
+			return false
+		}
+		lc.Infof("ignoring changed writable key %s overwritten in private writable", diff.Path)
+	}
+	return true
 }
 
-func (cp *Processor) applyWritableUpdates(serviceConfig interfaces.Configuration, raw any) {
-	lc := cp.lc
+// applyWritableUpdates applies the Writable update received from the source, i.e. "private" or "common", to the
+// service's configuration and performs the side effects of the changes
+func (cp *Processor) applyWritableUpdates(lc logger.LoggingClient, serviceConfig interfaces.Configuration, raw any, source string) {
+	cp.writableMutex.Lock()
+	defer cp.writableMutex.Unlock()
+
 	previousInsecureSecrets := serviceConfig.GetInsecureSecrets()
 	previousLogLevel := serviceConfig.GetLogLevel()
 	previousTelemetryInterval := serviceConfig.GetTelemetryInfo().Interval
 
+	watchedPaths := cp.watchedWritablePaths
+	var previousWritable map[string]any
+	if len(watchedPaths) > 0 || len(cp.restartRequiredPaths) > 0 || cp.publishConfigChanges {
+		if err := utils.ConvertToMap(serviceConfig.GetWritablePtr(), &previousWritable); err != nil {
+			lc.Errorf("failed to convert Writable to map, so processing update regardless of the watched paths: %v", err)
+			watchedPaths = nil
+		}
+	}
+
+	restartRequiredChanged := false
+	if len(cp.restartRequiredPaths) > 0 {
+		raw, restartRequiredChanged = cp.withoutRestartRequiredChanges(lc, serviceConfig.GetWritablePtr(), raw)
+	}
+
 	if err := utils.MergeValues(serviceConfig.GetWritablePtr(), raw); err != nil {
 		lc.Errorf("failed to apply Writable change to service configuration: %v", err)
 	}
 
+	if restartRequiredChanged && !writableChangedFrom(lc, previousWritable, serviceConfig.GetWritablePtr()) {
+		lc.Debug("Writable configuration has only been updated within the restart-required paths. Ignoring update")
+		return
+	}
+
+	if cp.publishConfigChanges && previousWritable != nil {
+		cp.publishWritableChanges(lc, serviceConfig, previousWritable, source)
+	}
+
+	if len(watchedPaths) > 0 && !watchedWritableChanged(lc, previousWritable, serviceConfig.GetWritablePtr(), watchedPaths) {
+		lc.Debugf("Writable configuration has been updated outside of the watched paths (%s). Ignoring update",
+			strings.Join(watchedPaths, ", "))
+		return
+	}
+
 	currentInsecureSecrets := serviceConfig.GetInsecureSecrets()
 	currentLogLevel := serviceConfig.GetLogLevel()
 	currentTelemetryInterval := serviceConfig.GetTelemetryInfo().Interval
@@ -848,89 +1737,397 @@ func (cp *Processor) applyWritableUpdates(serviceConfig interfaces.Configuration
 	// Note: Updates occur one setting at a time so only have to look for single changes
 	switch {
 	case currentLogLevel != previousLogLevel:
-		_ = lc.SetLogLevel(serviceConfig.GetLogLevel())
-		lc.Info(fmt.Sprintf("Logging level changed to %s", currentLogLevel))
+		cp.applyLogLevelChange(lc, currentLogLevel)
 
 	// InsecureSecrets (map) will be nil if not in the original TOML used to seed the Config Provider,
 	// so ignore it if this is the case.
 	case currentInsecureSecrets != nil &&
 		!reflect.DeepEqual(currentInsecureSecrets, previousInsecureSecrets):
-		lc.Info("Insecure Secrets have been updated")
-		secretProvider := container.SecretProviderExtFrom(cp.dic.Get)
-		if secretProvider != nil {
-			// Find the updated secret's path and perform call backs.
-			updatedSecrets := getSecretNamesChanged(previousInsecureSecrets, currentInsecureSecrets)
-			for _, v := range updatedSecrets {
-				secretProvider.SecretUpdatedAtSecretName(v)
-			}
-		}
+		cp.applyInsecureSecretsChange(lc, previousInsecureSecrets, currentInsecureSecrets)
 
 	case currentTelemetryInterval != previousTelemetryInterval:
-		lc.Info("Telemetry interval has been updated. Processing new value...")
-		interval, err := time.ParseDuration(currentTelemetryInterval)
+		cp.applyTelemetryIntervalChange(lc, currentTelemetryInterval)
+
+	default:
+		cp.signalConfigUpdated(lc)
+	}
+}
+
+// ReplaceWritable replaces the service's entire Writable configuration with newWritable, which must be the same type
+// as the Writable, or a pointer to it. The new Writable is validated, via its own Validate method and the service
+// configuration's Validate method, if implemented, before it is swapped in, so the Writable is left unchanged when an
+// error is returned. The side effects of the changed log level, Insecure Secrets and telemetry interval are then
+// performed and the configuration updated signal is sent. The Writable is only replaced locally, not in the
+// Configuration Provider, so subsequent changes from the Configuration Provider are still merged over it.
+func (cp *Processor) ReplaceWritable(newWritable any) error {
+	if cp.serviceConfig == nil {
+		return errors.New("unable to replace Writable before the configuration has been processed")
+	}
+
+	cp.writableMutex.Lock()
+	defer cp.writableMutex.Unlock()
+
+	lc := cp.operationLogger("ReplaceWritable")
+
+	writable := reflect.ValueOf(cp.serviceConfig.GetWritablePtr())
+	if writable.Kind() != reflect.Pointer || writable.IsNil() {
+		return fmt.Errorf("service's Writable is %T rather than a pointer to the Writable struct", cp.serviceConfig.GetWritablePtr())
+	}
+
+	replacement := reflect.Indirect(reflect.ValueOf(newWritable))
+	if !replacement.IsValid() || replacement.Type() != writable.Elem().Type() {
+		return fmt.Errorf("new Writable is %T rather than %s", newWritable, writable.Elem().Type())
+	}
+
+	if err := validateConfiguration("new Writable", newWritable); err != nil {
+		return err
+	}
+
+	// Validate the configuration as it will be with the new Writable, using a copy so the service's configuration is
+	// untouched when invalid
+	if _, ok := cp.serviceConfig.(interfaces.Validator); ok {
+		configCopy, err := copyConfigurationStruct(cp.serviceConfig)
 		if err != nil {
-			lc.Errorf("update telemetry interval value is invalid time duration, using previous value: %s", err.Error())
-			break
+			return err
+		}
+		reflect.ValueOf(configCopy.GetWritablePtr()).Elem().Set(replacement)
+		if err := validateConfiguration("configuration", configCopy); err != nil {
+			return err
 		}
+	}
+
+	previousInsecureSecrets := cp.serviceConfig.GetInsecureSecrets()
+	previousLogLevel := cp.serviceConfig.GetLogLevel()
+	previousTelemetryInterval := cp.serviceConfig.GetTelemetryInfo().Interval
+	if reflect.DeepEqual(writable.Elem().Interface(), replacement.Interface()) {
+		lc.Debug("New Writable is the same as the current Writable. Nothing to replace")
+		return nil
+	}
+
+	writable.Elem().Set(replacement)
+	lc.Info("Writable configuration has been replaced")
+
+	// Unlike updates from the Configuration Provider, many settings may have changed at once
+	cp.applyWritableChanges(lc, cp.serviceConfig, previousLogLevel, previousInsecureSecrets, previousTelemetryInterval)
+
+	cp.signalConfigUpdated(lc)
+	return nil
+}
+
+// ResetToFileDefaults discards the changes made to the service's configuration since it was processed, i.e. by the
+// Configuration Provider or at runtime, by reloading the private configuration file, with the environment variable
+// overrides applied, over a fresh copy of the configuration it was originally merged over, i.e. the service's defaults
+// and the common configuration. Custom configuration sources are not reloaded. The configuration is validated, if the
+// service's configuration implements Validate, before serviceConfig is reset, so it is left unchanged when an error is
+// returned. The side effects of the changed log level, Insecure Secrets and telemetry interval are then performed and
+// the configuration updated signal is sent. The Configuration Provider isn't changed and continues to be watched.
+func (cp *Processor) ResetToFileDefaults(serviceConfig interfaces.Configuration) error {
+	if cp.baseConfig == nil {
+		return errors.New("unable to reset to the file defaults before the configuration has been processed")
+	}
+
+	lc := cp.operationLogger("ResetToFileDefaults")
+
+	target := reflect.ValueOf(serviceConfig)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Type() != reflect.TypeOf(cp.baseConfig) {
+		return fmt.Errorf("service configuration is %T rather than %T", serviceConfig, cp.baseConfig)
+	}
+
+	resetConfig, err := copyConfigurationStruct(cp.baseConfig)
+	if err != nil {
+		return err
+	}
+
+	fileSource := &fileConfigSource{cp: cp, lc: lc, serviceType: cp.serviceType}
+	configMap, err := fileSource.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := utils.MergeValues(resetConfig, configMap); err != nil {
+		return newProcessError(ErrMergeFailed, "could not merge configuration from %s: %w", fileSource.Name(), err)
+	}
+
+	if err := validateConfiguration("configuration", resetConfig); err != nil {
+		return err
+	}
+
+	cp.writableMutex.Lock()
+	defer cp.writableMutex.Unlock()
+
+	previousInsecureSecrets := serviceConfig.GetInsecureSecrets()
+	previousLogLevel := serviceConfig.GetLogLevel()
+	previousTelemetryInterval := serviceConfig.GetTelemetryInfo().Interval
+
+	target.Elem().Set(reflect.ValueOf(resetConfig).Elem())
+	lc.Info("Configuration has been reset to the configuration file defaults")
+
+	cp.applyWritableChanges(lc, serviceConfig, previousLogLevel, previousInsecureSecrets, previousTelemetryInterval)
+
+	cp.signalConfigUpdated(lc)
+	return nil
+}
+
+// RefreshFromProvider re-pulls the common and private configuration from the Configuration Provider and applies the
+// resulting Writable section to serviceConfig, so out-of-band changes are picked up without waiting for the watchers,
+// whose events may be delayed or missed. The configuration is merged the same as by Process: the all services common
+// section, then the service type's common section and finally the private settings. The refreshed configuration is
+// validated, if the service's configuration implements Validate, before the Writable is updated, so it is left
+// unchanged when an error is returned. The side effects of the changed log level, Insecure Secrets and telemetry
+// interval are then performed and the configuration updated signal is sent. It is safe to call while the watchers are
+// running, since the Writable is updated under the same lock as the watchers' updates.
+func (cp *Processor) RefreshFromProvider(serviceConfig interfaces.Configuration) error {
+	if cp.privateConfigClient == nil || cp.commonConfigClient == nil {
+		return errors.New("unable to refresh from the Configuration Provider before the configuration has been processed using it")
+	}
 
-		if interval == 0 {
-			lc.Infof("0 specified for metrics reporting interval. Setting to max duration to effectively disable reporting.")
-			interval = math.MaxInt64
+	lc := cp.operationLogger("RefreshFromProvider")
+
+	// The copy is made under the lock, since the watchers may be updating the Writable
+	cp.writableMutex.Lock()
+	latestConfig, err := copyConfigurationStruct(serviceConfig)
+	cp.writableMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := cp.loadConfigFromProvider(latestConfig, cp.commonConfigClient); err != nil {
+		return fmt.Errorf("failed to load the common configuration for %s: %w", allServicesKey, err)
+	}
+
+	serviceTypeConfigClient, serviceTypeKey := cp.appConfigClient, appServicesKey
+	if serviceTypeConfigClient == nil {
+		serviceTypeConfigClient, serviceTypeKey = cp.deviceConfigClient, deviceServicesKey
+	}
+	if serviceTypeConfigClient != nil {
+		serviceTypeConfigMap, err := cp.loadServiceTypeCommonConfig(latestConfig, serviceTypeConfigClient, cp.configStem, serviceTypeKey)
+		if err != nil {
+			return err
+		}
+		if err := utils.MergeValues(latestConfig, serviceTypeConfigMap); err != nil {
+			return newProcessError(ErrMergeFailed, "failed to merge %s config with common config: %w", serviceTypeKey, err)
 		}
+	}
 
-		metricsManager := container.MetricsManagerFrom(cp.dic.Get)
-		if metricsManager == nil {
-			lc.Error("metrics manager not available while updating telemetry interval")
-			break
+	privateSource := &providerConfigSource{
+		cp:            cp,
+		lc:            lc,
+		serviceConfig: latestConfig,
+		client:        cp.privateConfigClient,
+		baseKey:       cp.baseKey,
+	}
+	privateConfigMap, err := privateSource.Load()
+	if err != nil {
+		return err
+	}
+	if err := utils.MergeValues(latestConfig, privateConfigMap); err != nil {
+		return newProcessError(ErrMergeFailed, "could not merge configuration from %s: %w", privateSource.Name(), err)
+	}
+
+	if err := validateConfiguration("configuration", latestConfig); err != nil {
+		return err
+	}
+
+	cp.writableMutex.Lock()
+	defer cp.writableMutex.Unlock()
+
+	previousInsecureSecrets := serviceConfig.GetInsecureSecrets()
+	previousLogLevel := serviceConfig.GetLogLevel()
+	previousTelemetryInterval := serviceConfig.GetTelemetryInfo().Interval
+
+	writable := reflect.ValueOf(serviceConfig.GetWritablePtr()).Elem()
+	latestWritable := reflect.ValueOf(latestConfig.GetWritablePtr()).Elem()
+	if reflect.DeepEqual(writable.Interface(), latestWritable.Interface()) {
+		lc.Debug("Writable configuration in the Configuration Provider is the same as the current Writable. Nothing to refresh")
+		return nil
+	}
+
+	writable.Set(latestWritable)
+	lc.Info("Writable configuration has been refreshed from the Configuration Provider")
+
+	cp.applyWritableChanges(lc, serviceConfig, previousLogLevel, previousInsecureSecrets, previousTelemetryInterval)
+
+	cp.signalConfigUpdated(lc)
+	return nil
+}
+
+// applyWritableChanges performs the side effects of the log level, Insecure Secrets and telemetry interval of the
+// service's configuration that have changed from their previous values
+func (cp *Processor) applyWritableChanges(
+	lc logger.LoggingClient,
+	serviceConfig interfaces.Configuration,
+	previousLogLevel string,
+	previousInsecureSecrets config.InsecureSecrets,
+	previousTelemetryInterval string) {
+	if currentLogLevel := serviceConfig.GetLogLevel(); currentLogLevel != previousLogLevel {
+		cp.applyLogLevelChange(lc, currentLogLevel)
+	}
+
+	currentInsecureSecrets := serviceConfig.GetInsecureSecrets()
+	if !reflect.DeepEqual(currentInsecureSecrets, previousInsecureSecrets) {
+		cp.applyInsecureSecretsChange(lc, previousInsecureSecrets, currentInsecureSecrets)
+	}
+
+	if currentTelemetryInterval := serviceConfig.GetTelemetryInfo().Interval; currentTelemetryInterval != previousTelemetryInterval {
+		cp.applyTelemetryIntervalChange(lc, currentTelemetryInterval)
+	}
+}
+
+// applyInsecureSecretsChange invokes the secret updated callbacks for the changed Insecure Secrets
+func (cp *Processor) applyInsecureSecretsChange(lc logger.LoggingClient, previousInsecureSecrets config.InsecureSecrets, currentInsecureSecrets config.InsecureSecrets) {
+	lc.Info("Insecure Secrets have been updated")
+	secretProvider := container.SecretProviderExtFrom(cp.dic.Get)
+	if secretProvider != nil {
+		// Find the updated secret's path and perform call backs.
+		updatedSecrets := getSecretNamesChanged(previousInsecureSecrets, currentInsecureSecrets)
+		for _, v := range updatedSecrets {
+			secretProvider.SecretUpdatedAtSecretName(v)
 		}
+	}
+}
+
+// applyTelemetryIntervalChange resets the metrics reporting interval to the changed telemetry interval
+func (cp *Processor) applyTelemetryIntervalChange(lc logger.LoggingClient, telemetryInterval string) {
+	lc.Info("Telemetry interval has been updated. Processing new value...")
+	interval, err := time.ParseDuration(telemetryInterval)
+	if err != nil {
+		lc.Errorf("update telemetry interval value is invalid time duration, using previous value: %s", err.Error())
+		return
+	}
+
+	if interval == 0 {
+		lc.Infof("0 specified for metrics reporting interval. Setting to max duration to effectively disable reporting.")
+		interval = math.MaxInt64
+	}
+
+	metricsManager := container.MetricsManagerFrom(cp.dic.Get)
+	if metricsManager == nil {
+		lc.Error("metrics manager not available while updating telemetry interval")
+		return
+	}
+
+	metricsManager.ResetInterval(interval)
+}
+
+// OnConfigChanged registers a callback which is invoked, along with the configuration updated signal being sent to the
+// UpdatedStream, when Writable changes are applied which aren't handled by the Processor itself, i.e. those other than
+// the log level, Insecure Secrets and telemetry interval. Any number of callbacks may be registered and they are invoked
+// in the order registered. Callbacks are invoked while the Writable is locked, so must not call ReplaceWritable.
+func (cp *Processor) OnConfigChanged(callback func()) {
+	if callback == nil {
+		return
+	}
+
+	cp.configChangedMutex.Lock()
+	defer cp.configChangedMutex.Unlock()
+
+	cp.configChangedCallbacks = append(cp.configChangedCallbacks, callback)
+}
+
+// signalConfigUpdated invokes the config changed callbacks and signals that configuration updates exists that have not
+// already been processed
+func (cp *Processor) signalConfigUpdated(lc logger.LoggingClient) {
+	cp.configChangedMutex.Lock()
+	callbacks := cp.configChangedCallbacks
+	cp.configChangedMutex.Unlock()
 
-		metricsManager.ResetInterval(interval)
+	for _, callback := range callbacks {
+		callback()
+	}
+
+	if cp.configUpdated == nil {
+		return
+	}
 
+	// Don't block the watcher when the service isn't reading the stream, otherwise no further updates are processed
+	select {
+	case cp.configUpdated <- struct{}{}:
 	default:
-		// Signal that configuration updates exists that have not already been processed.
-		if cp.configUpdated != nil {
-			cp.configUpdated <- struct{}{}
+		lc.Warn("Configuration updated signal dropped since nothing is reading the configuration updated stream")
+	}
+}
+
+// watchedWritableChanged returns whether a value within one of the watched paths differs between the previous and
+// current Writable.
+func watchedWritableChanged(lc logger.LoggingClient, previousWritable map[string]any, currentWritable any, watchedPaths []string) bool {
+	var current map[string]any
+	if err := utils.ConvertToMap(currentWritable, &current); err != nil {
+		lc.Errorf("failed to convert Writable to map, so processing update regardless of the watched paths: %v", err)
+		return true
+	}
+
+	for _, path := range watchedPaths {
+		previousValue, _ := getMapValue(previousWritable, path)
+		currentValue, _ := getMapValue(current, path)
+		if !reflect.DeepEqual(previousValue, currentValue) {
+			return true
 		}
 	}
+
+	return false
 }
 
-func (cp *Processor) waitForCommonConfig(configClient configuration.Client, configReadyPath string) error {
+// providerTimer returns the timer limiting the waiting for the Configuration Provider, as set by
+// SetProviderLoadRetryBudget, otherwise the startup timer
+func (cp *Processor) providerTimer() startup.Timer {
+	if cp.providerLoadTimeout <= 0 && cp.providerRetryInterval <= 0 {
+		return cp.startupTimer
+	}
+
+	interval := cp.providerRetryInterval
+	if interval <= 0 {
+		interval = cp.startupTimer.Interval()
+	}
+	if cp.providerLoadTimeout <= 0 {
+		return startup.NewDurationTimer(cp.startupTimer.Remaining(), interval)
+	}
+	return startup.NewDurationTimer(cp.providerLoadTimeout, interval)
+}
+
+func (cp *Processor) waitForCommonConfig(lc logger.LoggingClient, configClient configuration.Client, configReadyPath string) error {
+	timer := cp.providerTimer()
+
 	// Wait for configuration provider to be available
 	isAlive := false
-	for cp.startupTimer.HasNotElapsed() {
+	for timer.HasNotElapsed() {
 		if configClient.IsAlive() {
 			isAlive = true
 			break
 		}
 
-		cp.lc.Warnf("Waiting for configuration provider to be available")
+		lc.Warnf("Waiting for configuration provider to be available")
 
 		select {
 		case <-cp.ctx.Done():
-			return errors.New("aborted waiting Configuration Provider to be available")
+			return newProcessError(ErrProviderUnavailable, "aborted waiting Configuration Provider to be available")
 		default:
-			cp.startupTimer.SleepForInterval()
+			timer.SleepForInterval()
 			continue
 		}
 	}
 	if !isAlive {
-		return errors.New("configuration provider is not available")
+		return ErrProviderUnavailable
 	}
 
 	// check to see if common config is loaded
 	isConfigReady := false
 	isCommonConfigReady := false
-	for cp.startupTimer.HasNotElapsed() {
+	// The ready value is absent, rather than false, when core-common-config-bootstrapper uses a different config stem
+	isReadyPathAbsent := false
+	for timer.HasNotElapsed() {
 		commonConfigReady, err := configClient.GetConfigurationValueByFullPath(configReadyPath)
-		if err != nil {
-			cp.lc.Warn("waiting for Common Configuration to be available from config provider")
-			cp.startupTimer.SleepForInterval()
+		isReadyPathAbsent = err == nil && commonConfigReady == nil
+		if err != nil || isReadyPathAbsent {
+			lc.Warn("waiting for Common Configuration to be available from config provider")
+			if !cp.sleepForCommonConfigPoll() {
+				return newProcessError(ErrCommonConfigNotReady, "aborted waiting for Common Configuration to be available")
+			}
 			continue
 		}
 
 		isCommonConfigReady, err = strconv.ParseBool(string(commonConfigReady))
 		if err != nil {
-			cp.lc.Warnf("did not get boolean from config provider for %s: %s", configReadyPath, err.Error())
+			lc.Warnf("did not get boolean from config provider for %s: %s", configReadyPath, err.Error())
 			isCommonConfigReady = false
 		}
 		if isCommonConfigReady {
@@ -938,34 +2135,77 @@ func (cp *Processor) waitForCommonConfig(configClient configuration.Client, conf
 			break
 		}
 
-		cp.lc.Warn("waiting for Common Configuration to be available from config provider")
+		lc.Warn("waiting for Common Configuration to be available from config provider")
 
-		select {
-		case <-cp.ctx.Done():
-			return errors.New("aborted waiting for Common Configuration to be available")
-		default:
-			cp.startupTimer.SleepForInterval()
-			continue
+		if !cp.sleepForCommonConfigPoll() {
+			return newProcessError(ErrCommonConfigNotReady, "aborted waiting for Common Configuration to be available")
 		}
 	}
 	if !isConfigReady {
-		return errors.New("common config is not loaded - check to make sure core-common-config-bootstrapper ran")
+		if isReadyPathAbsent {
+			return fmt.Errorf("%w - %s is not present in the Configuration Provider, check core-common-config-bootstrapper "+
+				"ran and uses the same config stem as the service", ErrCommonConfigNotReady, configReadyPath)
+		}
+		return fmt.Errorf("%w - check to make sure core-common-config-bootstrapper ran", ErrCommonConfigNotReady)
 	}
 	return nil
 }
 
+// sleepForCommonConfigPoll pauses execution for the next interval of the polling for the common configuration to be
+// ready. false is returned if the Processor's context is done before the interval has passed.
+func (cp *Processor) sleepForCommonConfigPoll() bool {
+	timer := time.NewTimer(cp.commonConfigPollDelay())
+	defer timer.Stop()
+
+	select {
+	case <-cp.ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// commonConfigPollDelay returns the poll interval with a random jitter, in [0, jitter), added
+func (cp *Processor) commonConfigPollDelay() time.Duration {
+	interval := cp.commonPollInterval
+	jitter := cp.commonPollJitter
+	if option, ok := cp.flags.(flags.CommonConfigPollOption); ok {
+		if option.CommonConfigPollInterval() > 0 {
+			interval = option.CommonConfigPollInterval()
+		}
+		if option.CommonConfigPollJitter() > 0 {
+			jitter = option.CommonConfigPollJitter()
+		}
+	}
+
+	if interval <= 0 {
+		interval = cp.providerRetryInterval
+	}
+	if interval <= 0 {
+		interval = cp.startupTimer.Interval()
+	}
+	if jitter <= 0 {
+		return interval
+	}
+
+	// The jitter only staggers polling, so it doesn't need a cryptographically secure random number
+	return interval + time.Duration(rand.Int63n(int64(jitter))) //nolint: gosec
+}
+
 // loadConfigFromProvider loads the config into the config structure
 func (cp *Processor) loadConfigFromProvider(serviceConfig interfaces.Configuration, configClient configuration.Client) error {
 	// pull common config and apply config to service config structure
+	started := time.Now()
 	rawConfig, err := configClient.GetConfiguration(serviceConfig)
+	cp.metrics.providerRoundTrip.Update(millisecondsSince(started))
 	if err != nil {
-		return err
+		return newProcessError(ErrProviderUnavailable, "%w", err)
 	}
 
 	// update from raw
 	ok := serviceConfig.UpdateFromRaw(rawConfig)
 	if !ok {
-		return fmt.Errorf("could not update service's configuration from raw")
+		return newProcessError(ErrConfigParse, "could not update service's configuration from raw")
 	}
 
 	return nil
@@ -1017,44 +2257,10 @@ func copyConfigurationStruct(config interfaces.Configuration) (interfaces.Config
 	return configCopy, nil
 }
 
-func walkMapForChange(previousMap map[string]any, updatedMap map[string]any, changedKey string) string {
-	for updatedKey, updatedVal := range updatedMap {
-		previousVal, ok := previousMap[updatedKey]
-		if !ok {
-			return buildNewKey(changedKey, updatedKey)
-		}
-		updatedSubMap, ok := updatedVal.(map[string]any)
-		// if the value is not of type map[string]any, it should be a value to compare
-		if !ok {
-			if updatedVal != previousVal {
-				return buildNewKey(changedKey, updatedKey)
-			}
-			continue
-		}
-		previousSubMap, ok := previousVal.(map[string]any)
-		if !ok {
-			// handle the case where a new setting is added
-			if previousSubMap == nil && updatedSubMap != nil {
-				subKey := buildNewKey(changedKey, updatedKey)
-				for k := range updatedSubMap {
-					return buildNewKey(subKey, k)
-				}
-			}
-			return ""
-		}
-		key := buildNewKey(changedKey, updatedKey)
-		key = walkMapForChange(previousSubMap, updatedSubMap, key)
-		if len(key) > 0 {
-			return key
-		}
-	}
-	return ""
-}
-
-func (cp *Processor) isKeyInPrivate(privateConfigClient configuration.Client, changedKey string) bool {
+func (cp *Processor) isKeyInPrivate(lc logger.LoggingClient, privateConfigClient configuration.Client, changedKey string) bool {
 	keys, err := privateConfigClient.GetConfigurationKeys(writableKey)
 	if err != nil {
-		cp.lc.Errorf("could not get writable keys from private configuration: %s", err.Error())
+		lc.Errorf("could not get writable keys from private configuration: %s", err.Error())
 		// return true because shouldn't change an overridden value
 		// error means it is undetermined, so don't override to be safe
 		return true
@@ -1068,11 +2274,3 @@ func (cp *Processor) isKeyInPrivate(privateConfigClient configuration.Client, ch
 	}
 	return false
 }
-
-func buildNewKey(previousKey, currentKey string) string {
-	if previousKey != "" {
-		return utils.BuildBaseKey(previousKey, currentKey)
-	} else {
-		return currentKey
-	}
-}
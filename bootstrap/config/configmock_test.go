@@ -17,6 +17,8 @@
 package config
 
 import (
+	"errors"
+
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 )
 
@@ -24,6 +26,7 @@ type WritableInfo struct {
 	LogLevel        string
 	StoreAndForward StoreAndForwardInfo
 	Telemetry       config.TelemetryInfo
+	InsecureSecrets config.InsecureSecrets
 }
 
 type ConfigurationMockStruct struct {
@@ -77,7 +80,7 @@ func (c *ConfigurationMockStruct) GetRegistryInfo() config.RegistryInfo {
 }
 
 func (c *ConfigurationMockStruct) GetInsecureSecrets() config.InsecureSecrets {
-	return config.InsecureSecrets{}
+	return c.Writable.InsecureSecrets
 }
 
 func (c *ConfigurationMockStruct) GetTelemetryInfo() *config.TelemetryInfo {
@@ -87,3 +90,21 @@ func (c *ConfigurationMockStruct) GetTelemetryInfo() *config.TelemetryInfo {
 func (c *ConfigurationMockStruct) GetWritablePtr() any {
 	return &c.Writable
 }
+
+// ValidatingConfigurationMockStruct is a ConfigurationMockStruct that implements interfaces.Validator
+type ValidatingConfigurationMockStruct struct {
+	ConfigurationMockStruct
+}
+
+// errTriggerTypeRequired is returned by ValidatingConfigurationMockStruct's Validate when Trigger.Type isn't set
+var errTriggerTypeRequired = errors.New("Trigger.Type is required")
+
+func (c *ValidatingConfigurationMockStruct) Validate() error {
+	if len(c.Trigger.Type) == 0 {
+		return errTriggerTypeRequired
+	}
+	if len(c.Writable.LogLevel) == 0 {
+		return errors.New("Writable.LogLevel is required")
+	}
+	return nil
+}
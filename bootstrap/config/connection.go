@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ConnectionEvent is an event emitted when the state of the connection to the Configuration Provider changes
+type ConnectionEvent string
+
+const (
+	// Connected is emitted when the watchers first receive configuration from the Configuration Provider
+	Connected ConnectionEvent = "Connected"
+	// Disconnected is emitted when a watcher reports an error and the Configuration Provider is not alive
+	Disconnected ConnectionEvent = "Disconnected"
+	// Reconnected is emitted when the Configuration Provider is reachable again after being Disconnected
+	Reconnected ConnectionEvent = "Reconnected"
+)
+
+// ConnectionEventCallback is the function called when the connection state changes. It is called from the watcher's
+// go routine, so it must not block.
+type ConnectionEventCallback func(event ConnectionEvent)
+
+const defaultConnectionProbeInterval = 5 * time.Second
+
+// RegisterConnectionEventCallback registers a callback that is called each time the state of the connection to
+// the Configuration Provider changes.
+func (cp *Processor) RegisterConnectionEventCallback(callback ConnectionEventCallback) {
+	if callback == nil {
+		return
+	}
+
+	cp.connectionMutex.Lock()
+	defer cp.connectionMutex.Unlock()
+
+	cp.connectionCallbacks = append(cp.connectionCallbacks, callback)
+}
+
+// SetRefreshOnReconnect sets whether the private Writable section is re-pulled from the Configuration Provider when
+// the connection is re-established, so changes made while disconnected are not missed.
+func (cp *Processor) SetRefreshOnReconnect(enabled bool) {
+	cp.connectionMutex.Lock()
+	defer cp.connectionMutex.Unlock()
+
+	cp.refreshOnReconnect = enabled
+}
+
+// LastConnectionEvent returns the last connection event emitted, which is empty if the watchers have not yet connected.
+func (cp *Processor) LastConnectionEvent() ConnectionEvent {
+	cp.connectionMutex.Lock()
+	defer cp.connectionMutex.Unlock()
+
+	return cp.connectionState
+}
+
+// reportConnected is called when a watcher receives an update from the Configuration Provider.
+func (cp *Processor) reportConnected(lc logger.LoggingClient) {
+	cp.connectionMutex.Lock()
+
+	var event ConnectionEvent
+	switch cp.connectionState {
+	case "":
+		event = Connected
+	case Disconnected:
+		event = Reconnected
+	default:
+		cp.connectionMutex.Unlock()
+		return
+	}
+
+	cp.connectionState = event
+	callbacks := cp.connectionCallbacks
+	refresh := event == Reconnected && cp.refreshOnReconnect && cp.reconnectRefresh != nil
+	reconnectRefresh := cp.reconnectRefresh
+	cp.connectionMutex.Unlock()
+
+	lc.Infof("Configuration Provider connection state changed to '%s'", event)
+	cp.emitConnectionEvent(callbacks, event)
+
+	if refresh {
+		if err := reconnectRefresh(); err != nil {
+			lc.Errorf("failed to refresh Writable configuration after reconnecting: %v", err)
+		}
+	}
+}
+
+// reportWatcherError is called when a watcher receives an error. The Configuration Provider is probed to determine
+// if the connection has been lost, in which case it continues to be probed until it is alive again.
+func (cp *Processor) reportWatcherError(lc logger.LoggingClient, configClient configuration.Client) {
+	if configClient.IsAlive() {
+		return
+	}
+
+	cp.connectionMutex.Lock()
+	if cp.connectionState == Disconnected {
+		cp.connectionMutex.Unlock()
+		return
+	}
+
+	cp.connectionState = Disconnected
+	callbacks := cp.connectionCallbacks
+	probeInterval := cp.connectionProbeInterval
+	cp.connectionMutex.Unlock()
+
+	if probeInterval <= 0 {
+		probeInterval = defaultConnectionProbeInterval
+	}
+
+	lc.Warnf("Configuration Provider connection state changed to '%s'", Disconnected)
+	cp.emitConnectionEvent(callbacks, Disconnected)
+
+	cp.startWatcher("connection probe", func() {
+		ticker := time.NewTicker(probeInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-cp.ctx.Done():
+				return
+
+			case <-ticker.C:
+				if cp.LastConnectionEvent() != Disconnected {
+					// A watcher has already received an update, so the connection is back
+					return
+				}
+
+				if configClient.IsAlive() {
+					cp.reportConnected(lc)
+					return
+				}
+			}
+		}
+	})
+}
+
+func (cp *Processor) emitConnectionEvent(callbacks []ConnectionEventCallback, event ConnectionEvent) {
+	for _, callback := range callbacks {
+		callback(event)
+	}
+}
+
+// refreshPrivateWritable re-pulls the private Writable section from the Configuration Provider and queues it to be
+// applied to the service's configuration in order with the watchers' updates.
+func (cp *Processor) refreshPrivateWritable(lc logger.LoggingClient, serviceConfig interfaces.Configuration,
+	configClient configuration.Client, baseKey string) error {
+	// The copy is made under the lock, since the update worker may be updating the Writable
+	cp.writableMutex.Lock()
+	latestConfig, err := copyConfigurationStruct(serviceConfig)
+	cp.writableMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := cp.loadConfigFromProvider(latestConfig, configClient); err != nil {
+		return fmt.Errorf("failed to load configuration from Configuration Provider: %v", err)
+	}
+
+	usedKeys, err := configClient.GetConfigurationKeys(writableKey)
+	if err != nil {
+		return fmt.Errorf("failed to get list of private configuration keys for %s: %v", writableKey, err)
+	}
+
+	rawMap, err := utils.RemoveUnusedSettings(latestConfig.GetWritablePtr(), utils.BuildBaseKey(baseKey, writableKey), utils.StringSliceToMap(usedKeys))
+	if err != nil {
+		return fmt.Errorf("failed to remove unused private settings in %s: %v", writableKey, err)
+	}
+
+	cp.queueUpdate(lc, "private", func() {
+		cp.applyWritableUpdates(lc, serviceConfig, rawMap, "private")
+	})
+	return nil
+}
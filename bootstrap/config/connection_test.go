@@ -0,0 +1,211 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestConnectionEvents(t *testing.T) {
+	baseKey := "edgex/v3/unit-test"
+
+	tests := []struct {
+		Name               string
+		RefreshOnReconnect bool
+		RecoverViaUpdate   bool
+		ExpectedLogLevel   string
+	}{
+		{"Valid - recovered via probe", false, false, "INFO"},
+		{"Valid - recovered via probe with refresh", true, false, "DEBUG"},
+		{"Valid - recovered via update", false, true, "INFO"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			f := flags.New()
+			f.Parse(nil)
+			mockLogger := logger.NewMockClient()
+			env := environment.NewVariables(mockLogger)
+			timer := startup.NewTimer(5, 1)
+			wg := sync.WaitGroup{}
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			type streams struct {
+				updates chan<- any
+				errors  chan<- error
+			}
+			watcherStreams := make(chan streams, 1)
+
+			providerClientMock := &mocks.Client{}
+			providerClientMock.On("WatchForChanges", mock.Anything, mock.Anything, mock.Anything, writableKey).
+				Run(func(args mock.Arguments) {
+					watcherStreams <- streams{
+						updates: args.Get(0).(chan<- any),
+						errors:  args.Get(1).(chan<- error),
+					}
+				}).Return()
+			providerClientMock.On("StopWatching").Return()
+			providerClientMock.On("GetConfigurationKeys", writableKey).Return([]string{baseKey + "/Writable/LogLevel"}, nil)
+			if tc.RecoverViaUpdate {
+				// Probes never succeed, so only the update can signal the connection is back
+				providerClientMock.On("IsAlive").Return(false)
+			} else {
+				// Not alive when the errors are reported and on the first probe, alive afterwards
+				providerClientMock.On("IsAlive").Return(false).Times(3)
+				providerClientMock.On("IsAlive").Return(true)
+			}
+			providerClientMock.On("GetConfiguration", mock.Anything).Return(
+				&ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "DEBUG"}}, nil)
+
+			proc := NewProcessor(f, env, timer, context.Background(), &wg, nil, dic)
+			proc.connectionProbeInterval = 10 * time.Millisecond
+			proc.SetRefreshOnReconnect(tc.RefreshOnReconnect)
+
+			events := make(chan ConnectionEvent, 10)
+			proc.RegisterConnectionEventCallback(func(event ConnectionEvent) {
+				events <- event
+			})
+
+			serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+			proc.listenForPrivateChanges(serviceConfig, providerClientMock, baseKey)
+
+			var watcher streams
+			select {
+			case watcher = <-watcherStreams:
+			case <-time.After(time.Second):
+				require.Fail(t, "watcher not started")
+			}
+
+			waitForEvent := func(expected ConnectionEvent) {
+				select {
+				case event := <-events:
+					require.Equal(t, expected, event)
+				case <-time.After(time.Second):
+					require.Failf(t, "timed out waiting for event", "expected '%s'", expected)
+				}
+			}
+
+			assert.Empty(t, proc.LastConnectionEvent())
+
+			// The first update is sent as soon as the watcher connects
+			watcher.updates <- &WritableInfo{LogLevel: "INFO"}
+			waitForEvent(Connected)
+
+			watcher.errors <- errors.New("connection refused")
+			waitForEvent(Disconnected)
+			assert.Equal(t, Disconnected, proc.LastConnectionEvent())
+
+			// Additional errors while disconnected don't emit any events
+			watcher.errors <- errors.New("connection refused")
+
+			if tc.RecoverViaUpdate {
+				watcher.updates <- &WritableInfo{LogLevel: "INFO"}
+			}
+			waitForEvent(Reconnected)
+
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			require.NoError(t, proc.Shutdown(shutdownCtx))
+
+			assert.Empty(t, events)
+			assert.Equal(t, Reconnected, proc.LastConnectionEvent())
+			assert.Equal(t, tc.ExpectedLogLevel, serviceConfig.Writable.LogLevel)
+			if tc.RefreshOnReconnect {
+				providerClientMock.AssertCalled(t, "GetConfiguration", mock.Anything)
+			} else {
+				providerClientMock.AssertNotCalled(t, "GetConfiguration", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestListenForPrivateChangesResubscribes(t *testing.T) {
+	baseKey := "edgex/v3/unit-test"
+
+	f := flags.New()
+	f.Parse(nil)
+	mockLogger := logger.NewMockClient()
+	env := environment.NewVariables(mockLogger)
+	timer := startup.NewTimer(5, 1)
+	wg := sync.WaitGroup{}
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+
+	watcherUpdates := make(chan chan<- any, 2)
+
+	providerClientMock := &mocks.Client{}
+	providerClientMock.On("WatchForChanges", mock.Anything, mock.Anything, mock.Anything, writableKey).
+		Run(func(args mock.Arguments) {
+			watcherUpdates <- args.Get(0).(chan<- any)
+		}).Return()
+	providerClientMock.On("StopWatching").Return()
+	providerClientMock.On("GetConfigurationKeys", writableKey).Return([]string{baseKey + "/Writable/LogLevel"}, nil)
+	providerClientMock.On("GetConfiguration", mock.Anything).Return(
+		&ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "DEBUG"}}, nil)
+
+	proc := NewProcessor(f, env, timer, context.Background(), &wg, nil, dic)
+	proc.watchRetryInterval = 10 * time.Millisecond
+
+	serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+	proc.listenForPrivateChanges(serviceConfig, providerClientMock, baseKey)
+
+	waitForWatcher := func() chan<- any {
+		select {
+		case updates := <-watcherUpdates:
+			return updates
+		case <-time.After(time.Second):
+			require.Fail(t, "watcher not started")
+		}
+		return nil
+	}
+
+	updates := waitForWatcher()
+	// The first update is sent as soon as the watcher connects and is ignored
+	updates <- &WritableInfo{LogLevel: "INFO"}
+
+	// The provider drops the watch, so the loop must re-subscribe
+	close(updates)
+	updates = waitForWatcher()
+
+	// The first update after re-subscribing re-syncs the writable section from the provider
+	updates <- &WritableInfo{LogLevel: "INFO"}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, proc.Shutdown(shutdownCtx))
+
+	providerClientMock.AssertNumberOfCalls(t, "WatchForChanges", 2)
+	providerClientMock.AssertCalled(t, "GetConfiguration", mock.Anything)
+	assert.Equal(t, "DEBUG", serviceConfig.Writable.LogLevel)
+}
@@ -0,0 +1,133 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// ConfigFormatYaml is the format of YAML configuration, which is the default format of the configuration files
+	ConfigFormatYaml = "yaml"
+	// ConfigFormatJson is the format of JSON configuration
+	ConfigFormatJson = "json"
+)
+
+// DecodeConfig decodes the configuration in the format, i.e. ConfigFormatYaml or ConfigFormatJson, as it is streamed
+// from the reader, so the configuration is never buffered in full before it is decoded. "yml" is accepted for YAML.
+// Empty input results in an empty map.
+func DecodeConfig(r io.Reader, format string) (map[string]any, error) {
+	data := make(map[string]any)
+
+	var err error
+	switch strings.ToLower(format) {
+	case ConfigFormatYaml, "yml":
+		err = yaml.NewDecoder(r).Decode(&data)
+	case ConfigFormatJson:
+		err = json.NewDecoder(r).Decode(&data)
+	default:
+		return nil, fmt.Errorf("unsupported configuration format '%s'", format)
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+
+	return data, nil
+}
+
+// configFileFormat returns the format of the configuration file from its extension. Files without the JSON extension
+// are YAML.
+func configFileFormat(path string) string {
+	if strings.EqualFold(filepath.Ext(path), ".json") {
+		return ConfigFormatJson
+	}
+
+	return ConfigFormatYaml
+}
+
+// decodeConfigFile decodes the configuration file as it is read from the open file, failing rather than exhausting
+// memory when it is larger than maxSize. When contents isn't nil, the whole file is also copied to it as it is read.
+func decodeConfigFile(path string, maxSize int64, contents io.Writer) (map[string]any, error) {
+	if maxSize <= 0 {
+		maxSize = DefaultMaxConfigFileSize
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read configuration file %s: %w", path, err)
+	}
+	defer func() { _ = file.Close() }()
+
+	limiter := &maxSizeReader{reader: file, remaining: maxSize}
+	var reader io.Reader = limiter
+	if contents != nil {
+		reader = io.TeeReader(limiter, contents)
+	}
+
+	data, err := DecodeConfig(reader, configFileFormat(path))
+	if err == nil && contents != nil {
+		// The decoder may stop before the end of the file, i.e. trailing whitespace, so copy the rest of the file
+		_, err = io.Copy(io.Discard, reader)
+	}
+	if limiter.exceeded {
+		return nil, fmt.Errorf("failed to read configuration file %s: file exceeds the maximum configuration file size of %d bytes", path, maxSize)
+	}
+	if err != nil {
+		return nil, newProcessError(ErrConfigParse, "failed to unmarshall configuration file %s: %w", path, err)
+	}
+
+	return data, nil
+}
+
+// errMaxSizeExceeded is returned by maxSizeReader once more than the maximum size has been read
+var errMaxSizeExceeded = errors.New("maximum size exceeded")
+
+// maxSizeReader reads from the reader until more than the remaining bytes are available, at which point it fails
+// with errMaxSizeExceeded
+type maxSizeReader struct {
+	reader    io.Reader
+	remaining int64
+	exceeded  bool
+}
+
+func (r *maxSizeReader) Read(p []byte) (int, error) {
+	if r.remaining <= 0 {
+		// Read one byte past the limit to detect that there is more than the maximum size
+		var extra [1]byte
+		n, err := r.reader.Read(extra[:])
+		if n > 0 {
+			r.exceeded = true
+			return 0, errMaxSizeExceeded
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > r.remaining {
+		p = p[:r.remaining]
+	}
+
+	n, err := r.reader.Read(p)
+	r.remaining -= int64(n)
+	return n, err
+}
@@ -0,0 +1,90 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecodeConfig(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Contents      string
+		Format        string
+		Expected      map[string]any
+		ExpectedError string
+	}{
+		{"Valid - yaml", "Writable:\n  LogLevel: INFO\n  Port: 59880\n", ConfigFormatYaml,
+			map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": 59880}}, ""},
+		{"Valid - yml", "Writable:\n  LogLevel: INFO\n", "yml",
+			map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}}, ""},
+		{"Valid - json", `{"Writable": {"LogLevel": "INFO", "Port": 59880}}`, ConfigFormatJson,
+			map[string]any{"Writable": map[string]any{"LogLevel": "INFO", "Port": float64(59880)}}, ""},
+		{"Valid - upper case format", `{"Writable": {"LogLevel": "INFO"}}`, "JSON",
+			map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}}, ""},
+		{"Valid - empty yaml", "", ConfigFormatYaml, map[string]any{}, ""},
+		{"Valid - empty json", "", ConfigFormatJson, map[string]any{}, ""},
+		{"Invalid - yaml", "Writable: [\n", ConfigFormatYaml, nil, "yaml"},
+		{"Invalid - json", `{"Writable": `, ConfigFormatJson, nil, "unexpected EOF"},
+		{"Invalid - format", "Writable: {}\n", "toml", nil, "unsupported configuration format 'toml'"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Reading a byte at a time verifies the decoding doesn't rely on the whole input being read at once
+			actual, err := DecodeConfig(iotest.OneByteReader(strings.NewReader(tc.Contents)), tc.Format)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestDecodeConfigFile(t *testing.T) {
+	configDir := t.TempDir()
+	yamlFile := filepath.Join(configDir, "configuration.yaml")
+	jsonFile := filepath.Join(configDir, "configuration.json")
+	require.NoError(t, os.WriteFile(yamlFile, []byte("Writable:\n  LogLevel: INFO\n"), 0644))
+	require.NoError(t, os.WriteFile(jsonFile, []byte(`{"Writable": {"LogLevel": "DEBUG"}}`), 0644))
+
+	actual, err := decodeConfigFile(yamlFile, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}}, actual)
+
+	actual, err = decodeConfigFile(jsonFile, 0, nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"Writable": map[string]any{"LogLevel": "DEBUG"}}, actual)
+
+	_, err = decodeConfigFile(jsonFile, 10, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "exceeds the maximum configuration file size of 10 bytes")
+	assert.NotErrorIs(t, err, ErrConfigParse)
+
+	_, err = decodeConfigFile(filepath.Join(configDir, "missing.yaml"), 0, nil)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read configuration file")
+}
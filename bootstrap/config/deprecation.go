@@ -0,0 +1,76 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+// SetDeprecatedSettings sets the deprecated settings of the private configuration, keyed by their dotted path, i.e.
+// "Writable.Telemetry.PublishTopicPrefix", with a hint of what replaces them, i.e. "use Writable.Telemetry.Topic".
+// Once the configuration has been loaded, a warning with the hint is logged for each deprecated setting present in
+// the configuration loaded from the sources, so operators can update their configuration before the settings are
+// removed. The settings may be present even when the service's configuration struct no longer has them.
+func (cp *Processor) SetDeprecatedSettings(deprecations map[string]string) {
+	cp.deprecatedSettings = deprecations
+}
+
+// warnDeprecatedSettings logs a warning for each deprecated setting present in any of the configuration maps
+func (cp *Processor) warnDeprecatedSettings(lc logger.LoggingClient, configMaps []map[string]any) {
+	for _, path := range cp.presentDeprecatedSettings(configMaps) {
+		lc.Warnf("Configuration setting %s is deprecated and will be removed: %s", path, cp.deprecatedSettings[path])
+	}
+}
+
+// presentDeprecatedSettings returns the sorted paths of the deprecated settings present in any of the configuration maps
+func (cp *Processor) presentDeprecatedSettings(configMaps []map[string]any) []string {
+	paths := make([]string, 0, len(cp.deprecatedSettings))
+	for path := range cp.deprecatedSettings {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var present []string
+	for _, path := range paths {
+		keys := strings.Split(path, ".")
+		for _, configMap := range configMaps {
+			if _, found := lookupConfigMapSetting(configMap, keys); found {
+				present = append(present, path)
+				break
+			}
+		}
+	}
+
+	return present
+}
+
+// lookupConfigMapSetting returns the value of the setting or section with the keys in the configuration map and
+// whether it is present
+func lookupConfigMapSetting(configMap map[string]any, keys []string) (any, bool) {
+	value, found := configMap[keys[0]]
+	if !found || len(keys) == 1 {
+		return value, found
+	}
+
+	section, ok := value.(map[string]any)
+	if !ok {
+		return nil, false
+	}
+
+	return lookupConfigMapSetting(section, keys[1:])
+}
@@ -0,0 +1,119 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// warningLogger records the formatted warning messages so tests can assert on them
+type warningLogger struct {
+	logger.MockLogger
+	warnings []string
+}
+
+func (l *warningLogger) Warnf(msg string, args ...interface{}) {
+	l.warnings = append(l.warnings, fmt.Sprintf(msg, args...))
+}
+
+func TestProcessDeprecatedSettings(t *testing.T) {
+	deprecations := map[string]string{
+		"Writable.StoreAndForward.RetryInterval": "use Writable.StoreAndForward.RetryDelay",
+		"Trigger.SubscribeTopic":                 "use Trigger.SubscribeTopics",
+		"Registry.Host":                          "use Registry.Url",
+	}
+
+	tests := []struct {
+		Name             string
+		FileContents     string
+		Sources          map[string]string
+		ExpectedWarnings []string
+	}{
+		{
+			Name:         "None present",
+			FileContents: "Writable:\n  LogLevel: INFO\nTrigger:\n  Type: edgex-messagebus\n",
+		},
+		{
+			Name:         "Present in file",
+			FileContents: "Writable:\n  LogLevel: INFO\n  StoreAndForward:\n    RetryInterval: 5m\nTrigger:\n  SubscribeTopic: events/#\n",
+			ExpectedWarnings: []string{
+				"Trigger.SubscribeTopic is deprecated and will be removed: use Trigger.SubscribeTopics",
+				"Writable.StoreAndForward.RetryInterval is deprecated and will be removed: use Writable.StoreAndForward.RetryDelay",
+			},
+		},
+		{
+			Name:         "Present in custom source",
+			FileContents: "Writable:\n  LogLevel: INFO\n",
+			Sources:      map[string]string{"Registry.Host": "localhost"},
+			ExpectedWarnings: []string{
+				"Registry.Host is deprecated and will be removed: use Registry.Url",
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(envKeyConfigUrl, "")
+
+			configDir := t.TempDir()
+			require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte(tc.FileContents), 0644))
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir})
+			lc := &warningLogger{}
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(lc), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.SetDeprecatedSettings(deprecations)
+			if len(tc.Sources) > 0 {
+				proc.AddFlatConfigSource("custom", tc.Sources, 10)
+			}
+
+			err := proc.Process("unit-test", config.ServiceTypeOther, "edgex/v3", &ConfigurationMockStruct{}, nil)
+			require.NoError(t, err)
+
+			var actualWarnings []string
+			for _, warning := range lc.warnings {
+				if strings.Contains(warning, "is deprecated") {
+					actualWarnings = append(actualWarnings, warning)
+				}
+			}
+
+			require.Len(t, actualWarnings, len(tc.ExpectedWarnings))
+			for index, expected := range tc.ExpectedWarnings {
+				assert.Contains(t, actualWarnings[index], expected)
+			}
+		})
+	}
+}
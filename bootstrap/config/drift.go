@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// DriftEntry describes a setting whose value in the service's configuration differs from the value held by the
+// Configuration Provider. The values are as stored in the Configuration Provider, i.e. "59880" or "true".
+type DriftEntry struct {
+	// Path is the path of the setting, i.e. "Writable/LogLevel"
+	Path string
+	// MemoryValue is the value of the setting in the service's configuration, empty when InMemory is false
+	MemoryValue string
+	// ProviderValue is the value of the setting in the Configuration Provider, empty when InProvider is false
+	ProviderValue string
+	// InMemory is whether the setting is present in the service's configuration
+	InMemory bool
+	// InProvider is whether the setting is present in the Configuration Provider
+	InProvider bool
+}
+
+// DetectDrift compares serviceConfig against what the Configuration Provider holds for the service and returns the
+// settings which differ, sorted by path, so a divergence between the configuration file and the Configuration Provider
+// is surfaced explicitly. The Configuration Provider's view is merged the same as by Process: the all services common
+// section, then the service type's common section and finally the private settings. The common settings the service's
+// configuration doesn't have are ignored, since the common sections are shared by all services, whereas the private
+// settings it doesn't have are reported. The settings of the service's configuration not present in any section are
+// reported as missing from the Configuration Provider. Each setting is read from the Configuration Provider
+// individually, so this is intended for occasional diagnostics rather than frequent polling. An error wrapping
+// ErrProviderUnavailable is returned when the Configuration Provider isn't used or can't be queried.
+func (cp *Processor) DetectDrift(serviceConfig interfaces.Configuration) ([]DriftEntry, error) {
+	if cp.privateConfigClient == nil {
+		return nil, newProcessError(ErrProviderUnavailable,
+			"unable to detect drift before the configuration has been processed using the Configuration Provider")
+	}
+
+	// The copy is made under the lock, since the watchers may be updating the Writable
+	cp.writableMutex.Lock()
+	currentConfig, err := copyConfigurationStruct(serviceConfig)
+	cp.writableMutex.Unlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var currentMap map[string]any
+	if err := utils.ConvertToMap(currentConfig, &currentMap); err != nil {
+		return nil, fmt.Errorf("failed to convert the service's configuration to map: %w", err)
+	}
+	memoryValues := make(map[string]string)
+	flattenConfigValue("", currentMap, memoryValues)
+
+	providerValues := make(map[string]string)
+	commonSections := []struct {
+		key    string
+		client configuration.Client
+	}{
+		{allServicesKey, cp.commonConfigClient},
+		{appServicesKey, cp.appConfigClient},
+		{deviceServicesKey, cp.deviceConfigClient},
+	}
+	for _, section := range commonSections {
+		if section.client == nil {
+			continue
+		}
+
+		sectionKey := utils.BuildBaseKey(cp.configStem, common.CoreCommonConfigServiceKey, section.key)
+		inMemory := func(path string) bool {
+			_, found := memoryValues[path]
+			return found
+		}
+		if err := readProviderValues(section.client, sectionKey, providerValues, inMemory); err != nil {
+			return nil, newProcessError(ErrProviderUnavailable, "failed to read the common configuration for %s: %w", section.key, err)
+		}
+	}
+	if err := readProviderValues(cp.privateConfigClient, cp.baseKey, providerValues, nil); err != nil {
+		return nil, newProcessError(ErrProviderUnavailable, "failed to read the private configuration: %w", err)
+	}
+
+	var drift []DriftEntry
+	for path, memoryValue := range memoryValues {
+		providerValue, found := providerValues[path]
+		if found && providerValue == memoryValue {
+			continue
+		}
+		drift = append(drift, DriftEntry{
+			Path:          path,
+			MemoryValue:   memoryValue,
+			ProviderValue: providerValue,
+			InMemory:      true,
+			InProvider:    found,
+		})
+	}
+	for path, providerValue := range providerValues {
+		if _, found := memoryValues[path]; !found {
+			drift = append(drift, DriftEntry{Path: path, ProviderValue: providerValue, InProvider: true})
+		}
+	}
+
+	sort.Slice(drift, func(i, j int) bool {
+		return drift[i].Path < drift[j].Path
+	})
+
+	cp.lc.Debugf("Detected %d setting(s) which differ from the Configuration Provider", len(drift))
+	return drift, nil
+}
+
+// readProviderValues reads the values of the settings under the base key from the Configuration Provider into values,
+// keyed by their path relative to the base key. When include is not nil, the settings it doesn't include are skipped.
+func readProviderValues(client configuration.Client, baseKey string, values map[string]string, include func(path string) bool) error {
+	keys, err := client.GetConfigurationKeys("")
+	if err != nil {
+		return err
+	}
+
+	return readProviderKeyValues(client, baseKey, keys, values, include)
+}
+
+// readProviderKeyValues reads the values of the settings with the keys from the Configuration Provider into values, as
+// done by readProviderValues
+func readProviderKeyValues(client configuration.Client, baseKey string, keys []string, values map[string]string,
+	include func(path string) bool) error {
+	for _, key := range keys {
+		path := strings.TrimPrefix(key, baseKey+utils.PathSep)
+		// Keys ending with the separator are the folders of the sections rather than settings
+		if len(path) == 0 || strings.HasSuffix(path, utils.PathSep) {
+			continue
+		}
+		if include != nil && !include(path) {
+			continue
+		}
+
+		value, err := client.GetConfigurationValueByFullPath(key)
+		if err != nil {
+			return err
+		}
+		values[path] = string(value)
+	}
+
+	return nil
+}
+
+// flattenConfigValue flattens the configuration value into values, keyed by path, with the values encoded the same
+// way the Configuration Provider client does when pushing a configuration map, i.e. the slice items are keyed by index
+func flattenConfigValue(path string, value any, values map[string]string) {
+	switch typed := value.(type) {
+	case map[string]any:
+		for key, item := range typed {
+			flattenConfigValue(flatConfigPath(path, key), item, values)
+		}
+	case []any:
+		for index, item := range typed {
+			flattenConfigValue(flatConfigPath(path, strconv.Itoa(index)), item, values)
+		}
+	default:
+		encoded, err := encodeConfigurationValue(typed)
+		if err != nil {
+			encoded = fmt.Sprint(typed)
+		}
+		values[path] = encoded
+	}
+}
+
+// flatConfigPath returns the path of the key within the section at the path
+func flatConfigPath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + utils.PathSep + key
+}
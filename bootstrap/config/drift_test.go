@@ -0,0 +1,132 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// newFakeProviderClient returns a Configuration Provider client holding the values, keyed by their path relative to
+// the base key
+func newFakeProviderClient(baseKey string, values map[string]string) *mocks.Client {
+	client := &mocks.Client{}
+	keys := []string{baseKey + "/"}
+	for path, value := range values {
+		keys = append(keys, baseKey+"/"+path)
+		client.On("GetConfigurationValueByFullPath", baseKey+"/"+path).Return([]byte(value), nil)
+	}
+	sort.Strings(keys)
+	client.On("GetConfigurationKeys", "").Return(keys, nil)
+	return client
+}
+
+func TestDetectDrift(t *testing.T) {
+	f := flags.New()
+	f.Parse(nil)
+	lc := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+	})
+	proc := NewProcessor(f, environment.NewVariables(lc), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+
+	serviceConfig := &ConfigurationMockStruct{
+		Writable: WritableInfo{
+			LogLevel:        models.InfoLog,
+			StoreAndForward: StoreAndForwardInfo{RetryInterval: "5m", MaxRetryCount: 3},
+			Telemetry:       config.TelemetryInfo{Interval: "30s"},
+		},
+		Registry: config.RegistryInfo{Host: "localhost", Port: 8500, Type: "consul"},
+		Trigger:  TriggerInfo{Type: "edgex-messagebus"},
+	}
+
+	_, err := proc.DetectDrift(serviceConfig)
+	require.Error(t, err, "drift can't be detected when the Configuration Provider isn't used")
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+
+	proc.configStem = "edgex/v3"
+	proc.baseKey = "edgex/v3/unit-test"
+	proc.commonConfigClient = newFakeProviderClient("edgex/v3/core-common-config-bootstrapper/all-services", map[string]string{
+		"Writable/InsecureSecrets":    "",
+		"Writable/Telemetry/Interval": "60s",
+		"Writable/Telemetry/Metrics":  "",
+		"Writable/Telemetry/Tags":     "",
+		"Registry/Host":               "localhost",
+		"Registry/Port":               "8500",
+		"Registry/Type":               "consul",
+		// Not one of the service's settings, so ignored
+		"Database/Host": "localhost",
+	})
+	proc.privateConfigClient = newFakeProviderClient("edgex/v3/unit-test", map[string]string{
+		// Edited in the Configuration Provider
+		"Writable/LogLevel":                      models.DebugLog,
+		"Writable/StoreAndForward/Enabled":       "false",
+		"Writable/StoreAndForward/MaxRetryCount": "3",
+		// Overrides the common value
+		"Writable/Telemetry/Interval": "30s",
+		"Trigger/Type":                "edgex-messagebus",
+		// Not one of the service's settings
+		"Trigger/Topic": "events",
+	})
+
+	expected := []DriftEntry{
+		{Path: "Trigger/Topic", ProviderValue: "events", InProvider: true},
+		{Path: "Writable/LogLevel", MemoryValue: models.InfoLog, ProviderValue: models.DebugLog, InMemory: true, InProvider: true},
+		{Path: "Writable/StoreAndForward/RetryInterval", MemoryValue: "5m", InMemory: true},
+	}
+
+	actual, err := proc.DetectDrift(serviceConfig)
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+	assert.Equal(t, models.InfoLog, serviceConfig.Writable.LogLevel, "service's configuration must not be changed")
+
+	// No drift once the service's configuration matches the Configuration Provider
+	serviceConfig.Writable.LogLevel = models.DebugLog
+	serviceConfig.Writable.StoreAndForward.RetryInterval = ""
+	proc.privateConfigClient = newFakeProviderClient("edgex/v3/unit-test", map[string]string{
+		"Writable/LogLevel":                      models.DebugLog,
+		"Writable/StoreAndForward/Enabled":       "false",
+		"Writable/StoreAndForward/MaxRetryCount": "3",
+		"Writable/StoreAndForward/RetryInterval": "",
+		"Writable/Telemetry/Interval":            "30s",
+		"Trigger/Type":                           "edgex-messagebus",
+	})
+	actual, err = proc.DetectDrift(serviceConfig)
+	require.NoError(t, err)
+	assert.Empty(t, actual)
+
+	failingClient := &mocks.Client{}
+	failingClient.On("GetConfigurationKeys", "").Return(nil, errors.New("connection refused"))
+	proc.privateConfigClient = failingClient
+	_, err = proc.DetectDrift(serviceConfig)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+}
@@ -0,0 +1,77 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+)
+
+// The errors returned when processing the configuration wrap one of these errors, so that callers can use errors.Is
+// to determine the kind of failure, i.e. whether retrying may succeed.
+var (
+	// ErrProviderUnavailable indicates the Configuration Provider could not be reached or failed a request
+	ErrProviderUnavailable = errors.New("configuration provider is not available")
+	// ErrConfigParse indicates the configuration could not be parsed or converted into the service's configuration
+	ErrConfigParse = errors.New("failed to parse configuration")
+	// ErrMergeFailed indicates configurations from different sources could not be merged together
+	ErrMergeFailed = errors.New("failed to merge configuration")
+	// ErrCommonConfigNotReady indicates the common configuration has not been loaded into the Configuration Provider
+	ErrCommonConfigNotReady = errors.New("common config is not loaded")
+	// ErrSecretReference indicates a secret referenced by a configuration value could not be resolved
+	ErrSecretReference = errors.New("failed to resolve secret reference")
+	// ErrValidationFailed indicates the configuration failed the service's validation (see interfaces.Validator)
+	ErrValidationFailed = errors.New("configuration validation failed")
+)
+
+// processError classifies a configuration processing error with one of the above errors without changing its message
+type processError struct {
+	kind error
+	err  error
+}
+
+// newProcessError returns an error with the formatted message which wraps both the kind of failure and any error
+// wrapped via %w in the format.
+func newProcessError(kind error, format string, args ...any) error {
+	return &processError{
+		kind: kind,
+		err:  fmt.Errorf(format, args...),
+	}
+}
+
+func (e *processError) Error() string {
+	return e.err.Error()
+}
+
+func (e *processError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// validateConfiguration validates the configuration, named by what in the error, when it implements
+// interfaces.Validator. The validator's error is wrapped along with ErrValidationFailed, so callers can use errors.Is
+// and errors.As with their own validation errors.
+func validateConfiguration(what string, configuration any) error {
+	validator, ok := configuration.(interfaces.Validator)
+	if !ok {
+		return nil
+	}
+
+	if err := validator.Validate(); err != nil {
+		return newProcessError(ErrValidationFailed, "%s validation failed: %w", what, err)
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ConfigSystemEventType is the type of the system event published to the EdgeX MessageBus when a Writable setting is
+// changed, when enabled by SetPublishConfigChanges
+const ConfigSystemEventType = "configuration"
+
+// ConfigChangedDetails are the details of the system event published when a Writable setting is changed
+type ConfigChangedDetails struct {
+	// ServiceKey is the key of the service whose configuration changed
+	ServiceKey string `json:"serviceKey"`
+	// Path is the path of the changed setting, i.e. "Writable/LogLevel"
+	Path string `json:"path"`
+	// Source is the source of the change, i.e. "private" or "common"
+	Source string `json:"source"`
+}
+
+// SetPublishConfigChanges sets whether a system event is published to the EdgeX MessageBus for each Writable setting
+// changed by an update from the Configuration Provider, so the changes can be audited centrally. The events are
+// published to the "<BaseTopicPrefix>/system-events/<serviceKey>/configuration/update/<serviceKey>" topic, using the
+// MessageBus client from the DIC. When no MessageBus client has been added to the DIC the events aren't published. By
+// default no events are published.
+func (cp *Processor) SetPublishConfigChanges(enabled bool) {
+	cp.publishConfigChanges = enabled
+}
+
+// publishWritableChanges publishes a configuration changed system event for each setting that differs between the
+// previous and current Writable
+func (cp *Processor) publishWritableChanges(lc logger.LoggingClient, serviceConfig interfaces.Configuration, previousWritable map[string]any, source string) {
+	var currentWritable map[string]any
+	if err := utils.ConvertToMap(serviceConfig.GetWritablePtr(), &currentWritable); err != nil {
+		lc.Errorf("failed to convert Writable to map, so unable to publish the configuration changes: %v", err)
+		return
+	}
+
+	diffs := utils.DiffMaps(previousWritable, currentWritable)
+	if len(diffs) == 0 {
+		return
+	}
+
+	// App Services create the messaging client after bootstrapping, so must get it from the DIC each time
+	messageClient := container.MessagingClientFrom(cp.dic.Get)
+	if messageClient == nil {
+		lc.Warnf("MessageBus client not available. Unable to publish %d configuration change(s)", len(diffs))
+		return
+	}
+
+	baseTopic := common.DefaultBaseTopic
+	if messageBus := serviceConfig.GetBootstrap().MessageBus; messageBus != nil {
+		baseTopic = messageBus.GetBaseTopicPrefix()
+	}
+	topic := common.BuildTopic(baseTopic, common.SystemEventPublishTopic, cp.serviceKey, ConfigSystemEventType,
+		common.SystemEventActionUpdate, cp.serviceKey)
+
+	for _, diff := range diffs {
+		details := ConfigChangedDetails{
+			ServiceKey: cp.serviceKey,
+			Path:       utils.BuildBaseKey(writableKey, diff.Path),
+			Source:     source,
+		}
+		systemEvent := dtos.NewSystemEvent(ConfigSystemEventType, common.SystemEventActionUpdate, cp.serviceKey,
+			cp.serviceKey, nil, details)
+
+		payload, err := json.Marshal(systemEvent)
+		if err != nil {
+			lc.Errorf("failed to marshal configuration changed event for '%s' to JSON: %v", details.Path, err)
+			continue
+		}
+
+		message := types.MessageEnvelope{
+			CorrelationID: uuid.NewString(),
+			Payload:       payload,
+			ContentType:   common.ContentTypeJSON,
+		}
+
+		if err := messageClient.Publish(message, topic); err != nil {
+			lc.Warnf("failed to publish configuration changed event for '%s' to topic '%s': %v", details.Path, topic, err)
+			continue
+		}
+
+		lc.Debugf("Published configuration changed event for '%s' to topic '%s'", details.Path, topic)
+	}
+}
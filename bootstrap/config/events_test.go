@@ -0,0 +1,99 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos"
+	"github.com/edgexfoundry/go-mod-messaging/v3/messaging/mocks"
+	"github.com/edgexfoundry/go-mod-messaging/v3/pkg/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestApplyWritableUpdatesPublishConfigChanges(t *testing.T) {
+	expectedTopic := "edgex/system-events/unit-test/configuration/update/unit-test"
+
+	var published []types.MessageEnvelope
+	messageClient := &mocks.MessageClient{}
+	messageClient.On("Publish", mock.Anything, expectedTopic).Run(func(args mock.Arguments) {
+		published = append(published, args.Get(0).(types.MessageEnvelope))
+	}).Return(nil)
+
+	f := flags.New()
+	f.Parse(nil)
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+		container.MessagingClientName:        func(get di.Get) interface{} { return messageClient },
+	})
+
+	proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+	proc.serviceKey = "unit-test"
+	proc.SetPublishConfigChanges(true)
+
+	serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+	proc.applyWritableUpdates(proc.lc, serviceConfig, WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{MaxRetryCount: 5}}, "common")
+
+	require.Len(t, published, 1)
+	assert.Equal(t, common.ContentTypeJSON, published[0].ContentType)
+
+	systemEvent := dtos.SystemEvent{}
+	require.NoError(t, json.Unmarshal(published[0].Payload, &systemEvent))
+	assert.Equal(t, ConfigSystemEventType, systemEvent.Type)
+	assert.Equal(t, common.SystemEventActionUpdate, systemEvent.Action)
+	assert.Equal(t, "unit-test", systemEvent.Source)
+
+	details := ConfigChangedDetails{}
+	require.NoError(t, systemEvent.DecodeDetails(&details))
+	assert.Equal(t, ConfigChangedDetails{ServiceKey: "unit-test", Path: "Writable/StoreAndForward/MaxRetryCount", Source: "common"}, details)
+
+	// No event when nothing has changed
+	proc.applyWritableUpdates(proc.lc, serviceConfig, WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{MaxRetryCount: 5}}, "common")
+	assert.Len(t, published, 1)
+}
+
+func TestApplyWritableUpdatesPublishConfigChangesNoClient(t *testing.T) {
+	f := flags.New()
+	f.Parse(nil)
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+
+	configUpdated := make(UpdatedStream, 1)
+	proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, configUpdated, dic)
+	proc.SetPublishConfigChanges(true)
+
+	serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+	proc.applyWritableUpdates(proc.lc, serviceConfig, WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{Enabled: true}}, "private")
+
+	// The update is still applied when the events can't be published
+	assert.True(t, serviceConfig.Writable.StoreAndForward.Enabled)
+	assert.Len(t, configUpdated, 1)
+}
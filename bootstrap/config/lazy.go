@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// SetLazyConfigSections sets the top-level sections of the private configuration, i.e. "DeviceList", which aren't
+// merged into the service's configuration by Process when lazy loading is enabled by the --lazyConfig flag. Rather,
+// each is loaded from the Configuration Provider when the service first accesses it, by calling LoadLazyConfigSection,
+// which reduces the startup work and memory used by services with large configurations. The Writable section is always loaded by Process, since it is
+// watched for changes. The sections are loaded as usual when the private configuration is loaded from file.
+func (cp *Processor) SetLazyConfigSections(sections ...string) {
+	cp.lazySections = nil
+	for _, section := range sections {
+		if section != writableKey {
+			cp.lazySections = append(cp.lazySections, section)
+		}
+	}
+}
+
+// LoadLazyConfigSection loads the lazily loaded section of the private configuration, as set by
+// SetLazyConfigSections, from the Configuration Provider into serviceConfig. It must be called before the service
+// accesses the section. The section is only loaded the first time it is called since the configuration was loaded
+// from the Configuration Provider, so it is cheap to call each time the section is accessed. Nothing is done when lazy
+// loading isn't enabled, the section isn't loaded lazily or the private configuration was loaded from file.
+func (cp *Processor) LoadLazyConfigSection(serviceConfig interfaces.Configuration, section string) error {
+	cp.lazyMutex.Lock()
+	defer cp.lazyMutex.Unlock()
+
+	if !cp.deferredSections[section] {
+		return nil
+	}
+
+	lc := cp.operationLogger("LoadLazyConfigSection", "section", section)
+	started := time.Now()
+	keys, err := cp.privateConfigClient.GetConfigurationKeys(section)
+	if err != nil {
+		return newProcessError(ErrProviderUnavailable, "failed to get the keys of configuration section %s: %w", section, err)
+	}
+
+	values := make(map[string]string)
+	inSection := func(path string) bool {
+		return strings.HasPrefix(path, section+utils.PathSep)
+	}
+	if err := readProviderKeyValues(cp.privateConfigClient, cp.baseKey, keys, values, inSection); err != nil {
+		return newProcessError(ErrProviderUnavailable, "failed to read configuration section %s: %w", section, err)
+	}
+	cp.metrics.providerRoundTrip.Update(millisecondsSince(started))
+
+	settings, err := utils.ExpandMap(values, utils.PathSep)
+	if err != nil {
+		return newProcessError(ErrConfigParse, "failed to expand configuration section %s: %w", section, err)
+	}
+
+	// The section is updated under the lock, since the watchers may be updating the Writable
+	cp.writableMutex.Lock()
+	err = utils.CoerceToStruct(settings, serviceConfig)
+	cp.writableMutex.Unlock()
+	if err != nil {
+		return newProcessError(ErrConfigParse, "failed to load configuration section %s: %w", section, err)
+	}
+
+	delete(cp.deferredSections, section)
+	lc.Infof("Configuration section loaded lazily from the Configuration Provider with %d settings", len(values))
+	return nil
+}
+
+// lazyConfig returns whether the lazily loaded sections aren't loaded by Process, as specified by the --lazyConfig flag
+func (cp *Processor) lazyConfig() bool {
+	option, ok := cp.flags.(flags.LazyConfigOption)
+	return ok && option.LazyConfig() && len(cp.lazySections) > 0
+}
+
+// lazySectionOf returns the lazily loaded section the setting at the path is within, if any
+func (cp *Processor) lazySectionOf(path string) (string, bool) {
+	section, _, _ := strings.Cut(path, utils.PathSep)
+	for _, lazySection := range cp.lazySections {
+		if section == lazySection {
+			return section, true
+		}
+	}
+	return "", false
+}
+
+// setDeferredSections sets the lazily loaded sections which haven't been loaded since the configuration was loaded
+func (cp *Processor) setDeferredSections(sections map[string]bool) {
+	cp.lazyMutex.Lock()
+	cp.deferredSections = sections
+	cp.lazyMutex.Unlock()
+}
+
+// loadPartial loads the private settings from the Configuration Provider into privateServiceConfig, the same as when
+// lazy loading isn't enabled, but returns only the keys of the settings outside the lazily loaded sections. So the
+// lazily loaded sections are removed along with the other unused settings, rather than being merged into the
+// service's configuration, until they are loaded by LoadLazyConfigSection. The whole configuration is read in a single
+// request, since reading the other settings individually is slower for all but the smallest configurations.
+func (s *providerConfigSource) loadPartial(privateServiceConfig interfaces.Configuration) ([]string, error) {
+	configKeys, err := s.client.GetConfigurationKeys("")
+	if err != nil {
+		return nil, newProcessError(ErrProviderUnavailable, "%w", err)
+	}
+
+	var loadedKeys []string
+	deferred := make(map[string]bool)
+	for _, key := range configKeys {
+		if section, lazy := s.cp.lazySectionOf(strings.TrimPrefix(key, s.baseKey+utils.PathSep)); lazy {
+			deferred[section] = true
+			continue
+		}
+		loadedKeys = append(loadedKeys, key)
+	}
+
+	if err := s.cp.loadConfigFromProvider(privateServiceConfig, s.client); err != nil {
+		return nil, err
+	}
+
+	s.cp.setDeferredSections(deferred)
+	s.lc.Infof("Private configuration loaded partially from the Configuration Provider, deferring %d lazily loaded section(s)", len(deferred))
+	return loadedKeys, nil
+}
@@ -0,0 +1,126 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestLoadLazyConfigSection(t *testing.T) {
+	baseKey := "edgex/v3/unit-test"
+	providerConfig := &ConfigurationMockStruct{
+		Writable: WritableInfo{
+			LogLevel:        models.DebugLog,
+			StoreAndForward: StoreAndForwardInfo{Enabled: true, MaxRetryCount: 5},
+			Telemetry:       config.TelemetryInfo{Metrics: map[string]bool{"EventsSent": true}},
+		},
+		Registry: config.RegistryInfo{Host: "provider-host", Port: 8501},
+		Trigger:  TriggerInfo{Type: "http"},
+	}
+
+	// loadConfig loads the private configuration from the Configuration Provider, as done by Process, and then each
+	// section as it is accessed by the service, returning the configuration before and after the sections are accessed
+	loadConfig := func(t *testing.T, lazy bool) (*ConfigurationMockStruct, *ConfigurationMockStruct) {
+		client := newFakeProviderClient(baseKey, map[string]string{
+			"Writable/LogLevel":                      models.DebugLog,
+			"Writable/StoreAndForward/Enabled":       "true",
+			"Writable/StoreAndForward/MaxRetryCount": "5",
+			"Writable/Telemetry/Metrics/EventsSent":  "true",
+			"Writable/InsecureSecrets":               "",
+			"Registry/Host":                          "provider-host",
+			"Registry/Port":                          "8501",
+			"Trigger/Type":                           "http",
+		})
+		client.On("GetConfiguration", mock.Anything).Return(providerConfig, nil)
+		client.On("GetConfigurationKeys", "Registry").Return([]string{baseKey + "/Registry/", baseKey + "/Registry/Host", baseKey + "/Registry/Port"}, nil).Once()
+		client.On("GetConfigurationKeys", "Trigger").Return([]string{baseKey + "/Trigger/Type"}, nil).Once()
+
+		args := []string{}
+		if lazy {
+			args = append(args, "--lazyConfig")
+		}
+		f := flags.New()
+		f.Parse(args)
+		lc := logger.NewMockClient()
+		dic := di.NewContainer(di.ServiceConstructorMap{
+			container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+		})
+		proc := NewProcessor(f, environment.NewVariables(lc), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+		proc.SetLazyConfigSections("Registry", "Trigger", "Writable")
+		proc.privateConfigClient = client
+		proc.baseKey = baseKey
+
+		// The defaults from the common configuration
+		serviceConfig := &ConfigurationMockStruct{
+			Writable: WritableInfo{StoreAndForward: StoreAndForwardInfo{RetryInterval: "5m"}},
+			Registry: config.RegistryInfo{Type: "consul"},
+		}
+		source := &providerConfigSource{cp: proc, lc: lc, serviceConfig: serviceConfig, client: client, baseKey: baseKey}
+		configMap, err := source.Load()
+		require.NoError(t, err)
+		require.NoError(t, utils.MergeValues(serviceConfig, configMap))
+
+		loaded, err := copyConfigurationStruct(serviceConfig)
+		require.NoError(t, err)
+
+		for _, section := range []string{"Registry", "Trigger", "Registry"} {
+			require.NoError(t, proc.LoadLazyConfigSection(serviceConfig, section))
+		}
+
+		// The settings are loaded with a single request either way, while the lazily loaded sections are each read
+		// setting by setting when accessed
+		client.AssertNumberOfCalls(t, "GetConfiguration", 1)
+		if lazy {
+			client.AssertNumberOfCalls(t, "GetConfigurationValueByFullPath", 3)
+		} else {
+			client.AssertNotCalled(t, "GetConfigurationKeys", "Registry")
+			client.AssertNotCalled(t, "GetConfigurationValueByFullPath", mock.Anything)
+		}
+		return loaded.(*ConfigurationMockStruct), serviceConfig
+	}
+
+	fullLoaded, fullConfig := loadConfig(t, false)
+	lazyLoaded, lazyConfig := loadConfig(t, true)
+
+	// The settings accessed by the service are the same
+	assert.Equal(t, fullConfig, lazyConfig)
+	assert.Equal(t, "provider-host", lazyConfig.Registry.Host)
+	assert.Equal(t, 8501, lazyConfig.Registry.Port)
+	assert.Equal(t, "consul", lazyConfig.Registry.Type)
+	assert.Equal(t, "http", lazyConfig.Trigger.Type)
+	assert.Equal(t, "5m", lazyConfig.Writable.StoreAndForward.RetryInterval)
+
+	// Only the lazily loaded sections are deferred, with the Writable always loaded
+	assert.Equal(t, fullConfig, fullLoaded)
+	assert.Equal(t, fullConfig.Writable, lazyLoaded.Writable)
+	assert.Equal(t, config.RegistryInfo{Type: "consul"}, lazyLoaded.Registry)
+	assert.Empty(t, lazyLoaded.Trigger.Type)
+}
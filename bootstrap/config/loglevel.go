@@ -0,0 +1,100 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+// clock provides the current time and timers, so that tests can control the passing of time
+type clock interface {
+	Now() time.Time
+	AfterFunc(d time.Duration, f func()) stoppable
+}
+
+// stoppable is a timer which can be stopped before it fires
+type stoppable interface {
+	Stop() bool
+}
+
+type realClock struct{}
+
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+func (realClock) AfterFunc(d time.Duration, f func()) stoppable {
+	return time.AfterFunc(d, f)
+}
+
+// SetLogLevelChangeDelay sets how log level changes from the Writable configuration are applied, which by default is
+// immediately. A log level change is only applied once the log level has not changed again for the settle duration,
+// so rapid changes only apply the final log level, and not until at least minDwell after the previous log level change
+// was applied, so a misbehaving Configuration Provider can't thrash the log level.
+func (cp *Processor) SetLogLevelChangeDelay(settle time.Duration, minDwell time.Duration) {
+	cp.logLevelMutex.Lock()
+	defer cp.logLevelMutex.Unlock()
+
+	cp.logLevelSettle = settle
+	cp.logLevelMinDwell = minDwell
+}
+
+// applyLogLevelChange sets the logging client's level to the changed log level, once the delay, if any, has passed
+func (cp *Processor) applyLogLevelChange(lc logger.LoggingClient, logLevel string) {
+	cp.logLevelMutex.Lock()
+	defer cp.logLevelMutex.Unlock()
+
+	delay := cp.logLevelSettle
+	if !cp.logLevelChangedAt.IsZero() {
+		if dwell := cp.logLevelChangedAt.Add(cp.logLevelMinDwell).Sub(cp.clock.Now()); dwell > delay {
+			delay = dwell
+		}
+	}
+
+	// A pending change is replaced by the latest log level, so the delay starts again
+	if cp.logLevelTimer != nil {
+		cp.logLevelTimer.Stop()
+		cp.logLevelTimer = nil
+	}
+
+	if delay <= 0 {
+		cp.setLogLevel(lc, logLevel)
+		return
+	}
+
+	lc.Infof("Logging level change to %s will be applied in %s unless changed again", logLevel, delay)
+	cp.logLevelTimer = cp.clock.AfterFunc(delay, func() {
+		cp.logLevelMutex.Lock()
+		defer cp.logLevelMutex.Unlock()
+
+		cp.logLevelTimer = nil
+		if lc.LogLevel() == logLevel {
+			lc.Debugf("Logging level settled on the current level of %s", logLevel)
+			return
+		}
+
+		cp.setLogLevel(lc, logLevel)
+	})
+}
+
+// setLogLevel sets the logging client's level and records when it was changed. The logLevelMutex must be held.
+func (cp *Processor) setLogLevel(lc logger.LoggingClient, logLevel string) {
+	_ = lc.SetLogLevel(logLevel)
+	cp.logLevelChangedAt = cp.clock.Now()
+	lc.Info(fmt.Sprintf("Logging level changed to %s", logLevel))
+}
@@ -0,0 +1,142 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// fakeClock is a clock whose time only passes, firing the due timers, when advanced
+type fakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+}
+
+type fakeTimer struct {
+	at      time.Time
+	f       func()
+	stopped bool
+}
+
+func (t *fakeTimer) Stop() bool {
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) AfterFunc(d time.Duration, f func()) stoppable {
+	timer := &fakeTimer{at: c.now.Add(d), f: f}
+	c.timers = append(c.timers, timer)
+	return timer
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+	for _, timer := range c.timers {
+		if !timer.stopped && !timer.at.After(c.now) {
+			timer.stopped = true
+			timer.f()
+		}
+	}
+}
+
+func TestApplyLogLevelChangeDelay(t *testing.T) {
+	newProcessor := func(settle time.Duration, minDwell time.Duration) (*Processor, *leveledLogger, *fakeClock) {
+		f := flags.New()
+		f.Parse(nil)
+		lc := &leveledLogger{logLevel: models.InfoLog}
+		dic := di.NewContainer(di.ServiceConstructorMap{
+			container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+		})
+
+		proc := NewProcessor(f, environment.NewVariables(lc), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+		clock := &fakeClock{now: time.Now()}
+		proc.clock = clock
+		proc.SetLogLevelChangeDelay(settle, minDwell)
+		return proc, lc, clock
+	}
+
+	changeLogLevel := func(proc *Processor, serviceConfig *ConfigurationMockStruct, logLevel string) {
+		proc.applyWritableUpdates(proc.lc, serviceConfig, WritableInfo{LogLevel: logLevel}, "private")
+	}
+
+	t.Run("No delay", func(t *testing.T) {
+		proc, lc, _ := newProcessor(0, 0)
+		serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: models.InfoLog}}
+
+		changeLogLevel(proc, serviceConfig, models.DebugLog)
+		assert.Equal(t, models.DebugLog, lc.LogLevel())
+	})
+
+	t.Run("Settle", func(t *testing.T) {
+		proc, lc, clock := newProcessor(5*time.Second, 0)
+		serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: models.InfoLog}}
+
+		changeLogLevel(proc, serviceConfig, models.DebugLog)
+		clock.Advance(2 * time.Second)
+		assert.Equal(t, models.InfoLog, lc.LogLevel())
+
+		// Changing again restarts the settle period, and only the last log level is applied
+		changeLogLevel(proc, serviceConfig, models.TraceLog)
+		clock.Advance(4 * time.Second)
+		assert.Equal(t, models.InfoLog, lc.LogLevel())
+		clock.Advance(time.Second)
+		assert.Equal(t, models.TraceLog, lc.LogLevel())
+
+		// Flipping back to the current log level within the settle period leaves it unchanged
+		changeLogLevel(proc, serviceConfig, models.DebugLog)
+		clock.Advance(time.Second)
+		changeLogLevel(proc, serviceConfig, models.TraceLog)
+		clock.Advance(5 * time.Second)
+		assert.Equal(t, models.TraceLog, lc.LogLevel())
+	})
+
+	t.Run("Minimum dwell", func(t *testing.T) {
+		proc, lc, clock := newProcessor(0, 30*time.Second)
+		serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: models.InfoLog}}
+
+		// The first change has no previous change to dwell on
+		changeLogLevel(proc, serviceConfig, models.DebugLog)
+		assert.Equal(t, models.DebugLog, lc.LogLevel())
+
+		clock.Advance(10 * time.Second)
+		changeLogLevel(proc, serviceConfig, models.ErrorLog)
+		clock.Advance(19 * time.Second)
+		assert.Equal(t, models.DebugLog, lc.LogLevel())
+		clock.Advance(time.Second)
+		assert.Equal(t, models.ErrorLog, lc.LogLevel())
+
+		// Once the dwell time has passed changes are immediate again
+		clock.Advance(30 * time.Second)
+		changeLogLevel(proc, serviceConfig, models.InfoLog)
+		assert.Equal(t, models.InfoLog, lc.LogLevel())
+	})
+}
@@ -0,0 +1,112 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ProcessFromMap loads the service's configuration from the in-memory source, which has the same structure as the
+// configuration file, rather than from the configuration files and the Configuration Provider, so the service can be
+// embedded in another application or used in tests without touching the filesystem or a Configuration Provider. The
+// environment variable overrides are applied to the source, which is then merged into serviceConfig, along with the
+// custom sources added by AddConfigSource, the same as the private configuration is by Process. The service's
+// configuration is then validated, if it implements interfaces.Validator, and the log level set. The source isn't
+// changed and nothing is watched for changes.
+func (cp *Processor) ProcessFromMap(serviceConfig interfaces.Configuration, source map[string]any) error {
+	lc := cp.operationLogger("ProcessFromMap")
+	loadStarted := time.Now()
+
+	cp.envVars.SetServiceType(cp.serviceType)
+	cp.serviceConfig = serviceConfig
+	cp.result = ProcessResult{}
+	cp.provenance = nil
+	if cp.trackProvenance {
+		cp.provenance = make(map[string]string)
+	}
+
+	var err error
+	cp.baseConfig, err = copyConfigurationStruct(serviceConfig)
+	if err != nil {
+		return err
+	}
+
+	memorySource := &memoryConfigSource{cp: cp, lc: lc, source: source}
+	if err := cp.loadConfigSources(lc, serviceConfig, memorySource); err != nil {
+		return err
+	}
+
+	cp.metrics.configLoadDuration.Update(millisecondsSince(loadStarted))
+
+	if err := cp.checkUnmatchedOverrides(serviceConfig); err != nil {
+		return err
+	}
+
+	if err := validateConfiguration("configuration", serviceConfig); err != nil {
+		return err
+	}
+	if _, ok := serviceConfig.(interfaces.Validator); ok {
+		lc.Debug("Configuration passed validation")
+	}
+
+	logLevel := serviceConfig.GetLogLevel()
+	if flagLogLevel := logLevelFlag(cp.flags); len(flagLogLevel) > 0 {
+		logLevel = flagLogLevel
+	}
+	if err := lc.SetLogLevel(logLevel); err != nil {
+		return err
+	}
+
+	cp.completedMutex.Lock()
+	cp.bootstrapCompletedAt = time.Now()
+	cp.completedMutex.Unlock()
+	lc.Info("Configuration processing from memory completed")
+
+	return nil
+}
+
+// memoryConfigSource is the built-in source of the private configuration when processed by ProcessFromMap
+type memoryConfigSource struct {
+	cp     *Processor
+	lc     logger.LoggingClient
+	source map[string]any
+	// overrideCount is the number of overrides applied to the configuration last loaded
+	overrideCount int
+}
+
+func (s *memoryConfigSource) Name() string {
+	return "in-memory configuration"
+}
+
+// Load returns a copy of the in-memory configuration with the environment variable overrides applied
+func (s *memoryConfigSource) Load() (map[string]any, error) {
+	// The overrides are applied to a copy, so the caller's source is left unchanged
+	configMap := make(map[string]any)
+	utils.MergeMaps(configMap, s.source)
+
+	overrideCount, err := s.cp.envVars.OverrideConfigMapValues(configMap)
+	if err := s.cp.checkRejectedOverrides(s.lc, err); err != nil {
+		return nil, err
+	}
+	s.lc.Infof("Private configuration loaded from memory with %d overrides applied", overrideCount)
+
+	s.overrideCount = overrideCount
+	return configMap, nil
+}
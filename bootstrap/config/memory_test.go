@@ -0,0 +1,176 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestProcessFromMap(t *testing.T) {
+	source := map[string]any{
+		"Writable": map[string]any{
+			"LogLevel":        models.InfoLog,
+			"StoreAndForward": map[string]any{"Enabled": true, "MaxRetryCount": 3},
+		},
+		"Registry": map[string]any{"Host": "memory-host", "Port": 8500},
+	}
+
+	tests := []struct {
+		Name           string
+		EnvVars        map[string]string
+		Sources        []*testConfigSource
+		Strict         bool
+		Rejected       int
+		ExpectedConfig *ConfigurationMockStruct
+		ExpectedError  string
+	}{
+		{
+			Name: "Valid - merged over defaults",
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: models.InfoLog, StoreAndForward: StoreAndForwardInfo{Enabled: true, RetryInterval: "5m", MaxRetryCount: 3}},
+				Registry: config.RegistryInfo{Host: "memory-host", Port: 8500, Type: "consul"},
+			},
+		},
+		{
+			Name:    "Valid - overrides applied",
+			EnvVars: map[string]string{"WRITABLE_LOGLEVEL": models.DebugLog, "REGISTRY_PORT": "8501"},
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: models.DebugLog, StoreAndForward: StoreAndForwardInfo{Enabled: true, RetryInterval: "5m", MaxRetryCount: 3}},
+				Registry: config.RegistryInfo{Host: "memory-host", Port: 8501, Type: "consul"},
+			},
+		},
+		{
+			Name:    "Valid - custom source merged over",
+			Sources: []*testConfigSource{{name: "override database", configMap: map[string]any{"Trigger": map[string]any{"Type": "http"}}}},
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: models.InfoLog, StoreAndForward: StoreAndForwardInfo{Enabled: true, RetryInterval: "5m", MaxRetryCount: 3}},
+				Registry: config.RegistryInfo{Host: "memory-host", Port: 8500, Type: "consul"},
+				Trigger:  TriggerInfo{Type: "http"},
+			},
+		},
+		{
+			Name:     "Valid - override type rejected",
+			EnvVars:  map[string]string{"WRITABLE_LOGLEVEL": models.DebugLog, "REGISTRY_PORT": "not-a-port"},
+			Rejected: 1,
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: models.DebugLog, StoreAndForward: StoreAndForwardInfo{Enabled: true, RetryInterval: "5m", MaxRetryCount: 3}},
+				Registry: config.RegistryInfo{Host: "memory-host", Port: 8500, Type: "consul"},
+			},
+		},
+		{
+			Name:          "Invalid - strict override type",
+			EnvVars:       map[string]string{"REGISTRY_PORT": "not-a-port"},
+			Strict:        true,
+			ExpectedError: "REGISTRY_PORT='not-a-port' is not a valid int for Registry/Port",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Neither the Configuration Provider nor the configuration directory exist, so must not be used
+			t.Setenv(envKeyConfigUrl, "consul.http://localhost:1")
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			args := []string{"-cd", filepath.Join(t.TempDir(), "missing")}
+			if tc.Strict {
+				args = append(args, "--strictOverrides")
+			}
+			f := flags.New()
+			f.Parse(args)
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.SetTrackProvenance(true)
+			for _, customSource := range tc.Sources {
+				proc.AddConfigSource(customSource, 10)
+			}
+
+			serviceConfig := &ConfigurationMockStruct{
+				Writable: WritableInfo{StoreAndForward: StoreAndForwardInfo{RetryInterval: "5m"}},
+				Registry: config.RegistryInfo{Type: "consul"},
+			}
+			err := proc.ProcessFromMap(serviceConfig, source)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedConfig, serviceConfig)
+			assert.Equal(t, models.InfoLog, source["Writable"].(map[string]any)["LogLevel"], "source must not be changed")
+			assert.Equal(t, "in-memory configuration", proc.Result().Source)
+			assert.Equal(t, len(tc.EnvVars)-tc.Rejected, proc.Result().PrivateOverrideCount)
+			assert.False(t, proc.BootstrapCompletedAt().IsZero())
+
+			assert.Equal(t, ProvenanceMemory, proc.Provenance()["Registry.Host"])
+			if len(tc.EnvVars) > 0 {
+				assert.Equal(t, ProvenanceEnvOverride, proc.Provenance()["Writable.LogLevel"])
+			}
+		})
+	}
+}
+
+func TestProcessFromMapCustomLoggingClient(t *testing.T) {
+	t.Setenv(envKeyConfigUrl, "")
+
+	var mutex sync.Mutex
+	var logged []string
+	lc := utils.NewFuncLogger(models.InfoLog, func(logLevel string, msg string, _ []any) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		logged = append(logged, logLevel+": "+msg)
+	})
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+	})
+
+	f := flags.New()
+	f.Parse([]string{"-cd", filepath.Join(t.TempDir(), "missing")})
+	proc := NewProcessor(f, environment.NewVariables(lc), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+
+	serviceConfig := &ConfigurationMockStruct{}
+	source := map[string]any{"Writable": map[string]any{"LogLevel": models.DebugLog}}
+	require.NoError(t, proc.ProcessFromMap(serviceConfig, source))
+
+	// The service's log level is set on the custom client
+	assert.Equal(t, models.DebugLog, lc.LogLevel())
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Contains(t, logged, "INFO: [operation=ProcessFromMap] Private configuration loaded from memory with 0 overrides applied")
+	assert.Contains(t, logged, "INFO: [operation=ProcessFromMap] Configuration processing from memory completed")
+}
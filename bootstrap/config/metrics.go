@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	gometrics "github.com/rcrowley/go-metrics"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+)
+
+// configuration processing Metric Names
+const (
+	configLoadDurationMetricName       = "ConfigLoadDurationMs"
+	commonConfigLoadDurationMetricName = "CommonConfigLoadDurationMs"
+	configFileLoadDurationMetricName   = "ConfigFileLoadDurationMs"
+	providerRoundTripMetricName        = "ProviderRoundTripMs"
+
+	metricsSampleSize = 100
+)
+
+// processorMetrics are the metrics collected while processing the configuration. They are always collected, so they
+// are available to register once the MetricsManager has been created.
+type processorMetrics struct {
+	// configLoadDuration is the time taken to load and merge the configuration from all sources
+	configLoadDuration gometrics.Gauge
+	// commonConfigLoadDuration is the time taken to load the common configuration from the provider or file
+	commonConfigLoadDuration gometrics.Gauge
+	// configFileLoadDuration is the time taken to read and parse each configuration file
+	configFileLoadDuration gometrics.Histogram
+	// providerRoundTrip is the time taken by each request for configuration from the Configuration Provider
+	providerRoundTrip gometrics.Histogram
+}
+
+func newProcessorMetrics() processorMetrics {
+	return processorMetrics{
+		configLoadDuration:       gometrics.NewGauge(),
+		commonConfigLoadDuration: gometrics.NewGauge(),
+		configFileLoadDuration:   gometrics.NewHistogram(gometrics.NewUniformSample(metricsSampleSize)),
+		providerRoundTrip:        gometrics.NewHistogram(gometrics.NewUniformSample(metricsSampleSize)),
+	}
+}
+
+// GetMetricsToRegister returns all metric objects that needs to be registered.
+func (cp *Processor) GetMetricsToRegister() map[string]interface{} {
+	return map[string]interface{}{
+		configLoadDurationMetricName:       cp.metrics.configLoadDuration,
+		commonConfigLoadDurationMetricName: cp.metrics.commonConfigLoadDuration,
+		configFileLoadDurationMetricName:   cp.metrics.configFileLoadDuration,
+		providerRoundTripMetricName:        cp.metrics.providerRoundTrip,
+	}
+}
+
+// registerMetrics registers the metrics not already registered when the MetricsManager is available. Typically it is
+// created by a bootstrap handler after the configuration has been processed, in which case the metrics are registered
+// at the end of bootstrapping instead.
+func (cp *Processor) registerMetrics(lc logger.LoggingClient) {
+	metricsManager := container.MetricsManagerFrom(cp.dic.Get)
+	if metricsManager == nil {
+		lc.Debug("MetricsManager not available. Skipping registration of configuration load metrics")
+		return
+	}
+
+	for metricName, metric := range cp.GetMetricsToRegister() {
+		if metricsManager.IsRegistered(metricName) {
+			continue
+		}
+
+		if err := metricsManager.Register(metricName, metric, nil); err != nil {
+			lc.Warnf("Unable to register %s metric for reporting: %v", metricName, err)
+			continue
+		}
+
+		lc.Debugf("%s metric registered and will be reported (if enabled)", metricName)
+	}
+}
+
+// millisecondsSince returns the elapsed time in milliseconds since the start time
+func millisecondsSince(start time.Time) int64 {
+	return time.Since(start).Milliseconds()
+}
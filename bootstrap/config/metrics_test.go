@@ -0,0 +1,110 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	mockInterfaces "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestProcessLoadMetrics(t *testing.T) {
+	configDir := t.TempDir()
+	fileContents := "Writable:\n  LogLevel: INFO\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte(fileContents), 0644))
+	commonContents := "all-services:\n  Registry:\n    Host: localhost\n"
+	commonFile := filepath.Join(configDir, "common.yaml")
+	require.NoError(t, os.WriteFile(commonFile, []byte(commonContents), 0644))
+
+	tests := []struct {
+		Name              string
+		UseMetricsManager bool
+	}{
+		{"With MetricsManager", true},
+		{"Without MetricsManager", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests in this package may leave the provider override set, so ensure the files are used
+			t.Setenv(envKeyConfigUrl, "")
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir, "-cc", commonFile})
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			metricsManager := &mockInterfaces.MetricsManager{}
+			if tc.UseMetricsManager {
+				metricsManager.On("IsRegistered", mock.Anything).Return(false)
+				metricsManager.On("Register", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+				dic.Update(di.ServiceConstructorMap{
+					container.MetricsManagerInterfaceName: func(get di.Get) interface{} { return metricsManager },
+				})
+			}
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			err := proc.Process("unit-test", config.ServiceTypeOther, "edgex/v3", &ConfigurationMockStruct{}, nil)
+			require.NoError(t, err)
+
+			// The metrics are collected regardless of whether they are registered
+			assert.Equal(t, int64(2), proc.metrics.configFileLoadDuration.Count())
+
+			if !tc.UseMetricsManager {
+				metricsManager.AssertNotCalled(t, "Register", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+
+			metricsManager.AssertCalled(t, "Register", configLoadDurationMetricName, proc.metrics.configLoadDuration, mock.Anything)
+			metricsManager.AssertCalled(t, "Register", commonConfigLoadDurationMetricName, proc.metrics.commonConfigLoadDuration, mock.Anything)
+			metricsManager.AssertCalled(t, "Register", configFileLoadDurationMetricName, proc.metrics.configFileLoadDuration, mock.Anything)
+			metricsManager.AssertCalled(t, "Register", providerRoundTripMetricName, proc.metrics.providerRoundTrip, mock.Anything)
+		})
+	}
+}
+
+func TestLoadConfigFromProviderRoundTripMetric(t *testing.T) {
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+	proc := NewProcessor(flags.New(), environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+
+	serviceConfig := &ConfigurationMockStruct{}
+	providerClientMock := &mocks.Client{}
+	providerClientMock.On("GetConfiguration", serviceConfig).Return(&ConfigurationMockStruct{}, nil)
+
+	require.NoError(t, proc.loadConfigFromProvider(serviceConfig, providerClientMock))
+	require.NoError(t, proc.loadConfigFromProvider(serviceConfig, providerClientMock))
+	assert.Equal(t, int64(2), proc.metrics.providerRoundTrip.Count())
+}
@@ -0,0 +1,71 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// GetConfigOverlayFileLocation returns the location of the overlay file merged over the configuration file for the
+// environment selected by the EDGEX_ENV environment variable, i.e. "res/configuration.prod.yaml" for the "prod"
+// environment. The overlay file is in the same directory as the configuration file. Empty when no environment is
+// selected.
+func GetConfigOverlayFileLocation(lc logger.LoggingClient, flags flags.Common, serviceType string) string {
+	environmentName := environment.GetEnvironmentName(lc)
+	if len(environmentName) == 0 {
+		return ""
+	}
+
+	return overlayFileLocation(GetConfigFileLocationForServiceType(lc, flags, serviceType), environmentName)
+}
+
+// overlayFileLocation returns the location of the environment's overlay file for the configuration file, which is the
+// configuration file's name with the environment inserted before the extension
+func overlayFileLocation(configFile string, environmentName string) string {
+	extension := filepath.Ext(configFile)
+	return strings.TrimSuffix(configFile, extension) + "." + environmentName + extension
+}
+
+// mergeConfigOverlay merges the overlay file for the selected environment, if any, over the configuration map. The
+// overlay file must exist when an environment is selected, so a misspelled environment isn't silently ignored.
+func (cp *Processor) mergeConfigOverlay(lc logger.LoggingClient, serviceType string, configMap map[string]any) error {
+	environmentName := environment.GetEnvironmentName(lc)
+	if len(environmentName) == 0 {
+		return nil
+	}
+
+	overlayFile := overlayFileLocation(GetConfigFileLocationForServiceType(lc, cp.flags, serviceType), environmentName)
+	if _, err := os.Stat(overlayFile); err != nil {
+		return fmt.Errorf("configuration overlay file %s for environment '%s' not found: %s", overlayFile, environmentName, err.Error())
+	}
+
+	overlayMap, err := cp.loadConfigYamlFromFile(lc, overlayFile)
+	if err != nil {
+		return err
+	}
+
+	utils.MergeMaps(configMap, overlayMap)
+	lc.Infof("Configuration overlay %s merged over the configuration file", overlayFile)
+	return nil
+}
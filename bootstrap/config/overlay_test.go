@@ -0,0 +1,102 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestLoadPrivateConfigFileOverlay(t *testing.T) {
+	configDir := t.TempDir()
+	files := map[string]string{
+		"configuration.yaml":              "Service:\n  Host: localhost\n  Port: 59880\nWritable:\n  LogLevel: INFO\n",
+		"configuration.prod.yaml":         "Service:\n  Host: prod-host\nWritable:\n  LogLevel: WARN\n",
+		"docker/configuration.yaml":       "Service:\n  Host: docker-host\n  Port: 59881\n",
+		"docker/configuration.prod.yaml":  "Service:\n  Port: 59882\n",
+		"configuration.staging.yaml.save": "Service:\n  Host: staging-host\n",
+	}
+	for name, contents := range files {
+		path := filepath.Join(configDir, name)
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0644))
+	}
+
+	tests := []struct {
+		Name             string
+		Environment      string
+		Profile          string
+		ExpectedService  map[string]any
+		ExpectedLogLevel any
+		ExpectedError    string
+	}{
+		{"Valid - base only", "", "", map[string]any{"Host": "localhost", "Port": 59880}, "INFO", ""},
+		{"Valid - overlay", "prod", "", map[string]any{"Host": "prod-host", "Port": 59880}, "WARN", ""},
+		{"Valid - overlay in profile", "prod", "docker", map[string]any{"Host": "docker-host", "Port": 59882}, nil, ""},
+		{"Invalid - missing overlay", "staging", "", nil, nil,
+			"configuration overlay file " + filepath.Join(configDir, "configuration.staging.yaml") + " for environment 'staging' not found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("EDGEX_ENV", tc.Environment)
+			t.Setenv("EDGEX_PROFILE", tc.Profile)
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir})
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+			})
+
+			proc := NewProcessorForCustomConfig(f, context.Background(), &sync.WaitGroup{}, dic)
+			actual, err := proc.loadPrivateConfigFile(proc.lc, config.ServiceTypeOther)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedService, actual["Service"])
+			if tc.ExpectedLogLevel != nil {
+				assert.Equal(t, map[string]any{"LogLevel": tc.ExpectedLogLevel}, actual["Writable"])
+			}
+		})
+	}
+}
+
+func TestGetConfigOverlayFileLocation(t *testing.T) {
+	f := flags.New()
+	f.Parse([]string{"-cd", "res", "-cf", "config.yaml"})
+	lc := logger.NewMockClient()
+
+	t.Setenv("EDGEX_ENV", "")
+	assert.Empty(t, GetConfigOverlayFileLocation(lc, f, config.ServiceTypeOther))
+
+	t.Setenv("EDGEX_ENV", "staging")
+	assert.Equal(t, filepath.Join("res", "config.staging.yaml"), GetConfigOverlayFileLocation(lc, f, config.ServiceTypeOther))
+}
@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// SetPersistOnShutdown sets the dotted paths of the settings, i.e. "Service.Port", whose values in the service's live
+// configuration are written back to the Configuration Provider by Shutdown, so settings derived at runtime survive a
+// restart. Each setting is written with a single key put, so only the listed settings are written and operator edits
+// to the rest of the configuration are not clobbered. Only scalar settings are supported. By default nothing is
+// written on shutdown.
+func (cp *Processor) SetPersistOnShutdown(paths ...string) {
+	cp.persistedPaths = paths
+}
+
+// persistSettings writes the values of the settings to persist from the service's configuration to the Configuration
+// Provider. All the settings are attempted and the errors for those that failed are returned.
+func (cp *Processor) persistSettings() error {
+	if len(cp.persistedPaths) == 0 || cp.serviceConfig == nil {
+		return nil
+	}
+
+	if container.ConfigClientFrom(cp.dic.Get) == nil {
+		cp.lc.Warnf("Unable to persist %d configuration setting(s) on shutdown: Configuration Provider not available", len(cp.persistedPaths))
+		return nil
+	}
+
+	cp.writableMutex.Lock()
+	var configMap map[string]any
+	err := utils.ConvertToMap(cp.serviceConfig, &configMap)
+	cp.writableMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("unable to persist configuration settings on shutdown: %w", err)
+	}
+
+	var errs []error
+	for _, path := range cp.persistedPaths {
+		value, found := lookupConfigMapSetting(configMap, strings.Split(path, "."))
+		if !found {
+			errs = append(errs, fmt.Errorf("unable to persist configuration setting %s on shutdown: setting not found", path))
+			continue
+		}
+
+		if err := cp.PutConfigurationValue(strings.ReplaceAll(path, ".", utils.PathSep), value); err != nil {
+			errs = append(errs, fmt.Errorf("unable to persist configuration setting %s on shutdown: %w", path, err))
+			continue
+		}
+
+		cp.lc.Infof("Configuration setting %s persisted to the Configuration Provider on shutdown", path)
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,95 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestShutdownPersistsSettings(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Paths          []string
+		ExpectedPuts   map[string]string
+		ExpectedErrors []string
+	}{
+		{"Nothing persisted by default", nil, map[string]string{}, nil},
+		{"Listed settings", []string{"Registry.Port", "Writable.LogLevel"},
+			map[string]string{"Registry/Port": "8501", "Writable/LogLevel": "DEBUG"}, nil},
+		{"Missing and unsupported settings", []string{"Registry.Port", "Registry.Missing", "Writable.StoreAndForward"},
+			map[string]string{"Registry/Port": "8501"},
+			[]string{"Registry.Missing on shutdown: setting not found", "Writable.StoreAndForward on shutdown"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			stored := make(map[string]string)
+			providerClientMock := &mocks.Client{}
+			providerClientMock.On("PutConfigurationValue", mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) {
+					stored[args.String(0)] = string(args.Get(1).([]byte))
+				}).Return(nil)
+			providerClientMock.On("GetConfigurationValue", mock.Anything).
+				Return(func(path string) []byte { return []byte(stored[path]) }, nil)
+
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+				container.ConfigClientInterfaceName:  func(get di.Get) interface{} { return providerClientMock },
+			})
+
+			f := flags.New()
+			f.Parse(nil)
+			proc := NewProcessor(f, nil, startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.serviceConfig = &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: "DEBUG", StoreAndForward: StoreAndForwardInfo{Enabled: true}},
+				Registry: config.RegistryInfo{Host: "localhost", Port: 8501},
+			}
+			proc.SetPersistOnShutdown(tc.Paths...)
+
+			ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+			defer cancel()
+			err := proc.Shutdown(ctx)
+
+			// Only the listed settings are written, so operator edits to other settings are not clobbered
+			assert.Equal(t, tc.ExpectedPuts, stored)
+			providerClientMock.AssertNumberOfCalls(t, "PutConfigurationValue", len(tc.ExpectedPuts))
+
+			if len(tc.ExpectedErrors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range tc.ExpectedErrors {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,170 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"gopkg.in/yaml.v3"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// profileMarkerFileName is the optional file in a profile directory which declares the profile's parent profile
+const profileMarkerFileName = "profile.yaml"
+
+// ProfileInfo is the content of a profile's optional profile.yaml marker file
+type ProfileInfo struct {
+	// Parent is the name of the profile whose configuration files are loaded before this profile's files and
+	// then overlaid by them.
+	Parent string `yaml:"Parent"`
+}
+
+// getProfileChain returns the profile followed by all its ancestors declared via the profile.yaml marker files.
+// An error is returned if a cycle is detected.
+func getProfileChain(configDir string, profile string) ([]string, error) {
+	var chain []string
+	visited := make(map[string]bool)
+
+	for current := profile; len(current) > 0; {
+		if visited[current] {
+			return nil, fmt.Errorf("profile inheritance cycle detected: %s -> %s", strings.Join(chain, " -> "), current)
+		}
+
+		visited[current] = true
+		chain = append(chain, current)
+
+		info, err := loadProfileInfo(filepath.Join(configDir, current, profileMarkerFileName))
+		if err != nil {
+			return nil, err
+		}
+
+		current = info.Parent
+	}
+
+	return chain, nil
+}
+
+// validateProfileDir verifies the selected profile has a directory in the configuration directory, failing with the
+// list of the available profiles when it doesn't
+func validateProfileDir(configDir string, profile string) error {
+	info, err := os.Stat(filepath.Join(configDir, profile))
+	if err == nil && info.IsDir() {
+		return nil
+	}
+
+	available := "none"
+	if profiles := availableProfiles(configDir); len(profiles) > 0 {
+		available = strings.Join(profiles, ", ")
+	}
+
+	return fmt.Errorf("profile '%s' not found in configuration directory %s. Available profiles: %s", profile, configDir, available)
+}
+
+// availableProfiles returns the sorted names of the profile directories in the configuration directory
+func availableProfiles(configDir string) []string {
+	entries, err := os.ReadDir(configDir)
+	if err != nil {
+		return nil
+	}
+
+	var profiles []string
+	for _, entry := range entries {
+		if entry.IsDir() {
+			profiles = append(profiles, entry.Name())
+		}
+	}
+
+	return profiles
+}
+
+// loadProfileInfo loads the profile marker file. An empty ProfileInfo is returned if the file doesn't exist.
+func loadProfileInfo(markerFile string) (ProfileInfo, error) {
+	info := ProfileInfo{}
+
+	contents, err := os.ReadFile(markerFile)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return info, nil
+		}
+		return info, fmt.Errorf("failed to read profile file %s: %s", markerFile, err.Error())
+	}
+
+	if err := yaml.Unmarshal(contents, &info); err != nil {
+		return info, fmt.Errorf("failed to unmarshall profile file %s: %s", markerFile, err.Error())
+	}
+
+	return info, nil
+}
+
+// loadPrivateConfigFile loads the service's private configuration file. When the profile declares a parent profile,
+// the parent's file is loaded first and overlaid with the profile's file, recursively. The overlay file for the
+// environment selected by EDGEX_ENV, if any, is then merged over the result.
+func (cp *Processor) loadPrivateConfigFile(lc logger.LoggingClient, serviceType string) (map[string]any, error) {
+	configMap, err := cp.loadProfileConfigFiles(lc, serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cp.mergeConfigOverlay(lc, serviceType, configMap); err != nil {
+		return nil, err
+	}
+
+	return configMap, nil
+}
+
+// loadProfileConfigFiles loads the service's configuration file from the selected profile, overlaid over the files of
+// its parent profiles, if any
+func (cp *Processor) loadProfileConfigFiles(lc logger.LoggingClient, serviceType string) (map[string]any, error) {
+	profile := strings.TrimSuffix(environment.GetProfileDir(lc, cp.flags.Profile()), "/")
+	if len(profile) == 0 {
+		return cp.loadConfigYamlFromFile(lc, GetConfigFileLocationForServiceType(lc, cp.flags, serviceType))
+	}
+
+	configDir := environment.GetConfigDir(lc, cp.flags.ConfigDirectory())
+	configFileName := getConfigFileName(lc, cp.flags, serviceType)
+
+	if err := validateProfileDir(configDir, profile); err != nil {
+		return nil, err
+	}
+
+	chain, err := getProfileChain(configDir, profile)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(chain) > 1 {
+		lc.Infof("Profile '%s' inherits from profile(s): %s", profile, strings.Join(chain[1:], ", "))
+	}
+
+	configMap := make(map[string]any)
+	// Apply the base profile first, so each child profile overlays its parent
+	for index := len(chain) - 1; index >= 0; index-- {
+		profileMap, err := cp.loadConfigYamlFromFile(lc, filepath.Join(configDir, chain[index], configFileName))
+		if err != nil {
+			return nil, err
+		}
+
+		utils.MergeMaps(configMap, profileMap)
+	}
+
+	return configMap, nil
+}
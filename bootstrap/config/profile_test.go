@@ -0,0 +1,227 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestLoadPrivateConfigFileWithProfileInheritance(t *testing.T) {
+	// profile name -> parent, config file contents
+	profiles := map[string]struct {
+		Parent string
+		Config string
+	}{
+		"base":       {"", "Writable:\n  LogLevel: INFO\n  Telemetry:\n    Interval: 30s\nService:\n  Host: localhost\n  Port: 59880\n"},
+		"prod":       {"base", "Writable:\n  LogLevel: WARN\nService:\n  Host: prod-host\n"},
+		"prod-eu":    {"prod", "Service:\n  Host: prod-eu-host\nRegion: eu\n"},
+		"standalone": {"", "Writable:\n  LogLevel: DEBUG\n"},
+		"cycle-a":    {"cycle-b", "Writable:\n  LogLevel: DEBUG\n"},
+		"cycle-b":    {"cycle-a", "Writable:\n  LogLevel: DEBUG\n"},
+		"self":       {"self", "Writable:\n  LogLevel: DEBUG\n"},
+		"orphan":     {"missing", "Writable:\n  LogLevel: DEBUG\n"},
+	}
+
+	configDir := t.TempDir()
+	for name, profile := range profiles {
+		profileDir := filepath.Join(configDir, name)
+		require.NoError(t, os.MkdirAll(profileDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(profileDir, "configuration.yaml"), []byte(profile.Config), 0644))
+		if len(profile.Parent) > 0 {
+			marker := []byte("Parent: " + profile.Parent + "\n")
+			require.NoError(t, os.WriteFile(filepath.Join(profileDir, profileMarkerFileName), marker, 0644))
+		}
+	}
+
+	tests := []struct {
+		Name          string
+		Profile       string
+		Expected      map[string]any
+		ExpectedError string
+	}{
+		{
+			Name:    "Valid - no inheritance",
+			Profile: "standalone",
+			Expected: map[string]any{
+				"Writable": map[string]any{"LogLevel": "DEBUG"},
+			},
+		},
+		{
+			Name:    "Valid - single level",
+			Profile: "prod",
+			Expected: map[string]any{
+				"Writable": map[string]any{"LogLevel": "WARN", "Telemetry": map[string]any{"Interval": "30s"}},
+				"Service":  map[string]any{"Host": "prod-host", "Port": 59880},
+			},
+		},
+		{
+			Name:    "Valid - multi level",
+			Profile: "prod-eu",
+			Expected: map[string]any{
+				"Writable": map[string]any{"LogLevel": "WARN", "Telemetry": map[string]any{"Interval": "30s"}},
+				"Service":  map[string]any{"Host": "prod-eu-host", "Port": 59880},
+				"Region":   "eu",
+			},
+		},
+		{Name: "Invalid - cycle", Profile: "cycle-a", ExpectedError: "profile inheritance cycle detected: cycle-a -> cycle-b -> cycle-a"},
+		{Name: "Invalid - self cycle", Profile: "self", ExpectedError: "profile inheritance cycle detected: self -> self"},
+		{Name: "Invalid - missing parent", Profile: "orphan", ExpectedError: "failed to read configuration file"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir, "-p", tc.Profile})
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+			})
+
+			proc := NewProcessorForCustomConfig(f, context.Background(), &sync.WaitGroup{}, dic)
+			actual, err := proc.loadPrivateConfigFile(proc.lc, config.ServiceTypeOther)
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestLoadPrivateConfigFileWithAnchors(t *testing.T) {
+	base := `
+x-client: &client
+  Host: localhost
+  Protocol: http
+Clients:
+  core-metadata:
+    <<: *client
+    Port: 59881
+  core-command:
+    <<: *client
+    Port: 59882
+  core-data: *client
+`
+	child := `
+Clients:
+  core-metadata:
+    Host: edgex-core-metadata
+`
+
+	configDir := t.TempDir()
+	for name, contents := range map[string]string{"base": base, "child": child} {
+		profileDir := filepath.Join(configDir, name)
+		require.NoError(t, os.MkdirAll(profileDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(profileDir, "configuration.yaml"), []byte(contents), 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "child", profileMarkerFileName), []byte("Parent: base\n"), 0644))
+
+	f := flags.New()
+	f.Parse([]string{"-cd", configDir, "-p", "child"})
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+	})
+
+	proc := NewProcessorForCustomConfig(f, context.Background(), &sync.WaitGroup{}, dic)
+	actual, err := proc.loadPrivateConfigFile(proc.lc, config.ServiceTypeOther)
+	require.NoError(t, err)
+
+	expectedClients := map[string]any{
+		"core-metadata": map[string]any{"Host": "edgex-core-metadata", "Protocol": "http", "Port": 59881},
+		"core-command":  map[string]any{"Host": "localhost", "Protocol": "http", "Port": 59882},
+		"core-data":     map[string]any{"Host": "localhost", "Protocol": "http"},
+	}
+	assert.Equal(t, expectedClients, actual["Clients"])
+	assert.Equal(t, map[string]any{"Host": "localhost", "Protocol": "http"}, actual["x-client"])
+}
+
+func TestLoadPrivateConfigFileProfileSelection(t *testing.T) {
+	configDir := t.TempDir()
+	for _, name := range []string{"docker", "helm"} {
+		profileDir := filepath.Join(configDir, name)
+		require.NoError(t, os.MkdirAll(profileDir, 0755))
+		contents := []byte("Service:\n  Host: " + name + "-host\n")
+		require.NoError(t, os.WriteFile(filepath.Join(profileDir, "configuration.yaml"), contents, 0644))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte("Service:\n  Host: localhost\n"), 0644))
+
+	tests := []struct {
+		Name          string
+		FlagProfile   string
+		EnvProfile    string
+		ExpectedHost  string
+		ExpectedError string
+	}{
+		{"Valid - flag", "docker", "", "docker-host", ""},
+		{"Valid - env", "", "helm", "helm-host", ""},
+		{"Valid - env takes precedence over flag", "docker", "helm", "helm-host", ""},
+		{"Valid - no profile", "", "", "localhost", ""},
+		{"Invalid - missing profile from flag", "k8s", "", "",
+			"profile 'k8s' not found in configuration directory " + configDir + ". Available profiles: docker, helm"},
+		{"Invalid - missing profile from env", "docker", "k8s", "", "profile 'k8s' not found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv("EDGEX_PROFILE", tc.EnvProfile)
+
+			args := []string{"-cd", configDir}
+			if len(tc.FlagProfile) > 0 {
+				args = append(args, "-p", tc.FlagProfile)
+			}
+			f := flags.New()
+			f.Parse(args)
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+			})
+
+			proc := NewProcessorForCustomConfig(f, context.Background(), &sync.WaitGroup{}, dic)
+			actual, err := proc.loadPrivateConfigFile(proc.lc, config.ServiceTypeOther)
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, map[string]any{"Host": tc.ExpectedHost}, actual["Service"])
+		})
+	}
+}
+
+func TestValidateProfileDirNoProfiles(t *testing.T) {
+	configDir := t.TempDir()
+
+	err := validateProfileDir(configDir, "docker")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Available profiles: none")
+}
@@ -0,0 +1,138 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+// The labels of the sources of the settings reported by Provenance
+const (
+	ProvenanceCommon          = "common"
+	ProvenanceCommonApp       = "common-app"
+	ProvenanceCommonDevice    = "common-device"
+	ProvenancePrivateProvider = "private-provider"
+	ProvenanceFile            = "file"
+	ProvenanceMemory          = "memory"
+	ProvenanceEnvOverride     = "env-override"
+)
+
+// SetTrackProvenance sets whether Process records the source of each setting, as reported by Provenance. Disabled by
+// default, since it requires extra processing of each source and, for the common configuration in the Configuration
+// Provider, an extra request for its keys.
+func (cp *Processor) SetTrackProvenance(enabled bool) {
+	cp.trackProvenance = enabled
+}
+
+// Provenance returns the source of each setting loaded by Process, keyed by the dotted path of the setting, i.e.
+// "Writable.LogLevel", the same as the paths reported by ExportSchema. The source is one of the Provenance labels, or
+// the name of the custom source added by AddConfigSource, and is the last source which set the setting, as the later
+// sources override the earlier ones. Settings not set by any source, which keep the service's defaults, are absent.
+// nil is returned when SetTrackProvenance is not enabled.
+func (cp *Processor) Provenance() map[string]string {
+	if cp.provenance == nil {
+		return nil
+	}
+
+	provenance := make(map[string]string, len(cp.provenance))
+	for path, source := range cp.provenance {
+		provenance[path] = source
+	}
+
+	return provenance
+}
+
+// recordProvenance records the source of the settings in the configuration map, when tracking is enabled
+func (cp *Processor) recordProvenance(source string, configMap map[string]any) {
+	if !cp.trackProvenance {
+		return
+	}
+
+	recordMapProvenance(cp.provenance, source, configMap, "")
+}
+
+// recordOverrideProvenance records the settings at the paths, using "/" as the separator, as overridden by environment
+// variables, when tracking is enabled
+func (cp *Processor) recordOverrideProvenance(paths []string) {
+	if !cp.trackProvenance {
+		return
+	}
+
+	for _, path := range paths {
+		cp.provenance[strings.ReplaceAll(path, schemaPathSeparator, flatKeySeparator)] = ProvenanceEnvOverride
+	}
+}
+
+// recordConfigMapOverrideProvenance records the settings in the configuration map overridden by environment variables,
+// when tracking is enabled
+func (cp *Processor) recordConfigMapOverrideProvenance(configMap map[string]any) {
+	if !cp.trackProvenance {
+		return
+	}
+
+	cp.recordOverrideProvenance(cp.envVars.OverriddenMapPaths(configMap))
+}
+
+// recordProviderProvenance records the source of the settings of the configuration loaded from the Configuration
+// Provider at the base key, which are only those actually present in the Configuration Provider
+func (cp *Processor) recordProviderProvenance(source string, serviceConfig interfaces.Configuration, client configuration.Client, baseKey string) error {
+	keys, err := client.GetConfigurationKeys("")
+	if err != nil {
+		return newProcessError(ErrProviderUnavailable, "failed to load the configuration keys for %s: %w", source, err)
+	}
+
+	configMap, err := utils.RemoveUnusedSettings(serviceConfig, baseKey, utils.StringSliceToMap(keys))
+	if err != nil {
+		return newProcessError(ErrMergeFailed, "failed to remove unused settings from %s configuration: %w", source, err)
+	}
+
+	cp.recordProvenance(source, configMap)
+	return nil
+}
+
+// commonProvenanceLabel returns the label of the common configuration section for the type of service
+func commonProvenanceLabel(serviceType string) string {
+	switch serviceType {
+	case config.ServiceTypeApp:
+		return ProvenanceCommonApp
+	case config.ServiceTypeDevice:
+		return ProvenanceCommonDevice
+	default:
+		return ProvenanceCommon
+	}
+}
+
+// recordMapProvenance sets the source of each setting in the configuration map at the path in the provenance
+func recordMapProvenance(provenance map[string]string, source string, configMap map[string]any, path string) {
+	for key, value := range configMap {
+		settingPath := key
+		if len(path) > 0 {
+			settingPath = path + flatKeySeparator + key
+		}
+
+		if section, ok := value.(map[string]any); ok {
+			recordMapProvenance(provenance, source, section, settingPath)
+			continue
+		}
+
+		provenance[settingPath] = source
+	}
+}
@@ -0,0 +1,86 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestProcessProvenance(t *testing.T) {
+	configDir := t.TempDir()
+	commonContents := "all-services:\n  Writable:\n    LogLevel: INFO\n  Registry:\n    Host: common-host\n    Port: 8500\n" +
+		"app-services:\n  Registry:\n    Port: 8600\n"
+	commonFile := filepath.Join(configDir, "common.yaml")
+	require.NoError(t, os.WriteFile(commonFile, []byte(commonContents), 0644))
+	privateContents := "Registry:\n  Host: file-host\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "app-configuration.yaml"), []byte(privateContents), 0644))
+
+	tests := []struct {
+		Name     string
+		Enabled  bool
+		Expected map[string]string
+	}{
+		{"Disabled", false, nil},
+		{"Enabled", true, map[string]string{
+			"Writable.LogLevel":                ProvenanceCommon,
+			"Registry.Port":                    ProvenanceCommonApp,
+			"Registry.Host":                    ProvenanceFile,
+			"Registry.Type":                    ProvenanceEnvOverride,
+			"Writable.StoreAndForward.Enabled": "configmap",
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests in this package may leave the provider override set, so ensure the file is used
+			t.Setenv(envKeyConfigUrl, "")
+			t.Setenv("REGISTRY_TYPE", "keeper")
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir, "-cc", commonFile})
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.SetTrackProvenance(tc.Enabled)
+			proc.AddFlatConfigSource("configmap", map[string]string{"Writable.StoreAndForward.Enabled": "true"}, 10)
+
+			serviceConfig := &ConfigurationMockStruct{}
+			require.NoError(t, proc.Process("unit-test", config.ServiceTypeApp, "edgex/v3", serviceConfig, nil))
+
+			assert.Equal(t, tc.Expected, proc.Provenance())
+			assert.Equal(t, "file-host", serviceConfig.Registry.Host)
+			assert.Equal(t, 8600, serviceConfig.Registry.Port)
+			assert.Equal(t, "keeper", serviceConfig.Registry.Type)
+		})
+	}
+}
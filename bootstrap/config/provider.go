@@ -31,6 +31,10 @@ func NewProviderInfo(envVars *environment.Variables, providerUrl string) (*Provi
 
 	// initialize config provider configuration for URL set in commandline options
 	if providerUrl != "" {
+		if err = environment.ValidateProviderUrl(providerUrl); err != nil {
+			return nil, err
+		}
+
 		if err = configProviderInfo.serviceConfig.PopulateFromUrl(providerUrl); err != nil {
 			return nil, err
 		}
@@ -87,3 +87,52 @@ func TestNewConfigProviderInfoBadEnvUrl(t *testing.T) {
 	_, err = NewProviderInfo(envVars, goodUrlValue)
 	assert.Error(t, err)
 }
+
+func TestNewProviderInfoValidation(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Url           string
+		ExpectedError string
+	}{
+		{"Valid", goodUrlValue, ""},
+		{"Valid default protocol", "consul://localhost:8500", ""},
+		{"Valid IP host", "keeper.https://127.0.0.1:59890", ""},
+		{"Missing scheme", "localhost:8500", "missing the scheme"},
+		{"Missing scheme and host", "//localhost:8500", "missing the scheme"},
+		{"Not a url", badUrlValue, "missing the scheme"},
+		{"Too many scheme parts", "consul.http.v2://localhost:8500", "scheme 'consul.http.v2'"},
+		{"Missing protocol after dot", "consul.://localhost:8500", "scheme 'consul.'"},
+		{"Missing host", "consul.http://:8500", "missing the host"},
+		{"Missing port", "consul.http://localhost", "missing the port"},
+		{"Non-numeric port", "consul.http://localhost:port", "invalid port"},
+		{"Port out of range", "consul.http://localhost:70000", "port '70000' must be a number from 1 to 65535"},
+		{"Zero port", "consul.http://localhost:0", "port '0' must be a number from 1 to 65535"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(envKeyConfigUrl, "")
+			envVars := environment.NewVariables(logger.NewMockClient())
+
+			_, err := NewProviderInfo(envVars, tc.Url)
+			if len(tc.ExpectedError) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ExpectedError)
+			assert.Contains(t, err.Error(), tc.Url)
+		})
+	}
+}
+
+func TestNewProviderInfoValidationEnv(t *testing.T) {
+	t.Setenv(envKeyConfigUrl, "consul.http://localhost:99999")
+	envVars := environment.NewVariables(logger.NewMockClient())
+
+	_, err := NewProviderInfo(envVars, goodUrlValue)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), envKeyConfigUrl)
+	assert.Contains(t, err.Error(), "port '99999'")
+}
@@ -0,0 +1,118 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// PruneProviderKeys finds the keys of the service's private configuration in the Configuration Provider which don't
+// correspond to a setting of serviceConfig, i.e. those of settings removed from the service's configuration since it
+// was pushed, which would otherwise linger in the Configuration Provider. The keys are only deleted when confirmed by
+// the -pruneProviderKeys flag, otherwise they are logged for review. The keys found, or deleted when confirmed, are
+// returned relative to the service's base key, i.e. "Writable/OldSetting". serviceConfig must be the configuration
+// loaded by Process, so the keys of its maps, i.e. Clients, are known, and the Configuration Provider client must
+// implement ConfigValueDeleter for the keys to be deleted.
+func (cp *Processor) PruneProviderKeys(serviceConfig interfaces.Configuration) ([]string, error) {
+	lc := cp.operationLogger("PruneProviderKeys")
+
+	configClient := container.ConfigClientFrom(cp.dic.Get)
+	if configClient == nil {
+		return nil, newProcessError(ErrProviderUnavailable,
+			"unable to prune Configuration Provider keys: no Configuration Provider is configured")
+	}
+	if len(cp.baseKey) == 0 {
+		return nil, errors.New("unable to prune Configuration Provider keys: the configuration has not been processed")
+	}
+
+	staleKeys, err := cp.findStaleProviderKeys(serviceConfig, configClient)
+	if err != nil {
+		return nil, err
+	}
+	if len(staleKeys) == 0 {
+		lc.Debug("No stale keys found in the Configuration Provider")
+		return nil, nil
+	}
+
+	if !cp.pruneProviderKeysConfirmed() {
+		lc.Warnf("Found %d keys in the Configuration Provider which the service's configuration doesn't have, "+
+			"use -pruneProviderKeys to delete them: %s", len(staleKeys), strings.Join(staleKeys, ", "))
+		return staleKeys, nil
+	}
+
+	deleter, ok := configClient.(ConfigValueDeleter)
+	if !ok {
+		return nil, errors.New("unable to prune Configuration Provider keys: the Configuration Provider client doesn't support deleting keys")
+	}
+
+	var removed []string
+	for _, key := range staleKeys {
+		if err := deleter.DeleteConfigurationValue(key); err != nil {
+			return removed, newProcessError(ErrProviderUnavailable,
+				"unable to delete stale key '%s' from Configuration Provider: %w", key, err)
+		}
+		removed = append(removed, key)
+		delete(cp.unknownSettings, key)
+		lc.Infof("Deleted stale key '%s' from the Configuration Provider", key)
+	}
+
+	return removed, nil
+}
+
+// findStaleProviderKeys returns the sorted keys under the service's base key, relative to it, which don't correspond to
+// a setting of serviceConfig
+func (cp *Processor) findStaleProviderKeys(serviceConfig interfaces.Configuration, configClient configuration.Client) ([]string, error) {
+	keys, err := configClient.GetConfigurationKeys("")
+	if err != nil {
+		return nil, newProcessError(ErrProviderUnavailable, "unable to get keys from Configuration Provider: %w", err)
+	}
+
+	// Only the keys within the service's configuration are considered, not the base key itself
+	prefix := cp.baseKey + utils.PathSep
+	var serviceKeys []string
+	for _, key := range keys {
+		if strings.HasPrefix(key, prefix) && len(strings.TrimSuffix(key, utils.PathSep)) > len(prefix) {
+			serviceKeys = append(serviceKeys, key)
+		}
+	}
+
+	unknownKeys, err := utils.FindUnknownSettings(serviceConfig, cp.baseKey, serviceKeys)
+	if err != nil {
+		return nil, fmt.Errorf("unable to find stale keys: %w", err)
+	}
+
+	staleKeys := make([]string, 0, len(unknownKeys))
+	for _, key := range unknownKeys {
+		staleKeys = append(staleKeys, strings.TrimPrefix(key, prefix))
+	}
+	sort.Strings(staleKeys)
+	return staleKeys, nil
+}
+
+// pruneProviderKeysConfirmed returns whether deleting the stale keys was confirmed by the -pruneProviderKeys flag
+func (cp *Processor) pruneProviderKeysConfirmed() bool {
+	option, ok := cp.flags.(flags.PruneProviderKeysOption)
+	return ok && option.PruneProviderKeys()
+}
@@ -0,0 +1,128 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// pruneClient is a Configuration Provider client which records the keys deleted
+type pruneClient struct {
+	*mocks.Client
+	deleted []string
+}
+
+func (c *pruneClient) DeleteConfigurationValue(name string) error {
+	c.deleted = append(c.deleted, name)
+	return nil
+}
+
+func TestPruneProviderKeys(t *testing.T) {
+	baseKey := "edgex/v3/unit-test"
+	providerKeys := []string{
+		baseKey + "/",
+		baseKey + "/Writable/LogLevel",
+		baseKey + "/Writable/OldSetting",
+		baseKey + "/Writable/InsecureSecrets/DB/SecretName",
+		baseKey + "/Writable/Telemetry/Metrics/EventsSent",
+		baseKey + "/Legacy/Host",
+		baseKey + "/Trigger/Type",
+	}
+	staleKeys := []string{"Legacy/Host", "Writable/OldSetting"}
+
+	serviceConfig := &ConfigurationMockStruct{
+		Writable: WritableInfo{
+			LogLevel:        "INFO",
+			InsecureSecrets: config.InsecureSecrets{"DB": config.InsecureSecretsInfo{SecretName: "redisdb"}},
+			Telemetry:       config.TelemetryInfo{Metrics: map[string]bool{"EventsSent": true}},
+		},
+		Trigger: TriggerInfo{Type: "edgex-messagebus"},
+	}
+
+	tests := []struct {
+		Name            string
+		Args            []string
+		CanDelete       bool
+		ExpectedKeys    []string
+		ExpectedDeleted []string
+		ExpectError     bool
+	}{
+		{"Valid - stale keys only listed when not confirmed", nil, true, staleKeys, nil, false},
+		{"Valid - stale keys removed when confirmed", []string{"--pruneProviderKeys"}, true, staleKeys, staleKeys, false},
+		{"Invalid - client doesn't support deleting keys", []string{"--pruneProviderKeys"}, false, nil, nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockClient := &mocks.Client{}
+			mockClient.On("GetConfigurationKeys", "").Return(providerKeys, nil)
+			client := &pruneClient{Client: mockClient}
+			var configClient configuration.Client = client
+			if !tc.CanDelete {
+				configClient = mockClient
+			}
+
+			f := flags.New()
+			f.Parse(tc.Args)
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+				container.ConfigClientInterfaceName:  func(get di.Get) interface{} { return configClient },
+			})
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.baseKey = baseKey
+
+			actual, err := proc.PruneProviderKeys(serviceConfig)
+			if tc.ExpectError {
+				require.Error(t, err)
+				assert.Empty(t, client.deleted)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedKeys, actual)
+			assert.Equal(t, tc.ExpectedDeleted, client.deleted)
+		})
+	}
+}
+
+func TestPruneProviderKeysNoProvider(t *testing.T) {
+	f := flags.New()
+	f.Parse([]string{"--pruneProviderKeys"})
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+	proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+
+	_, err := proc.PruneProviderKeys(&ConfigurationMockStruct{})
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrProviderUnavailable)
+}
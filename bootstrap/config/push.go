@@ -0,0 +1,192 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+// TransactionalConfigClient is optionally implemented by configuration.Client implementations which can push a
+// configuration map atomically, so either all or none of its keys are written.
+type TransactionalConfigClient interface {
+	PutConfigurationMapAtomically(configuration map[string]any, overwrite bool) error
+}
+
+// ConfigValueDeleter is optionally implemented by configuration.Client implementations which can delete a key, so the
+// keys created by a push which failed midway can be removed when rolling it back.
+type ConfigValueDeleter interface {
+	DeleteConfigurationValue(name string) error
+}
+
+// PushFailurePolicy determines what is done with the keys already written when pushing configuration into the
+// Configuration Provider fails midway, for clients which don't implement TransactionalConfigClient.
+type PushFailurePolicy int
+
+const (
+	// PushFailureKeep leaves the keys already written in the Configuration Provider. Pushing again is idempotent, since
+	// the keys already written hold the same values, so a retry completes the push.
+	PushFailureKeep PushFailurePolicy = iota
+	// PushFailureRollback restores the previous values of the keys already written and removes the keys created, when
+	// the client implements ConfigValueDeleter, so the Configuration Provider is left as it was before the push.
+	PushFailureRollback
+)
+
+// PartialPushError is the error returned when pushing configuration into the Configuration Provider fails after some
+// of the keys were written. The keys are relative to the client's base path, i.e. "Writable/LogLevel".
+type PartialPushError struct {
+	// Written are the keys written before the failure
+	Written []string
+	// NotWritten are the keys not written due to the failure
+	NotWritten []string
+	// RolledBack is whether the keys written were rolled back, leaving the Configuration Provider as it was
+	RolledBack bool
+	// Err is the error the push failed with
+	Err error
+}
+
+func (e *PartialPushError) Error() string {
+	if e.RolledBack {
+		return fmt.Sprintf("push failed with %d keys not written, the %d keys written were rolled back: %v",
+			len(e.NotWritten), len(e.Written), e.Err)
+	}
+	return fmt.Sprintf("push failed with %d keys written and %d keys not written: %v", len(e.Written), len(e.NotWritten), e.Err)
+}
+
+func (e *PartialPushError) Unwrap() error {
+	return e.Err
+}
+
+// SetPushFailurePolicy sets what is done with the keys already written when pushing configuration into the
+// Configuration Provider fails midway. Defaults to PushFailureKeep.
+func (cp *Processor) SetPushFailurePolicy(policy PushFailurePolicy) {
+	cp.pushFailurePolicy = policy
+}
+
+// pushConfigurationMap pushes the configuration map into the Configuration Provider. The map is pushed atomically when
+// the client supports it, otherwise one key at a time, so the keys written and not written can be logged and the push
+// handled according to the PushFailurePolicy when it fails midway.
+func (cp *Processor) pushConfigurationMap(lc logger.LoggingClient, client configuration.Client, configMap map[string]any, overwrite bool) error {
+	if transactional, ok := client.(TransactionalConfigClient); ok {
+		if err := transactional.PutConfigurationMapAtomically(configMap, overwrite); err != nil {
+			lc.Errorf("Atomic push of configuration into Configuration Provider failed, no keys were written: %v", err)
+			return err
+		}
+		return nil
+	}
+
+	values := make(map[string]string)
+	flattenConfigValue("", configMap, values)
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// The previous values are only kept when they may need to be rolled back
+	previousValues := make(map[string]previousValue)
+	var written []string
+	for index, key := range keys {
+		wrote, err := cp.pushConfigurationValue(client, key, values[key], overwrite, previousValues)
+		if err != nil {
+			pushErr := &PartialPushError{Written: written, NotWritten: keys[index:], Err: err}
+			lc.Errorf("Push of configuration into Configuration Provider failed at key '%s'. Keys written: [%s]. Keys not written: [%s]",
+				key, strings.Join(pushErr.Written, ", "), strings.Join(pushErr.NotWritten, ", "))
+
+			if cp.pushFailurePolicy == PushFailureRollback {
+				pushErr.RolledBack = cp.rollbackPush(lc, client, written, previousValues)
+			}
+			return pushErr
+		}
+
+		if wrote {
+			written = append(written, key)
+		}
+	}
+
+	return nil
+}
+
+// previousValue is the value of a key before it was written by a push, so it can be rolled back
+type previousValue struct {
+	existed bool
+	value   []byte
+}
+
+// pushConfigurationValue writes the value at the key, unless it already exists and overwrite is false, keeping its
+// previous value in previousValues when the push may need to be rolled back. Returns whether the value was written.
+func (cp *Processor) pushConfigurationValue(client configuration.Client, key string, value string, overwrite bool,
+	previousValues map[string]previousValue) (bool, error) {
+	exists, err := client.ConfigurationValueExists(key)
+	if err != nil {
+		return false, err
+	}
+	if exists && !overwrite {
+		return false, nil
+	}
+
+	if cp.pushFailurePolicy == PushFailureRollback {
+		previous := previousValue{existed: exists}
+		if exists {
+			if previous.value, err = client.GetConfigurationValue(key); err != nil {
+				return false, err
+			}
+		}
+		previousValues[key] = previous
+	}
+
+	if err := client.PutConfigurationValue(key, []byte(value)); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// rollbackPush restores the previous values of the keys written and removes those created, returning whether all of
+// them were rolled back
+func (cp *Processor) rollbackPush(lc logger.LoggingClient, client configuration.Client, written []string, previousValues map[string]previousValue) bool {
+	deleter, canDelete := client.(ConfigValueDeleter)
+
+	var failed []string
+	for _, key := range written {
+		previous := previousValues[key]
+		var err error
+		switch {
+		case previous.existed:
+			err = client.PutConfigurationValue(key, previous.value)
+		case canDelete:
+			err = deleter.DeleteConfigurationValue(key)
+		default:
+			err = errors.New("the Configuration Provider client doesn't support deleting keys")
+		}
+		if err != nil {
+			lc.Errorf("Unable to roll back key '%s' written to Configuration Provider: %v", key, err)
+			failed = append(failed, key)
+		}
+	}
+
+	if len(failed) > 0 {
+		lc.Errorf("Push of configuration into Configuration Provider partially rolled back. Keys not rolled back: [%s]",
+			strings.Join(failed, ", "))
+		return false
+	}
+
+	lc.Infof("Push of configuration into Configuration Provider rolled back, %d keys restored", len(written))
+	return true
+}
@@ -0,0 +1,193 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// pushStoreClient is a Configuration Provider client backed by a map, which fails to put the value of failKey
+type pushStoreClient struct {
+	*mocks.Client
+	store   map[string]string
+	failKey string
+}
+
+func newPushStoreClient(store map[string]string, failKey string) *pushStoreClient {
+	return &pushStoreClient{Client: &mocks.Client{}, store: store, failKey: failKey}
+}
+
+func (c *pushStoreClient) ConfigurationValueExists(name string) (bool, error) {
+	_, exists := c.store[name]
+	return exists, nil
+}
+
+func (c *pushStoreClient) GetConfigurationValue(name string) ([]byte, error) {
+	return []byte(c.store[name]), nil
+}
+
+func (c *pushStoreClient) PutConfigurationValue(name string, value []byte) error {
+	if name == c.failKey {
+		return errors.New("connection reset by peer")
+	}
+	c.store[name] = string(value)
+	return nil
+}
+
+// deletingPushStoreClient is a pushStoreClient which supports deleting keys
+type deletingPushStoreClient struct {
+	*pushStoreClient
+}
+
+func (c *deletingPushStoreClient) DeleteConfigurationValue(name string) error {
+	delete(c.store, name)
+	return nil
+}
+
+// atomicPushStoreClient is a pushStoreClient which supports pushing a configuration map atomically
+type atomicPushStoreClient struct {
+	*pushStoreClient
+	atomicErr error
+}
+
+func (c *atomicPushStoreClient) PutConfigurationMapAtomically(configMap map[string]any, overwrite bool) error {
+	if c.atomicErr != nil {
+		return c.atomicErr
+	}
+	flattenConfigValue("", configMap, c.store)
+	return nil
+}
+
+func TestPushConfigurationMap(t *testing.T) {
+	configMap := map[string]any{
+		"Service": map[string]any{"Host": "localhost"},
+		"Writable": map[string]any{
+			"LogLevel":        "DEBUG",
+			"StoreAndForward": map[string]any{"Enabled": true, "MaxRetryCount": 10},
+		},
+	}
+	pushed := map[string]string{
+		"Service/Host":                           "localhost",
+		"Writable/LogLevel":                      "DEBUG",
+		"Writable/StoreAndForward/Enabled":       "true",
+		"Writable/StoreAndForward/MaxRetryCount": "10",
+	}
+	failKey := "Writable/StoreAndForward/Enabled"
+	connectionErr := "connection reset by peer"
+
+	tests := []struct {
+		Name               string
+		Policy             PushFailurePolicy
+		Overwrite          bool
+		FailKey            string
+		CanDelete          bool
+		ExpectedStore      map[string]string
+		ExpectedWritten    []string
+		ExpectedNotWritten []string
+		ExpectedRolledBack bool
+	}{
+		{"Valid - all keys written", PushFailureKeep, true, "", false, pushed, nil, nil, false},
+		{"Valid - existing keys not overwritten", PushFailureKeep, false, "", false,
+			map[string]string{
+				"Service/Host":                           "localhost",
+				"Writable/LogLevel":                      "INFO",
+				"Writable/StoreAndForward/Enabled":       "true",
+				"Writable/StoreAndForward/MaxRetryCount": "10",
+			}, nil, nil, false},
+		{"Invalid - keep written keys", PushFailureKeep, true, failKey, false,
+			map[string]string{"Service/Host": "localhost", "Writable/LogLevel": "DEBUG"},
+			[]string{"Service/Host", "Writable/LogLevel"},
+			[]string{"Writable/StoreAndForward/Enabled", "Writable/StoreAndForward/MaxRetryCount"}, false},
+		{"Invalid - rollback written keys", PushFailureRollback, true, failKey, true,
+			map[string]string{"Writable/LogLevel": "INFO"},
+			[]string{"Service/Host", "Writable/LogLevel"},
+			[]string{"Writable/StoreAndForward/Enabled", "Writable/StoreAndForward/MaxRetryCount"}, true},
+		{"Invalid - rollback without delete support", PushFailureRollback, true, failKey, false,
+			map[string]string{"Service/Host": "localhost", "Writable/LogLevel": "INFO"},
+			[]string{"Service/Host", "Writable/LogLevel"},
+			[]string{"Writable/StoreAndForward/Enabled", "Writable/StoreAndForward/MaxRetryCount"}, false},
+		{"Invalid - existing keys not overwritten aren't rolled back", PushFailureRollback, false, failKey, true,
+			map[string]string{"Writable/LogLevel": "INFO"},
+			[]string{"Service/Host"},
+			[]string{"Writable/StoreAndForward/Enabled", "Writable/StoreAndForward/MaxRetryCount"}, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			storeClient := newPushStoreClient(map[string]string{"Writable/LogLevel": "INFO"}, tc.FailKey)
+			var client configuration.Client = storeClient
+			if tc.CanDelete {
+				client = &deletingPushStoreClient{pushStoreClient: storeClient}
+			}
+
+			proc := &Processor{}
+			proc.SetPushFailurePolicy(tc.Policy)
+			err := proc.pushConfigurationMap(logger.NewMockClient(), client, configMap, tc.Overwrite)
+			assert.Equal(t, tc.ExpectedStore, storeClient.store)
+
+			if len(tc.FailKey) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), connectionErr)
+			var pushErr *PartialPushError
+			require.True(t, errors.As(err, &pushErr))
+			assert.Equal(t, tc.ExpectedWritten, pushErr.Written)
+			assert.Equal(t, tc.ExpectedNotWritten, pushErr.NotWritten)
+			assert.Equal(t, tc.ExpectedRolledBack, pushErr.RolledBack)
+		})
+	}
+}
+
+func TestPushConfigurationMapRetry(t *testing.T) {
+	configMap := map[string]any{
+		"Writable": map[string]any{"LogLevel": "DEBUG", "StoreAndForward": map[string]any{"Enabled": true}},
+	}
+	client := newPushStoreClient(map[string]string{}, "Writable/StoreAndForward/Enabled")
+	proc := &Processor{}
+
+	require.Error(t, proc.pushConfigurationMap(logger.NewMockClient(), client, configMap, false))
+	assert.Equal(t, map[string]string{"Writable/LogLevel": "DEBUG"}, client.store)
+
+	// The keys written by the failed push are left as is, so pushing again completes it
+	client.failKey = ""
+	require.NoError(t, proc.pushConfigurationMap(logger.NewMockClient(), client, configMap, false))
+	assert.Equal(t, map[string]string{"Writable/LogLevel": "DEBUG", "Writable/StoreAndForward/Enabled": "true"}, client.store)
+}
+
+func TestPushConfigurationMapAtomically(t *testing.T) {
+	configMap := map[string]any{"Writable": map[string]any{"LogLevel": "DEBUG"}}
+
+	client := &atomicPushStoreClient{pushStoreClient: newPushStoreClient(map[string]string{}, "Writable/LogLevel")}
+	proc := &Processor{}
+	require.NoError(t, proc.pushConfigurationMap(logger.NewMockClient(), client, configMap, true))
+	assert.Equal(t, map[string]string{"Writable/LogLevel": "DEBUG"}, client.store)
+
+	client = &atomicPushStoreClient{pushStoreClient: newPushStoreClient(map[string]string{}, ""), atomicErr: errors.New("transaction rolled back")}
+	err := proc.pushConfigurationMap(logger.NewMockClient(), client, configMap, true)
+	require.Error(t, err)
+	assert.Empty(t, client.store)
+	var pushErr *PartialPushError
+	assert.False(t, errors.As(err, &pushErr), "an atomic push never partially succeeds")
+}
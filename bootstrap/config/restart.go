@@ -0,0 +1,117 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// RestartRequiredStream defines the stream type that is notified, with the changed paths, when a Writable update
+// changes a setting which requires the service to be restarted.
+type RestartRequiredStream chan []string
+
+// SetRestartRequiredWritablePaths sets the paths, relative to the Writable section and using "/" as the separator,
+// i.e. "MessageBus/Host", of the settings which can't be safely changed while the service is running. When an update
+// from the Configuration Provider changes a value within one of these paths, the change isn't applied to the service's
+// configuration. Instead, a warning is logged and the changed paths are sent to the restartRequired stream, if not nil,
+// so the service can orchestrate a controlled restart. The update's other changes are still applied. Must be called
+// before the watchers are started.
+func (cp *Processor) SetRestartRequiredWritablePaths(restartRequired RestartRequiredStream, paths ...string) {
+	cp.restartRequired = restartRequired
+	cp.restartRequiredPaths = paths
+}
+
+// withoutRestartRequiredChanges returns the Writable update without the settings within the restart-required paths
+// when any of them are changed by the update, in which case the restart required signal is sent. changed is false
+// when the update doesn't change any restart-required setting, in which case the update is returned unchanged.
+func (cp *Processor) withoutRestartRequiredChanges(lc logger.LoggingClient, currentWritable any, raw any) (update any, changed bool) {
+	var current, updated map[string]any
+	if err := utils.ConvertToMap(currentWritable, &current); err != nil {
+		lc.Errorf("failed to convert Writable to map, so unable to check for restart-required changes: %v", err)
+		return raw, false
+	}
+	if err := utils.ConvertToMap(raw, &updated); err != nil {
+		lc.Errorf("failed to convert Writable update to map, so unable to check for restart-required changes: %v", err)
+		return raw, false
+	}
+
+	var changedPaths []string
+	for _, path := range cp.restartRequiredPaths {
+		updatedValue, found := getMapValue(updated, path)
+		if !found {
+			continue
+		}
+
+		if currentValue, _ := getMapValue(current, path); !reflect.DeepEqual(currentValue, updatedValue) {
+			changedPaths = append(changedPaths, path)
+			removeMapValue(updated, path)
+		}
+	}
+
+	if len(changedPaths) == 0 {
+		return raw, false
+	}
+
+	lc.Warnf("Writable setting(s) %s changed, which require the service to be restarted. Change(s) not applied",
+		strings.Join(changedPaths, ", "))
+	cp.signalRestartRequired(lc, changedPaths)
+
+	return updated, true
+}
+
+// signalRestartRequired sends the changed restart-required paths to the restart required stream, if any
+func (cp *Processor) signalRestartRequired(lc logger.LoggingClient, changedPaths []string) {
+	if cp.restartRequired == nil {
+		return
+	}
+
+	// Don't block the watcher when the service isn't reading the stream, otherwise no further updates are processed
+	select {
+	case cp.restartRequired <- changedPaths:
+	default:
+		lc.Warn("Restart required signal dropped since nothing is reading the restart required stream")
+	}
+}
+
+// removeMapValue removes the value at the path, using "/" as the separator, from the nested map
+func removeMapValue(configMap map[string]any, path string) {
+	keys := strings.Split(path, "/")
+	current := configMap
+	for _, key := range keys[:len(keys)-1] {
+		next, ok := current[key].(map[string]any)
+		if !ok {
+			return
+		}
+		current = next
+	}
+
+	delete(current, keys[len(keys)-1])
+}
+
+// writableChangedFrom returns whether the current Writable differs from the previous Writable
+func writableChangedFrom(lc logger.LoggingClient, previousWritable map[string]any, currentWritable any) bool {
+	var current map[string]any
+	if err := utils.ConvertToMap(currentWritable, &current); err != nil {
+		lc.Errorf("failed to convert Writable to map, so processing update regardless of the restart-required paths: %v", err)
+		return true
+	}
+
+	return !reflect.DeepEqual(previousWritable, current)
+}
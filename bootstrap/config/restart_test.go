@@ -0,0 +1,119 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestApplyWritableUpdatesRestartRequired(t *testing.T) {
+	tests := []struct {
+		Name                  string
+		Update                WritableInfo
+		Expected              WritableInfo
+		ExpectedRestartPaths  []string
+		ExpectedUpdatedSignal bool
+	}{
+		{
+			Name:                  "Valid - normal field applied live",
+			Update:                WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{MaxRetryCount: 5}},
+			Expected:              WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{MaxRetryCount: 5}},
+			ExpectedUpdatedSignal: true,
+		},
+		{
+			Name:                 "Valid - restart-required field not applied",
+			Update:               WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{Enabled: true}},
+			Expected:             WritableInfo{LogLevel: "INFO"},
+			ExpectedRestartPaths: []string{"StoreAndForward/Enabled"},
+		},
+		{
+			Name:                  "Valid - restart-required field skipped, normal field applied",
+			Update:                WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{Enabled: true, MaxRetryCount: 5}},
+			Expected:              WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{MaxRetryCount: 5}},
+			ExpectedRestartPaths:  []string{"StoreAndForward/Enabled"},
+			ExpectedUpdatedSignal: true,
+		},
+		{
+			Name:                 "Valid - restart-required section not applied",
+			Update:               WritableInfo{LogLevel: "INFO", Telemetry: config.TelemetryInfo{Interval: "45s"}},
+			Expected:             WritableInfo{LogLevel: "INFO"},
+			ExpectedRestartPaths: []string{"Telemetry"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			f := flags.New()
+			f.Parse(nil)
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			configUpdated := make(UpdatedStream, 1)
+			restartRequired := make(RestartRequiredStream, 1)
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, configUpdated, dic)
+			proc.SetRestartRequiredWritablePaths(restartRequired, "StoreAndForward/Enabled", "Telemetry")
+
+			serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+			proc.applyWritableUpdates(proc.lc, serviceConfig, tc.Update, "private")
+
+			assert.Equal(t, tc.Expected, serviceConfig.Writable)
+			if len(tc.ExpectedRestartPaths) > 0 {
+				require.Len(t, restartRequired, 1)
+				assert.Equal(t, tc.ExpectedRestartPaths, <-restartRequired)
+			} else {
+				assert.Empty(t, restartRequired)
+			}
+
+			if tc.ExpectedUpdatedSignal {
+				assert.Len(t, configUpdated, 1)
+			} else {
+				assert.Empty(t, configUpdated)
+			}
+		})
+	}
+}
+
+func TestApplyWritableUpdatesRestartRequiredNoReader(t *testing.T) {
+	f := flags.New()
+	f.Parse(nil)
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+
+	proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+	proc.SetRestartRequiredWritablePaths(make(RestartRequiredStream), "StoreAndForward/Enabled")
+
+	serviceConfig := &ConfigurationMockStruct{Writable: WritableInfo{LogLevel: "INFO"}}
+
+	// Must not block when nothing is reading the stream
+	proc.applyWritableUpdates(proc.lc, serviceConfig, WritableInfo{LogLevel: "INFO", StoreAndForward: StoreAndForwardInfo{Enabled: true}}, "private")
+	assert.False(t, serviceConfig.Writable.StoreAndForward.Enabled)
+}
@@ -0,0 +1,169 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+)
+
+// schemaPathSeparator is the separator of the paths built when overriding the configuration
+const schemaPathSeparator = "/"
+
+// SchemaEntry describes a single setting of a service's configuration
+type SchemaEntry struct {
+	// Path is the dotted path of the setting, i.e. "Writable.LogLevel"
+	Path string
+	// Type is the Go type of the setting, i.e. "string" or "[]string"
+	Type string
+	// Default is the value of the setting in the configuration passed to ExportSchema, or nil if it's the zero value
+	Default any
+	// OmitEmpty is whether the setting is omitted when empty, as specified by the omitempty option of its json tag
+	OmitEmpty bool
+	// EnvOverride is the name of the environment variable which overrides the setting
+	EnvOverride string
+}
+
+// ExportSchema returns an entry for each setting of the configuration in the order the fields are declared. The paths
+// are built the same way as when the configuration is converted to a map for merging and overriding, i.e. using the
+// json tag name when present, so the entries match the environment variable overrides. Maps are reported as a setting
+// and, when their values are structs or maps, the settings for each key present in the configuration are also
+// reported. cfg may be the configuration struct or a pointer to it.
+func ExportSchema(cfg any) ([]SchemaEntry, error) {
+	value := reflect.Indirect(reflect.ValueOf(cfg))
+	if value.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configuration must be a struct or pointer to a struct, not %T", cfg)
+	}
+
+	var entries []SchemaEntry
+	exportStructSchema(value, "", &entries)
+	return entries, nil
+}
+
+// exportStructSchema adds the entries for the struct's exported fields with the path prefix to the entries
+func exportStructSchema(value reflect.Value, prefix string, entries *[]SchemaEntry) {
+	structType := value.Type()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		// The exported fields of embedded structs are promoted even when the struct's type is unexported
+		if !field.IsExported() && !field.Anonymous {
+			continue
+		}
+
+		name, omitEmpty, ok := schemaFieldName(field)
+		if !ok {
+			// The field is skipped by JSON, so it is never merged or overridden
+			continue
+		}
+
+		fieldValue := value.Field(i)
+		// Embedded structs without a json name are flattened into the parent, the same as encoding/json
+		if field.Anonymous && len(name) == 0 {
+			if embedded, ok := derefSchemaValue(fieldValue); ok && embedded.Kind() == reflect.Struct {
+				exportStructSchema(embedded, prefix, entries)
+				continue
+			}
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+
+		exportValueSchema(fieldValue, prefix+name, omitEmpty, entries)
+	}
+}
+
+// exportValueSchema adds the entries for the value at the path to the entries
+func exportValueSchema(value reflect.Value, path string, omitEmpty bool, entries *[]SchemaEntry) {
+	if derefed, ok := derefSchemaValue(value); ok && derefed.Kind() == reflect.Struct {
+		exportStructSchema(derefed, path+schemaPathSeparator, entries)
+		return
+	}
+
+	entry := SchemaEntry{
+		Path:        strings.ReplaceAll(path, schemaPathSeparator, "."),
+		Type:        value.Type().String(),
+		OmitEmpty:   omitEmpty,
+		EnvOverride: environment.OverrideNameFor(path),
+	}
+	if !value.IsZero() {
+		entry.Default = value.Interface()
+	}
+	*entries = append(*entries, entry)
+
+	if value.Kind() != reflect.Map || value.Type().Key().Kind() != reflect.String {
+		return
+	}
+
+	elemKind := value.Type().Elem().Kind()
+	if elemKind == reflect.Pointer {
+		elemKind = value.Type().Elem().Elem().Kind()
+	}
+	if elemKind != reflect.Struct && elemKind != reflect.Map {
+		return
+	}
+
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].String() < keys[j].String()
+	})
+	for _, key := range keys {
+		exportValueSchema(value.MapIndex(key), path+schemaPathSeparator+key.String(), false, entries)
+	}
+}
+
+// derefSchemaValue returns the value a pointer points to, or the zero value of its type when the pointer is nil, so
+// the schema includes the settings of unset sections. Non-pointer values are returned as is. false is returned when
+// the value is an interface, whose type isn't known until set.
+func derefSchemaValue(value reflect.Value) (reflect.Value, bool) {
+	switch value.Kind() {
+	case reflect.Interface:
+		return value, false
+	case reflect.Pointer:
+		if value.IsNil() {
+			return reflect.Zero(value.Type().Elem()), true
+		}
+		return value.Elem(), true
+	default:
+		return value, true
+	}
+}
+
+// schemaFieldName returns the json tag name, which is empty if not specified, and whether the omitempty option is
+// set for the field. false is returned if the field is ignored by JSON.
+func schemaFieldName(field reflect.StructField) (string, bool, bool) {
+	tag := field.Tag.Get("json")
+	if tag == "-" {
+		return "", false, false
+	}
+
+	name, options, _ := strings.Cut(tag, ",")
+	omitEmpty := false
+	for _, option := range strings.Split(options, ",") {
+		if option == "omitempty" {
+			omitEmpty = true
+		}
+	}
+
+	return name, omitEmpty, true
+}
@@ -0,0 +1,93 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+type schemaWritable struct {
+	LogLevel        string
+	InsecureSecrets config.InsecureSecrets
+}
+
+type schemaCommon struct {
+	Host string
+	Port int `json:"port,omitempty"`
+}
+
+type schemaConfig struct {
+	schemaCommon
+	Writable  schemaWritable
+	Registry  *config.RegistryInfo `json:"Registry"`
+	Tags      []string             `json:",omitempty"`
+	Ignored   string               `json:"-"`
+	unexposed string
+}
+
+func TestExportSchema(t *testing.T) {
+	cfg := &schemaConfig{
+		schemaCommon: schemaCommon{Host: "localhost"},
+		Writable: schemaWritable{
+			LogLevel: "INFO",
+			InsecureSecrets: config.InsecureSecrets{
+				"DB": {SecretName: "redisdb", SecretData: map[string]string{"username": "redis"}},
+			},
+		},
+		Tags: []string{"edgex"},
+	}
+
+	actual, err := ExportSchema(cfg)
+	require.NoError(t, err)
+
+	expected := []SchemaEntry{
+		{Path: "Host", Type: "string", Default: "localhost", EnvOverride: "HOST"},
+		{Path: "port", Type: "int", OmitEmpty: true, EnvOverride: "PORT"},
+		{Path: "Writable.LogLevel", Type: "string", Default: "INFO", EnvOverride: "WRITABLE_LOGLEVEL"},
+		{Path: "Writable.InsecureSecrets", Type: "config.InsecureSecrets", Default: cfg.Writable.InsecureSecrets, EnvOverride: "WRITABLE_INSECURESECRETS"},
+		{Path: "Writable.InsecureSecrets.DB.SecretName", Type: "string", Default: "redisdb", EnvOverride: "WRITABLE_INSECURESECRETS_DB_SECRETNAME"},
+		{Path: "Writable.InsecureSecrets.DB.SecretData", Type: "map[string]string", Default: map[string]string{"username": "redis"}, EnvOverride: "WRITABLE_INSECURESECRETS_DB_SECRETDATA"},
+		{Path: "Registry.Host", Type: "string", EnvOverride: "REGISTRY_HOST"},
+		{Path: "Registry.Port", Type: "int", EnvOverride: "REGISTRY_PORT"},
+		{Path: "Registry.Type", Type: "string", EnvOverride: "REGISTRY_TYPE"},
+		{Path: "Tags", Type: "[]string", Default: []string{"edgex"}, OmitEmpty: true, EnvOverride: "TAGS"},
+	}
+
+	assert.Equal(t, expected, actual)
+}
+
+func TestExportSchemaMatchesOverrides(t *testing.T) {
+	actual, err := ExportSchema(ConfigurationMockStruct{})
+	require.NoError(t, err)
+
+	names := make(map[string]string)
+	for _, entry := range actual {
+		names[entry.Path] = entry.EnvOverride
+	}
+
+	assert.Equal(t, "WRITABLE_LOGLEVEL", names["Writable.LogLevel"])
+	assert.Equal(t, "REGISTRY_HOST", names["Registry.Host"])
+}
+
+func TestExportSchemaInvalid(t *testing.T) {
+	_, err := ExportSchema("not a struct")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "must be a struct")
+}
@@ -0,0 +1,180 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+)
+
+// SecretReferenceScheme is the prefix of the configuration values which reference a secret in the secret store rather
+// than holding the value itself, i.e. "secret://redisdb/password" for the "password" key of the "redisdb" secret, so
+// the value isn't stored in plaintext in the Configuration Provider. The references are resolved by Process.
+const SecretReferenceScheme = "secret://"
+
+// secretResolver resolves the secret references found in the configuration, recording the references that couldn't
+// be resolved
+type secretResolver struct {
+	secretProvider interfaces.SecretProvider
+	resolved       int
+	failures       []string
+}
+
+// resolveSecretReferences replaces all the secret references in the service's configuration with the values of the
+// referenced secrets, using the secret provider from the DIC, or the one passed to Process when not in the DIC.
+func (cp *Processor) resolveSecretReferences(lc logger.LoggingClient, serviceConfig interfaces.Configuration, secretProvider interfaces.SecretProvider) error {
+	if dicProvider := container.SecretProviderFrom(cp.dic.Get); dicProvider != nil {
+		secretProvider = dicProvider
+	}
+
+	resolver := &secretResolver{secretProvider: secretProvider}
+	resolver.resolveValue(reflect.ValueOf(serviceConfig), "")
+
+	if len(resolver.failures) > 0 {
+		return newProcessError(ErrSecretReference, "unable to resolve %d secret reference(s): %s",
+			len(resolver.failures), strings.Join(resolver.failures, "; "))
+	}
+
+	if resolver.resolved > 0 {
+		lc.Infof("Resolved %d secret reference(s) in the configuration", resolver.resolved)
+	}
+
+	return nil
+}
+
+// resolveValue resolves the secret references in the value, which must be settable for the references to be replaced,
+// other than those in maps, which are replaced via SetMapIndex
+func (r *secretResolver) resolveValue(value reflect.Value, path string) {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !value.IsNil() {
+			r.resolveValue(value.Elem(), path)
+		}
+
+	case reflect.Struct:
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.IsExported() {
+				r.resolveValue(value.Field(i), joinSettingPath(path, field.Name))
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < value.Len(); i++ {
+			r.resolveValue(value.Index(i), fmt.Sprintf("%s[%d]", path, i))
+		}
+
+	case reflect.Map:
+		r.resolveMap(value, path)
+
+	case reflect.String:
+		if resolved, ok := r.resolveString(value.String(), path); ok && value.CanSet() {
+			value.SetString(resolved)
+		}
+	}
+}
+
+// resolveMap resolves the secret references in the map's values. Map values aren't addressable, so each value is
+// resolved in a copy which then replaces the value in the map.
+func (r *secretResolver) resolveMap(value reflect.Value, path string) {
+	keys := value.MapKeys()
+	sort.Slice(keys, func(i, j int) bool {
+		return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+	})
+
+	for _, key := range keys {
+		elemPath := joinSettingPath(path, fmt.Sprint(key.Interface()))
+		elem := value.MapIndex(key)
+
+		// Values held by interface, i.e. in a map[string]any, are resolved as their concrete type
+		concrete := elem
+		if concrete.Kind() == reflect.Interface && !concrete.IsNil() {
+			concrete = concrete.Elem()
+		}
+
+		if concrete.Kind() == reflect.String {
+			if resolved, ok := r.resolveString(concrete.String(), elemPath); ok {
+				value.SetMapIndex(key, reflect.ValueOf(resolved).Convert(concrete.Type()))
+			}
+			continue
+		}
+
+		if concrete.Kind() == reflect.Pointer || concrete.Kind() == reflect.Map {
+			// Shares the underlying data, so the references are replaced in place
+			r.resolveValue(concrete, elemPath)
+			continue
+		}
+
+		replacement := reflect.New(concrete.Type()).Elem()
+		replacement.Set(concrete)
+		resolvedBefore := r.resolved
+		r.resolveValue(replacement, elemPath)
+		if r.resolved > resolvedBefore {
+			value.SetMapIndex(key, replacement)
+		}
+	}
+}
+
+// resolveString returns the value of the referenced secret when the value is a secret reference. false is returned
+// when the value isn't a secret reference or the reference couldn't be resolved, in which case the failure is recorded.
+func (r *secretResolver) resolveString(value string, path string) (string, bool) {
+	if !strings.HasPrefix(value, SecretReferenceScheme) {
+		return "", false
+	}
+
+	reference := strings.TrimPrefix(value, SecretReferenceScheme)
+	separator := strings.LastIndex(reference, "/")
+	if separator <= 0 || separator == len(reference)-1 {
+		r.failures = append(r.failures, fmt.Sprintf("%s: reference '%s' must be in the form %s<secretName>/<key>", path, value, SecretReferenceScheme))
+		return "", false
+	}
+
+	if r.secretProvider == nil {
+		r.failures = append(r.failures, fmt.Sprintf("%s: reference '%s' can't be resolved without a secret provider", path, value))
+		return "", false
+	}
+
+	secretName, key := reference[:separator], reference[separator+1:]
+	secrets, err := r.secretProvider.GetSecret(secretName, key)
+	if err != nil {
+		r.failures = append(r.failures, fmt.Sprintf("%s: reference '%s' failed: %v", path, value, err))
+		return "", false
+	}
+
+	resolved, found := secrets[key]
+	if !found {
+		r.failures = append(r.failures, fmt.Sprintf("%s: reference '%s' failed: secret '%s' has no key '%s'", path, value, secretName, key))
+		return "", false
+	}
+
+	r.resolved++
+	return resolved, true
+}
+
+// joinSettingPath appends the name to the dotted path of the setting
+func joinSettingPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+
+	return path + flatKeySeparator + name
+}
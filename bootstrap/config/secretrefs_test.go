@@ -0,0 +1,182 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	mockInterfaces "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func newSecretReferenceProvider() *mockInterfaces.SecretProvider {
+	secretProvider := &mockInterfaces.SecretProvider{}
+	secretProvider.On("GetSecret", "trigger", "type").Return(map[string]string{"type": "http"}, nil)
+	secretProvider.On("GetSecret", "edgex/redisdb", "password").Return(map[string]string{"password": "p@ssw0rd"}, nil)
+	secretProvider.On("GetSecret", "registry", "host").Return(map[string]string{"other": "value"}, nil)
+	secretProvider.On("GetSecret", "missing", "type").Return(nil, errors.New("no secret data found"))
+	return secretProvider
+}
+
+func TestResolveSecretReferences(t *testing.T) {
+	tests := []struct {
+		Name           string
+		Config         ConfigurationMockStruct
+		NoProvider     bool
+		Expected       ConfigurationMockStruct
+		ExpectedErrors []string
+	}{
+		{
+			Name: "Valid - references resolved",
+			Config: ConfigurationMockStruct{
+				Trigger: TriggerInfo{Type: "secret://trigger/type"},
+				Writable: WritableInfo{InsecureSecrets: config.InsecureSecrets{
+					"DB": config.InsecureSecretsInfo{SecretName: "redisdb", SecretData: map[string]string{"password": "secret://edgex/redisdb/password"}},
+				}},
+				Registry: config.RegistryInfo{Host: "localhost"},
+			},
+			Expected: ConfigurationMockStruct{
+				Trigger: TriggerInfo{Type: "http"},
+				Writable: WritableInfo{InsecureSecrets: config.InsecureSecrets{
+					"DB": config.InsecureSecretsInfo{SecretName: "redisdb", SecretData: map[string]string{"password": "p@ssw0rd"}},
+				}},
+				Registry: config.RegistryInfo{Host: "localhost"},
+			},
+		},
+		{
+			Name:       "Valid - no references without provider",
+			Config:     ConfigurationMockStruct{Trigger: TriggerInfo{Type: "edgex-messagebus"}},
+			NoProvider: true,
+			Expected:   ConfigurationMockStruct{Trigger: TriggerInfo{Type: "edgex-messagebus"}},
+		},
+		{
+			Name:           "Invalid - missing secret",
+			Config:         ConfigurationMockStruct{Trigger: TriggerInfo{Type: "secret://missing/type"}},
+			ExpectedErrors: []string{"Trigger.Type: reference 'secret://missing/type' failed: no secret data found"},
+		},
+		{
+			Name:           "Invalid - missing key",
+			Config:         ConfigurationMockStruct{Registry: config.RegistryInfo{Host: "secret://registry/host"}},
+			ExpectedErrors: []string{"Registry.Host: reference 'secret://registry/host' failed: secret 'registry' has no key 'host'"},
+		},
+		{
+			Name: "Invalid - malformed references",
+			Config: ConfigurationMockStruct{
+				Trigger:  TriggerInfo{Type: "secret://type"},
+				Registry: config.RegistryInfo{Host: "secret://registry/"},
+			},
+			ExpectedErrors: []string{
+				"unable to resolve 2 secret reference(s)",
+				"Registry.Host: reference 'secret://registry/' must be in the form secret://<secretName>/<key>",
+				"Trigger.Type: reference 'secret://type' must be in the form secret://<secretName>/<key>",
+			},
+		},
+		{
+			Name:           "Invalid - no provider",
+			Config:         ConfigurationMockStruct{Trigger: TriggerInfo{Type: "secret://trigger/type"}},
+			NoProvider:     true,
+			ExpectedErrors: []string{"Trigger.Type: reference 'secret://trigger/type' can't be resolved without a secret provider"},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+			})
+			if !tc.NoProvider {
+				dic.Update(di.ServiceConstructorMap{
+					container.SecretProviderName: func(get di.Get) interface{} { return newSecretReferenceProvider() },
+				})
+			}
+
+			f := flags.New()
+			f.Parse(nil)
+			proc := NewProcessorForCustomConfig(f, context.Background(), &sync.WaitGroup{}, dic)
+
+			serviceConfig := tc.Config
+			err := proc.resolveSecretReferences(proc.lc, &serviceConfig, nil)
+			if len(tc.ExpectedErrors) > 0 {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrSecretReference)
+				for _, expected := range tc.ExpectedErrors {
+					assert.Contains(t, err.Error(), expected)
+				}
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, serviceConfig)
+		})
+	}
+}
+
+func TestProcessSecretReferences(t *testing.T) {
+	tests := []struct {
+		Name          string
+		TriggerType   string
+		Expected      string
+		ExpectedError string
+	}{
+		{"Valid - resolved", "secret://trigger/type", "http", ""},
+		{"Invalid - missing secret", "secret://missing/type", "", "Trigger.Type: reference 'secret://missing/type' failed"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(envKeyConfigUrl, "")
+
+			configDir := t.TempDir()
+			contents := []byte("Writable:\n  LogLevel: INFO\nTrigger:\n  Type: " + tc.TriggerType + "\n")
+			require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), contents, 0644))
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir})
+			mockLogger := logger.NewMockClient()
+			secretProvider := newSecretReferenceProvider()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+				container.SecretProviderName:         func(get di.Get) interface{} { return secretProvider },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			serviceConfig := &ConfigurationMockStruct{}
+			err := proc.Process("unit-test", config.ServiceTypeOther, "edgex/v3", serviceConfig, nil)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.ErrorIs(t, err, ErrSecretReference)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, serviceConfig.Trigger.Type)
+		})
+	}
+}
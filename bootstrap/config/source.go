@@ -0,0 +1,329 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ConfigSource is a source of the service's private configuration. The sources are loaded in priority order and the
+// configuration from each source is merged over the configuration from the sources before it.
+type ConfigSource interface {
+	// Name returns the name of the source, which is used in log and error messages
+	Name() string
+	// Load returns the configuration from the source, using the same structure as the configuration file
+	Load() (map[string]any, error)
+}
+
+// PrivateConfigSourcePriority is the priority of the built-in source of the private configuration, which is the
+// Configuration Provider when it already has the service's configuration, otherwise the configuration file.
+const PrivateConfigSourcePriority = 0
+
+type prioritizedConfigSource struct {
+	source   ConfigSource
+	priority int
+	builtIn  bool
+}
+
+// AddConfigSource adds a custom source of the private configuration. Sources with a lower priority than
+// PrivateConfigSourcePriority provide base configuration which the built-in source overrides, while those with a
+// higher priority override the built-in source. Sources with the same priority are merged in the order added, after
+// the built-in source. Environment variable overrides are applied to the configuration from custom sources, which is
+// never pushed into the Configuration Provider.
+func (cp *Processor) AddConfigSource(source ConfigSource, priority int) {
+	cp.configSources = append(cp.configSources, prioritizedConfigSource{source: source, priority: priority})
+}
+
+// AddFlatConfigSource adds a custom source of the private configuration, as for AddConfigSource, from a flat map of
+// settings, such as the data of a Kubernetes ConfigMap. Each key is the path of a setting with its keys separated by
+// dots, i.e. "Writable.LogLevel" or "Clients.core-metadata.Port", which are the same paths as those reported by
+// ExportSchema. The values are converted to the types of the settings: "true" and "false" for bools, base 10 numbers
+// for integers and floats, and comma separated values for slices. Values of settings not in the service's
+// configuration, i.e. new keys of maps, are left as strings.
+func (cp *Processor) AddFlatConfigSource(name string, values map[string]string, priority int) {
+	cp.AddConfigSource(&flatConfigSource{cp: cp, name: name, values: values}, priority)
+}
+
+// loadConfigSources loads the configuration from the built-in private source and the custom sources in priority order
+// and merges it into the service's configuration.
+func (cp *Processor) loadConfigSources(lc logger.LoggingClient, serviceConfig interfaces.Configuration, privateSource ConfigSource) error {
+	sources := append([]prioritizedConfigSource{{source: privateSource, priority: PrivateConfigSourcePriority, builtIn: true}}, cp.configSources...)
+	sort.SliceStable(sources, func(i, j int) bool {
+		return sources[i].priority < sources[j].priority
+	})
+
+	// The settings present in the sources are checked for deprecations, since the service's configuration struct may
+	// no longer have them
+	var loadedMaps []map[string]any
+	for _, prioritized := range sources {
+		source := prioritized.source
+		configMap, err := source.Load()
+		if err != nil {
+			if prioritized.builtIn {
+				return err
+			}
+			return fmt.Errorf("failed to load configuration from %s: %w", source.Name(), err)
+		}
+
+		if prioritized.builtIn {
+			cp.result.Source = source.Name()
+			switch builtInSource := source.(type) {
+			case *fileConfigSource:
+				cp.result.PrivateOverrideCount += builtInSource.overrideCount
+				cp.recordProvenance(ProvenanceFile, configMap)
+				cp.recordConfigMapOverrideProvenance(configMap)
+			case *memoryConfigSource:
+				cp.result.PrivateOverrideCount += builtInSource.overrideCount
+				cp.recordProvenance(ProvenanceMemory, configMap)
+				cp.recordConfigMapOverrideProvenance(configMap)
+			default:
+				cp.recordProvenance(ProvenancePrivateProvider, configMap)
+			}
+		} else {
+			overrideCount, err := cp.envVars.OverrideConfigMapValues(configMap)
+			if err := cp.checkRejectedOverrides(lc, err); err != nil {
+				return err
+			}
+			lc.Infof("Configuration loaded from %s with %d overrides applied", source.Name(), overrideCount)
+			cp.result.PrivateOverrideCount += overrideCount
+			cp.result.CustomSources = append(cp.result.CustomSources, source.Name())
+			cp.recordProvenance(source.Name(), configMap)
+			cp.recordConfigMapOverrideProvenance(configMap)
+		}
+
+		if err := utils.MergeValues(serviceConfig, configMap); err != nil {
+			return newProcessError(ErrMergeFailed, "could not merge configuration from %s: %w", source.Name(), err)
+		}
+		loadedMaps = append(loadedMaps, configMap)
+	}
+
+	cp.warnDeprecatedSettings(lc, loadedMaps)
+
+	return nil
+}
+
+// providerConfigSource is the built-in source of the private configuration from the Configuration Provider
+type providerConfigSource struct {
+	cp            *Processor
+	lc            logger.LoggingClient
+	serviceConfig interfaces.Configuration
+	client        configuration.Client
+	baseKey       string
+}
+
+func (s *providerConfigSource) Name() string {
+	return "Configuration Provider"
+}
+
+// Load returns only the private settings actually present in the Configuration Provider, so they can be merged over
+// the common configuration.
+func (s *providerConfigSource) Load() (map[string]any, error) {
+	privateServiceConfig, err := copyConfigurationStruct(s.serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	var configKeys []string
+	if s.cp.lazyConfig() {
+		configKeys, err = s.loadPartial(privateServiceConfig)
+	} else {
+		s.cp.setDeferredSections(nil)
+		if err = s.cp.loadConfigFromProvider(privateServiceConfig, s.client); err == nil {
+			configKeys, err = s.client.GetConfigurationKeys("")
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if s.cp.keepUnknownSettings {
+		if err := s.loadUnknownSettings(privateServiceConfig, configKeys); err != nil {
+			return nil, err
+		}
+	}
+
+	// Must remove any settings in the config that are not actually present in the Config Provider
+	privateConfigKeys := utils.StringSliceToMap(configKeys)
+	privateConfigMap, err := utils.RemoveUnusedSettings(privateServiceConfig, s.baseKey, privateConfigKeys)
+	if err != nil {
+		return nil, newProcessError(ErrMergeFailed, "could not remove unused settings from private configurations: %w", err)
+	}
+
+	s.lc.Info("Private configuration loaded from the Configuration Provider. No overrides applied")
+	return privateConfigMap, nil
+}
+
+// loadUnknownSettings keeps the raw values of the settings present in the Config Provider which the service's
+// configuration doesn't have, so they aren't lost by services which are older than the configuration
+func (s *providerConfigSource) loadUnknownSettings(privateServiceConfig interfaces.Configuration, configKeys []string) error {
+	unknownKeys, err := utils.FindUnknownSettings(privateServiceConfig, s.baseKey, configKeys)
+	if err != nil {
+		return newProcessError(ErrMergeFailed, "could not find unknown settings in private configuration: %w", err)
+	}
+
+	unknownSettings := make(map[string]string, len(unknownKeys))
+	for _, key := range unknownKeys {
+		value, err := s.client.GetConfigurationValueByFullPath(key)
+		if err != nil {
+			return newProcessError(ErrProviderUnavailable, "could not get unknown setting %s from Configuration Provider: %w", key, err)
+		}
+		unknownSettings[strings.TrimPrefix(key, s.baseKey+utils.PathSep)] = string(value)
+	}
+
+	if len(unknownSettings) > 0 {
+		s.lc.Infof("Kept %d private settings from the Configuration Provider unknown to the service's configuration", len(unknownSettings))
+	}
+	s.cp.unknownSettings = unknownSettings
+	return nil
+}
+
+// fileConfigSource is the built-in source of the private configuration from the local configuration file
+type fileConfigSource struct {
+	cp          *Processor
+	lc          logger.LoggingClient
+	serviceType string
+	// configMap is the configuration last loaded, which is pushed into the Configuration Provider when used
+	configMap map[string]any
+	// overrideCount is the number of overrides applied to the configuration last loaded
+	overrideCount int
+}
+
+func (s *fileConfigSource) Name() string {
+	return "configuration file"
+}
+
+// Load returns the private configuration file with the environment variable overrides applied
+func (s *fileConfigSource) Load() (map[string]any, error) {
+	configMap, err := s.cp.loadPrivateConfigFile(s.lc, s.serviceType)
+	if err != nil {
+		return nil, err
+	}
+
+	// apply overrides - Now only done when loaded from file and values will get pushed into Configuration Provider (if used)
+	overrideCount, err := s.cp.envVars.OverrideConfigMapValues(configMap)
+	if err := s.cp.checkRejectedOverrides(s.lc, err); err != nil {
+		return nil, err
+	}
+	s.lc.Infof("Private configuration loaded from file with %d overrides applied", overrideCount)
+
+	s.configMap = configMap
+	s.overrideCount = overrideCount
+	return configMap, nil
+}
+
+// flatConfigSource is a custom source of the private configuration from a flat map of dotted keys
+type flatConfigSource struct {
+	cp     *Processor
+	name   string
+	values map[string]string
+}
+
+func (s *flatConfigSource) Name() string {
+	return s.name
+}
+
+// Load expands the flat map into the nested configuration with the values converted to the types of the settings
+func (s *flatConfigSource) Load() (map[string]any, error) {
+	configMap, err := utils.ExpandMap(s.values, flatKeySeparator)
+	if err != nil {
+		return nil, newProcessError(ErrConfigParse, "invalid configuration keys: %w", err)
+	}
+
+	settingTypes := make(map[string]string)
+	if s.cp.serviceConfig != nil {
+		schema, err := ExportSchema(s.cp.serviceConfig)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range schema {
+			settingTypes[entry.Path] = entry.Type
+		}
+	}
+
+	if err := convertFlatValues(configMap, "", settingTypes); err != nil {
+		return nil, newProcessError(ErrConfigParse, "%w", err)
+	}
+
+	return configMap, nil
+}
+
+// flatKeySeparator is the separator of the keys within the paths of a flat configuration map
+const flatKeySeparator = "."
+
+// convertFlatValues converts the string values of the expanded configuration map, which are at the path, to the types
+// of the settings
+func convertFlatValues(configMap map[string]any, path string, settingTypes map[string]string) error {
+	for key, value := range configMap {
+		settingPath := key
+		if len(path) > 0 {
+			settingPath = path + flatKeySeparator + key
+		}
+
+		if section, ok := value.(map[string]any); ok {
+			if err := convertFlatValues(section, settingPath, settingTypes); err != nil {
+				return err
+			}
+			continue
+		}
+
+		settingType, found := settingTypes[settingPath]
+		if !found {
+			// New keys of maps have the type of the map's values
+			settingType = strings.TrimPrefix(settingTypes[path], "map[string]")
+		}
+
+		converted, err := convertFlatValue(value.(string), settingType)
+		if err != nil {
+			return fmt.Errorf("invalid value '%s' for %s: %w", value, settingPath, err)
+		}
+		configMap[key] = converted
+	}
+
+	return nil
+}
+
+// convertFlatValue converts the value to the type named by settingType, as reported by ExportSchema
+func convertFlatValue(value string, settingType string) (any, error) {
+	switch {
+	case settingType == "bool":
+		return strconv.ParseBool(value)
+	case strings.HasPrefix(settingType, "int"):
+		return strconv.ParseInt(value, 10, 64)
+	case strings.HasPrefix(settingType, "uint"):
+		return strconv.ParseUint(value, 10, 64)
+	case strings.HasPrefix(settingType, "float"):
+		return strconv.ParseFloat(value, 64)
+	case strings.HasPrefix(settingType, "[]"):
+		if len(strings.TrimSpace(value)) == 0 {
+			return []any{}, nil
+		}
+		var values []any
+		for _, item := range strings.Split(value, ",") {
+			values = append(values, strings.TrimSpace(item))
+		}
+		return values, nil
+	default:
+		return value, nil
+	}
+}
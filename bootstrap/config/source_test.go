@@ -0,0 +1,284 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+type testConfigSource struct {
+	name      string
+	configMap map[string]any
+	err       error
+}
+
+func (s *testConfigSource) Name() string {
+	return s.name
+}
+
+func (s *testConfigSource) Load() (map[string]any, error) {
+	return s.configMap, s.err
+}
+
+func TestProcessConfigSources(t *testing.T) {
+	configDir := t.TempDir()
+	fileContents := "Writable:\n  LogLevel: INFO\nRegistry:\n  Host: file-host\n  Port: 8500\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte(fileContents), 0644))
+
+	base := &testConfigSource{
+		name: "base database",
+		configMap: map[string]any{
+			"Writable": map[string]any{"LogLevel": "DEBUG"},
+			"Registry": map[string]any{"Type": "consul"},
+			"Trigger":  map[string]any{"Type": "edgex-messagebus"},
+		},
+	}
+	override := &testConfigSource{
+		name:      "override database",
+		configMap: map[string]any{"Registry": map[string]any{"Host": "database-host"}},
+	}
+
+	tests := []struct {
+		Name           string
+		Sources        []*testConfigSource
+		Priorities     []int
+		EnvVars        map[string]string
+		ExpectedConfig *ConfigurationMockStruct
+		ExpectedError  string
+	}{
+		{
+			Name: "Valid - no custom sources",
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: "INFO"},
+				Registry: config.RegistryInfo{Host: "file-host", Port: 8500},
+			},
+		},
+		{
+			Name:       "Valid - base and override sources",
+			Sources:    []*testConfigSource{override, base},
+			Priorities: []int{10, -10},
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: "INFO"},
+				Registry: config.RegistryInfo{Host: "database-host", Port: 8500, Type: "consul"},
+				Trigger:  TriggerInfo{Type: "edgex-messagebus"},
+			},
+		},
+		{
+			Name:       "Valid - overrides applied to custom source",
+			Sources:    []*testConfigSource{override},
+			Priorities: []int{10},
+			EnvVars:    map[string]string{"REGISTRY_HOST": "env-host"},
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: "INFO"},
+				Registry: config.RegistryInfo{Host: "env-host", Port: 8500},
+			},
+		},
+		{
+			Name:          "Invalid - custom source fails",
+			Sources:       []*testConfigSource{{name: "broken database", err: errors.New("connection refused")}},
+			Priorities:    []int{10},
+			ExpectedError: "failed to load configuration from broken database: connection refused",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests in this package may leave the provider override set, so ensure the file is used
+			t.Setenv(envKeyConfigUrl, "")
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir})
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			for index, source := range tc.Sources {
+				proc.AddConfigSource(source, tc.Priorities[index])
+			}
+
+			serviceConfig := &ConfigurationMockStruct{}
+			err := proc.Process("unit-test", config.ServiceTypeOther, "edgex/v3", serviceConfig, nil)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedConfig, serviceConfig)
+		})
+	}
+}
+
+func TestProviderConfigSourceUnknownSettings(t *testing.T) {
+	baseKey := "edgex/v3/unit-test"
+	configKeys := []string{
+		baseKey + "/Writable/LogLevel",
+		baseKey + "/Writable/FutureSetting",
+		baseKey + "/Registry/Host",
+		baseKey + "/FutureSection/Enabled",
+	}
+
+	tests := []struct {
+		Name             string
+		Keep             bool
+		ExpectedSettings map[string]string
+	}{
+		{"Kept", true, map[string]string{"Writable/FutureSetting": "future", "FutureSection/Enabled": "true"}},
+		{"Ignored", false, map[string]string{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+			proc := NewProcessor(flags.New(), environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.SetKeepUnknownSettings(tc.Keep)
+
+			providerConfig := &ConfigurationMockStruct{
+				Writable: WritableInfo{LogLevel: "DEBUG"},
+				Registry: config.RegistryInfo{Host: "provider-host"},
+			}
+			providerClientMock := &mocks.Client{}
+			providerClientMock.On("GetConfiguration", mock.Anything).Return(providerConfig, nil)
+			providerClientMock.On("GetConfigurationKeys", "").Return(configKeys, nil)
+			providerClientMock.On("GetConfigurationValueByFullPath", baseKey+"/Writable/FutureSetting").Return([]byte("future"), nil)
+			providerClientMock.On("GetConfigurationValueByFullPath", baseKey+"/FutureSection/Enabled").Return([]byte("true"), nil)
+
+			source := &providerConfigSource{
+				cp:            proc,
+				lc:            mockLogger,
+				serviceConfig: &ConfigurationMockStruct{},
+				client:        providerClientMock,
+				baseKey:       baseKey,
+			}
+
+			// The unknown settings must survive each time the configuration is loaded
+			for i := 0; i < 2; i++ {
+				configMap, err := source.Load()
+				require.NoError(t, err)
+				assert.Equal(t, "DEBUG", configMap["Writable"].(map[string]any)["LogLevel"])
+				assert.Equal(t, tc.ExpectedSettings, proc.UnknownSettings())
+			}
+
+			providerClientMock.AssertNotCalled(t, "PutConfigurationMap", mock.Anything, mock.Anything)
+			providerClientMock.AssertNotCalled(t, "PutConfigurationValue", mock.Anything, mock.Anything)
+			if !tc.Keep {
+				providerClientMock.AssertNotCalled(t, "GetConfigurationValueByFullPath", mock.Anything)
+			}
+		})
+	}
+}
+
+func TestProcessFlatConfigSource(t *testing.T) {
+	configDir := t.TempDir()
+	fileContents := "Writable:\n  LogLevel: INFO\nRegistry:\n  Host: file-host\n  Port: 8500\n"
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte(fileContents), 0644))
+
+	tests := []struct {
+		Name           string
+		Values         map[string]string
+		ExpectedConfig *ConfigurationMockStruct
+		ExpectedError  string
+	}{
+		{
+			Name: "Valid",
+			Values: map[string]string{
+				"Writable.LogLevel":                               "DEBUG",
+				"Writable.StoreAndForward.Enabled":                "true",
+				"Writable.StoreAndForward.MaxRetryCount":          "10",
+				"Writable.Telemetry.Metrics.EventsPersisted":      "true",
+				"Writable.InsecureSecrets.DB.SecretName":          "redisdb",
+				"Writable.InsecureSecrets.DB.SecretData.password": "12345",
+				"Registry.Port":                                   "8501",
+			},
+			ExpectedConfig: &ConfigurationMockStruct{
+				Writable: WritableInfo{
+					LogLevel:        "DEBUG",
+					StoreAndForward: StoreAndForwardInfo{Enabled: true, MaxRetryCount: 10},
+					Telemetry:       config.TelemetryInfo{Metrics: map[string]bool{"EventsPersisted": true}},
+					InsecureSecrets: config.InsecureSecrets{
+						"DB": config.InsecureSecretsInfo{SecretName: "redisdb", SecretData: map[string]string{"password": "12345"}},
+					},
+				},
+				Registry: config.RegistryInfo{Host: "file-host", Port: 8501},
+			},
+		},
+		{
+			Name:          "Invalid - bad bool",
+			Values:        map[string]string{"Writable.StoreAndForward.Enabled": "yes please"},
+			ExpectedError: "invalid value 'yes please' for Writable.StoreAndForward.Enabled",
+		},
+		{
+			Name:          "Invalid - setting and section",
+			Values:        map[string]string{"Writable": "DEBUG", "Writable.LogLevel": "DEBUG"},
+			ExpectedError: "key 'Writable' is both a setting and a section",
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests in this package may leave the provider override set, so ensure the file is used
+			t.Setenv(envKeyConfigUrl, "")
+
+			f := flags.New()
+			f.Parse([]string{"-cd", configDir})
+			mockLogger := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+			})
+
+			proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+			proc.AddFlatConfigSource("configmap", tc.Values, 10)
+
+			serviceConfig := &ConfigurationMockStruct{}
+			err := proc.Process("unit-test", config.ServiceTypeOther, "edgex/v3", serviceConfig, nil)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				assert.ErrorIs(t, err, ErrConfigParse)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedConfig, serviceConfig)
+		})
+	}
+}
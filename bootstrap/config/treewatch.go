@@ -0,0 +1,153 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ListenForAllConfigChanges listens for changes anywhere in the service's private configuration, by watching the
+// Configuration Provider from the service's base key rather than just the Writable section. When changes occur
+// changedCallback is called with the updated configuration, which has the same type as serviceConfig, and the sorted
+// paths of the changed settings, i.e. "Service/Port". The changes aren't applied to the service's configuration, since
+// settings outside Writable generally can't be safely changed while the service is running and may require a restart,
+// which is logged as a warning. This watcher is opt-in and independent of the Writable watcher started by Process,
+// which still applies the Writable changes. The returned StopFunc stops just this watcher. changedCallback is called
+// one update at a time, in the order received, with the other configuration updates (see SetUpdateQueueSize).
+func (cp *Processor) ListenForAllConfigChanges(
+	serviceConfig interfaces.Configuration,
+	changedCallback func(updated any, changedPaths []string)) StopFunc {
+	lc := cp.operationLogger("WatchAllConfig")
+	configToWatch, err := copyConfigurationStruct(serviceConfig)
+	if err != nil {
+		lc.Errorf("unable to watch the whole configuration for changes: %v", err)
+		return func() {}
+	}
+
+	configClient, err := cp.newWatchClient()
+	if err != nil {
+		lc.Warnf("unable to watch the whole configuration for changes: %v", err)
+		return func() {}
+	}
+
+	watchCtx, stopWatch := context.WithCancel(cp.ctx)
+	watcherName := fmt.Sprintf("private %s", cp.baseKey)
+
+	cp.startWatcher(watcherName, func() {
+		errorStream := make(chan error)
+		updateStream := make(chan any)
+
+		// The empty key watches the whole tree under the client's base path, which is the service's base key
+		configClient.WatchForChanges(updateStream, errorStream, configToWatch, "")
+
+		var previousValues map[string]string
+
+		for {
+			select {
+			case <-watchCtx.Done():
+				lc.Info("Watching for configuration changes has stopped")
+				stopWatchStreams(configClient, updateStream, errorStream)
+				return
+
+			case ex := <-errorStream:
+				lc.Error(ex.Error())
+
+			case raw := <-updateStream:
+				values, err := flattenConfigStruct(raw)
+				if err != nil {
+					lc.Errorf("unable to determine the changed configuration: %v", err)
+					continue
+				}
+
+				// The Configuration Provider sends an update as soon as the watcher is connected, which is the
+				// configuration the changes are compared against
+				if previousValues == nil {
+					previousValues = values
+					continue
+				}
+
+				changedPaths := changedConfigPaths(previousValues, values)
+				previousValues = values
+				if len(changedPaths) == 0 {
+					continue
+				}
+
+				if restartPaths := pathsOutsideWritable(changedPaths); len(restartPaths) > 0 {
+					lc.Warnf("Configuration changes outside '%s' have been received for %s. These aren't applied to the "+
+						"running service and applying them may require the service to be restarted",
+						writableKey, strings.Join(restartPaths, ", "))
+				} else {
+					lc.Infof("Configuration changes have been received for %s", strings.Join(changedPaths, ", "))
+				}
+
+				cp.queueUpdate(lc, watcherName, func() {
+					changedCallback(raw, changedPaths)
+				})
+			}
+		}
+	})
+
+	lc.Infof("Watching for configuration changes has started for `%s`", cp.baseKey)
+
+	return StopFunc(stopWatch)
+}
+
+// flattenConfigStruct flattens the configuration into its values keyed by path, the same as they are stored in the
+// Configuration Provider
+func flattenConfigStruct(config any) (map[string]string, error) {
+	configMap := make(map[string]any)
+	if err := utils.ConvertToMap(config, &configMap); err != nil {
+		return nil, err
+	}
+
+	values := make(map[string]string)
+	flattenConfigValue("", configMap, values)
+	return values, nil
+}
+
+// changedConfigPaths returns the sorted paths of the values which were added, removed or changed
+func changedConfigPaths(previousValues map[string]string, values map[string]string) []string {
+	var changedPaths []string
+	for path, value := range values {
+		if previous, found := previousValues[path]; !found || previous != value {
+			changedPaths = append(changedPaths, path)
+		}
+	}
+	for path := range previousValues {
+		if _, found := values[path]; !found {
+			changedPaths = append(changedPaths, path)
+		}
+	}
+
+	sort.Strings(changedPaths)
+	return changedPaths
+}
+
+// pathsOutsideWritable returns the paths which aren't within the Writable section
+func pathsOutsideWritable(paths []string) []string {
+	var outside []string
+	for _, path := range paths {
+		if path != writableKey && !strings.HasPrefix(path, writableKey+utils.PathSep) {
+			outside = append(outside, path)
+		}
+	}
+	return outside
+}
@@ -0,0 +1,138 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration"
+	"github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestListenForAllConfigChanges(t *testing.T) {
+	f := flags.New()
+	f.Parse(nil)
+
+	watcherUpdates := make(chan chan<- any, 1)
+	providerClientMock := &mocks.Client{}
+	providerClientMock.On("WatchForChanges", mock.Anything, mock.Anything, mock.Anything, "").
+		Run(func(args mock.Arguments) {
+			watcherUpdates <- args.Get(0).(chan<- any)
+		}).Return()
+	providerClientMock.On("StopWatching").Return()
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+	})
+	container.InjectConfigClientFactory(dic, func(basePath string) (configuration.Client, error) {
+		assert.Equal(t, "edgex/v3/unit-test", basePath)
+		return providerClientMock, nil
+	})
+	proc := NewProcessor(f, nil, startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+	proc.configStem = "edgex/v3"
+	proc.serviceKey = "unit-test"
+	proc.baseKey = "edgex/v3/unit-test"
+
+	serviceConfig := &ConfigurationMockStruct{
+		Writable: WritableInfo{LogLevel: "INFO"},
+		Registry: config.RegistryInfo{Host: "localhost", Port: 8500},
+	}
+
+	type change struct {
+		updated      any
+		changedPaths []string
+	}
+	received := make(chan change, 10)
+	proc.ListenForAllConfigChanges(serviceConfig, func(updated any, changedPaths []string) {
+		received <- change{updated: updated, changedPaths: changedPaths}
+	})
+
+	var updates chan<- any
+	select {
+	case updates = <-watcherUpdates:
+	case <-time.After(time.Second):
+		require.Fail(t, "watcher not started")
+	}
+
+	sendUpdate := func(update *ConfigurationMockStruct) {
+		select {
+		case updates <- update:
+		case <-time.After(time.Second):
+			require.Fail(t, "watcher is blocked and not processing updates")
+		}
+	}
+	receiveChange := func() change {
+		select {
+		case actual := <-received:
+			return actual
+		case <-time.After(time.Second):
+			require.Fail(t, "change not received")
+			return change{}
+		}
+	}
+
+	// The first update is the configuration the changes are compared against
+	sendUpdate(&ConfigurationMockStruct{
+		Writable: WritableInfo{LogLevel: "INFO"},
+		Registry: config.RegistryInfo{Host: "localhost", Port: 8500},
+	})
+
+	// A change outside Writable triggers the callback, but isn't applied to the service's configuration
+	hostChanged := &ConfigurationMockStruct{
+		Writable: WritableInfo{LogLevel: "INFO"},
+		Registry: config.RegistryInfo{Host: "consul", Port: 8500},
+	}
+	sendUpdate(hostChanged)
+	actual := receiveChange()
+	assert.Equal(t, []string{"Registry/Host"}, actual.changedPaths)
+	assert.Equal(t, hostChanged, actual.updated)
+	assert.Equal(t, "localhost", serviceConfig.Registry.Host)
+
+	// An update without changes doesn't trigger the callback, while a change within Writable does
+	sendUpdate(hostChanged)
+	sendUpdate(&ConfigurationMockStruct{
+		Writable: WritableInfo{LogLevel: "DEBUG"},
+		Registry: config.RegistryInfo{Host: "consul", Port: 8500},
+	})
+	assert.Equal(t, []string{"Writable/LogLevel"}, receiveChange().changedPaths)
+	assert.Empty(t, received)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	require.NoError(t, proc.Shutdown(shutdownCtx))
+	providerClientMock.AssertCalled(t, "StopWatching")
+}
+
+func TestChangedConfigPaths(t *testing.T) {
+	previous := map[string]string{"Service/Host": "localhost", "Service/Port": "59880", "Writable/LogLevel": "INFO"}
+	current := map[string]string{"Service/Host": "core-data", "Service/Port": "59880", "Writable/Telemetry/Interval": "30s"}
+
+	assert.Equal(t, []string{"Service/Host", "Writable/LogLevel", "Writable/Telemetry/Interval"}, changedConfigPaths(previous, current))
+	assert.Empty(t, changedConfigPaths(previous, previous))
+	assert.Equal(t, []string{"Service/Host"}, pathsOutsideWritable([]string{"Service/Host", "Writable", "Writable/LogLevel"}))
+}
@@ -0,0 +1,155 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+// DefaultUpdateQueueSize is the default maximum number of configuration updates waiting to be applied
+const DefaultUpdateQueueSize = 16
+
+// queuedUpdate is a configuration update received by a watcher, waiting to be applied
+type queuedUpdate struct {
+	// source is the name of the watcher which received the update
+	source string
+	apply  func()
+}
+
+// updateQueue serializes the application of the configuration updates received by all the watchers, so the updates
+// are applied one at a time in the order received. The queue is bounded, so the watchers are never blocked by slow
+// updates. When the queue is full, a new update replaces the last update from the same watcher still waiting, since
+// each update has the whole of the watched section, otherwise the new update is dropped.
+type updateQueue struct {
+	mutex   sync.Mutex
+	size    int
+	pending []queuedUpdate
+	ready   chan struct{}
+}
+
+func newUpdateQueue(size int) *updateQueue {
+	if size <= 0 {
+		size = DefaultUpdateQueueSize
+	}
+
+	return &updateQueue{
+		size:  size,
+		ready: make(chan struct{}, 1),
+	}
+}
+
+// push adds the update from the source to the queue and returns whether it was coalesced with an update already
+// waiting or dropped because the queue is full
+func (q *updateQueue) push(source string, apply func()) (coalesced bool, dropped bool) {
+	q.mutex.Lock()
+	defer func() {
+		q.mutex.Unlock()
+		if !dropped {
+			select {
+			case q.ready <- struct{}{}:
+			default:
+			}
+		}
+	}()
+
+	if len(q.pending) < q.size {
+		q.pending = append(q.pending, queuedUpdate{source: source, apply: apply})
+		return false, false
+	}
+
+	for i := len(q.pending) - 1; i >= 0; i-- {
+		if q.pending[i].source == source {
+			q.pending[i].apply = apply
+			return true, false
+		}
+	}
+
+	return false, true
+}
+
+// pop removes and returns the oldest update waiting. false is returned when the queue is empty.
+func (q *updateQueue) pop() (queuedUpdate, bool) {
+	q.mutex.Lock()
+	defer q.mutex.Unlock()
+
+	if len(q.pending) == 0 {
+		return queuedUpdate{}, false
+	}
+
+	update := q.pending[0]
+	q.pending[0] = queuedUpdate{}
+	q.pending = q.pending[1:]
+	return update, true
+}
+
+// SetUpdateQueueSize sets the maximum number of configuration updates waiting to be applied. The updates from all the
+// watchers are applied one at a time, in the order received, so a burst of changes can't be applied concurrently or
+// out of order. Defaults to DefaultUpdateQueueSize. Must be called before the watchers are started.
+func (cp *Processor) SetUpdateQueueSize(size int) {
+	cp.updateQueueSize = size
+}
+
+// queueUpdate queues the update received by the source watcher to be applied by the update worker, which is started
+// by the first update. The worker is stopped along with the watchers, but isn't itself reported as a watcher.
+func (cp *Processor) queueUpdate(lc logger.LoggingClient, source string, apply func()) {
+	cp.updateWorkerOnce.Do(func() {
+		cp.updates = newUpdateQueue(cp.updateQueueSize)
+		cp.wg.Add(1)
+		cp.watchersWg.Add(1)
+		go func() {
+			defer func() {
+				cp.watchersWg.Done()
+				cp.wg.Done()
+			}()
+
+			cp.runUpdateWorker()
+		}()
+	})
+
+	coalesced, dropped := cp.updates.push(source, apply)
+	switch {
+	case dropped:
+		lc.Warnf("Configuration update queue is full. Dropped update from %s watcher", source)
+	case coalesced:
+		lc.Warnf("Configuration update queue is full. Coalesced update from %s watcher with its previous update", source)
+	}
+}
+
+// runUpdateWorker applies the queued updates one at a time until the Processor's context is done. The updates still
+// queued at that point are applied before it exits, so the updates received before Shutdown aren't lost.
+func (cp *Processor) runUpdateWorker() {
+	for {
+		select {
+		case <-cp.ctx.Done():
+			cp.applyQueuedUpdates()
+			return
+		case <-cp.updates.ready:
+			cp.applyQueuedUpdates()
+		}
+	}
+}
+
+// applyQueuedUpdates applies the queued updates, in order, until the queue is empty
+func (cp *Processor) applyQueuedUpdates() {
+	for {
+		update, ok := cp.updates.pop()
+		if !ok {
+			return
+		}
+		update.apply()
+	}
+}
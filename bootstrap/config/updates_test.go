@@ -0,0 +1,133 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func newUpdateTestProcessor(t *testing.T, queueSize int) *Processor {
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return logger.NewMockClient() },
+	})
+
+	f := flags.New()
+	f.Parse(nil)
+	proc := NewProcessor(f, nil, startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+	proc.SetUpdateQueueSize(queueSize)
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		require.NoError(t, proc.Shutdown(ctx))
+	})
+
+	return proc
+}
+
+func TestQueueUpdateSerialized(t *testing.T) {
+	const updateCount = 100
+	proc := newUpdateTestProcessor(t, updateCount)
+
+	var running int32
+	var overlapped atomic.Bool
+	var appliedMutex sync.Mutex
+	var applied []int
+
+	var wg sync.WaitGroup
+	wg.Add(updateCount)
+	for i := 0; i < updateCount; i++ {
+		update := i
+		proc.queueUpdate(proc.lc, "private", func() {
+			defer wg.Done()
+			if atomic.AddInt32(&running, 1) > 1 {
+				overlapped.Store(true)
+			}
+			time.Sleep(100 * time.Microsecond)
+
+			appliedMutex.Lock()
+			applied = append(applied, update)
+			appliedMutex.Unlock()
+
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	wg.Wait()
+
+	assert.False(t, overlapped.Load(), "updates must not be applied concurrently")
+	require.Len(t, applied, updateCount)
+	for i, update := range applied {
+		require.Equal(t, i, update, "updates must be applied in the order received")
+	}
+}
+
+func TestQueueUpdateFull(t *testing.T) {
+	proc := newUpdateTestProcessor(t, 3)
+
+	var appliedMutex sync.Mutex
+	var applied []string
+	record := func(name string) func() {
+		return func() {
+			appliedMutex.Lock()
+			defer appliedMutex.Unlock()
+			applied = append(applied, name)
+		}
+	}
+
+	// Block the worker with the first update, so the following updates wait in the queue
+	started := make(chan struct{})
+	release := make(chan struct{})
+	proc.queueUpdate(proc.lc, "blocking", func() {
+		close(started)
+		<-release
+	})
+	<-started
+
+	proc.queueUpdate(proc.lc, "private", record("private 1"))
+	proc.queueUpdate(proc.lc, "common", record("common 1"))
+	proc.queueUpdate(proc.lc, "private", record("private 2"))
+	// The queue is now full, so the updates from the watchers with updates waiting replace their last update, while
+	// those from other watchers are dropped
+	for i := 3; i <= 5; i++ {
+		proc.queueUpdate(proc.lc, "private", record(fmt.Sprintf("private %d", i)))
+	}
+	proc.queueUpdate(proc.lc, "common", record("common 2"))
+	proc.queueUpdate(proc.lc, "custom 'AppCustom'", record("custom 1"))
+	close(release)
+
+	expected := []string{"private 1", "common 2", "private 5"}
+	require.Eventually(t, func() bool {
+		appliedMutex.Lock()
+		defer appliedMutex.Unlock()
+		return len(applied) >= len(expected)
+	}, time.Second, 10*time.Millisecond)
+
+	appliedMutex.Lock()
+	defer appliedMutex.Unlock()
+	assert.Equal(t, expected, applied)
+}
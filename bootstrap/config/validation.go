@@ -0,0 +1,155 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+// ValidationSeverity is the severity of an issue found by ValidateConfig
+type ValidationSeverity string
+
+const (
+	// ValidationSeverityError is the severity of the issues which would make the service fail to start
+	ValidationSeverityError ValidationSeverity = "error"
+	// ValidationSeverityWarning is the severity of the issues which the service starts with, but should be fixed
+	ValidationSeverityWarning ValidationSeverity = "warning"
+)
+
+// ValidationIssue is an issue found in the configuration by ValidateConfig
+type ValidationIssue struct {
+	// Severity is the severity of the issue
+	Severity ValidationSeverity `json:"severity"`
+	// Path is the dotted path of the setting with the issue, i.e. "Writable.LogLevel". Empty when the issue isn't
+	// with a single setting.
+	Path string `json:"path,omitempty"`
+	// Message describes the issue
+	Message string `json:"message"`
+}
+
+// ValidationReport is the machine-readable report of the issues found in the configuration by ValidateConfig, in the
+// order found
+type ValidationReport struct {
+	Issues []ValidationIssue `json:"issues"`
+}
+
+// HasErrors returns whether any of the issues have the error severity
+func (r ValidationReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == ValidationSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (r *ValidationReport) addIssue(severity ValidationSeverity, path string, format string, args ...any) {
+	r.Issues = append(r.Issues, ValidationIssue{Severity: severity, Path: path, Message: fmt.Sprintf(format, args...)})
+}
+
+// ValidateConfig is a dry-run of the loading of the configuration from file, as done by Process when the Configuration
+// Provider isn't used, which reports the issues found rather than failing on the first one. The common configuration
+// file, if specified, and the private configuration file are loaded into serviceConfig with the environment variable
+// overrides applied. The Configuration Provider is never used, so nothing is written to it. The following are reported:
+//   - the configuration files which can't be loaded or merged, i.e. a setting of the wrong type, as errors
+//   - the overrides whose values don't match the type of their setting as warnings, or errors when strict overrides
+//     are enabled
+//   - the overrides which don't match any setting as warnings, or errors when strict overrides are enabled
+//   - the deprecated settings present, as set by SetDeprecatedSettings, as warnings
+//   - the failure of the service's own validation, when serviceConfig implements interfaces.Validator, as an error
+//
+// The secret references aren't resolved. An error is only returned when the validation itself fails. Mapping the
+// report to an exit code is left to the caller, i.e. via ValidationReport.HasErrors.
+func (cp *Processor) ValidateConfig(serviceConfig interfaces.Configuration) (ValidationReport, error) {
+	lc := cp.operationLogger("ValidateConfig")
+	report := ValidationReport{}
+
+	if commonConfigLocation := environment.GetCommonConfigFileName(lc, cp.flags.CommonConfig()); commonConfigLocation != "" {
+		if err := cp.loadCommonConfigFromFile(lc, commonConfigLocation, serviceConfig, cp.serviceType); err != nil {
+			report.addIssue(ValidationSeverityError, "", "failed to load common configuration: %v", err)
+			return report, nil
+		}
+
+		if _, err := cp.envVars.OverrideConfiguration(serviceConfig); err != nil {
+			cp.addOverrideIssues(&report, "common", err)
+		}
+	}
+
+	configMap, err := cp.loadPrivateConfigFile(lc, cp.serviceType)
+	if err != nil {
+		report.addIssue(ValidationSeverityError, "", "failed to load private configuration: %v", err)
+		return report, nil
+	}
+
+	if _, err := cp.envVars.OverrideConfigMapValues(configMap); err != nil {
+		cp.addOverrideIssues(&report, "private", err)
+	}
+
+	if err := utils.MergeValues(serviceConfig, configMap); err != nil {
+		report.addIssue(ValidationSeverityError, "", "failed to merge private configuration: %v", err)
+		return report, nil
+	}
+
+	unmatched, err := cp.envVars.UnmatchedOverrides(serviceConfig)
+	if err != nil {
+		return report, fmt.Errorf("unable to check for unmatched environment overrides: %s", err.Error())
+	}
+	for _, name := range unmatched {
+		report.addIssue(cp.overrideSeverity(), "", "environment variable override %s doesn't match any configuration setting", name)
+	}
+
+	for _, path := range cp.presentDeprecatedSettings([]map[string]any{configMap}) {
+		report.addIssue(ValidationSeverityWarning, path, "setting is deprecated and will be removed: %s", cp.deprecatedSettings[path])
+	}
+
+	if validator, ok := serviceConfig.(interfaces.Validator); ok {
+		if err := validator.Validate(); err != nil {
+			report.addIssue(ValidationSeverityError, "", "configuration validation failed: %s", err.Error())
+		}
+	}
+
+	lc.Infof("Configuration validated with %d issue(s) found", len(report.Issues))
+	return report, nil
+}
+
+// addOverrideIssues adds an issue for each override rejected due to its value not matching the type of its setting,
+// or a single error issue when the overrides of the configuration failed for another reason
+func (cp *Processor) addOverrideIssues(report *ValidationReport, configName string, err error) {
+	var rejectedErr *environment.OverrideRejectedError
+	if !errors.As(err, &rejectedErr) {
+		report.addIssue(ValidationSeverityError, "", "failed to override %s configuration: %v", configName, err)
+		return
+	}
+
+	for _, rejection := range rejectedErr.Rejections {
+		report.addIssue(cp.overrideSeverity(), strings.ReplaceAll(rejection.Path, utils.PathSep, "."),
+			"environment variable override %s='%s' is not a valid %s", rejection.Name, rejection.Value, rejection.TargetType)
+	}
+}
+
+// overrideSeverity returns the severity of the issues with the environment variable overrides
+func (cp *Processor) overrideSeverity() ValidationSeverity {
+	if cp.strictOverrides() {
+		return ValidationSeverityError
+	}
+	return ValidationSeverityWarning
+}
@@ -0,0 +1,162 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package config
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/flags"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestValidateConfig(t *testing.T) {
+	validContents := "Writable:\n  LogLevel: INFO\nTrigger:\n  Type: edgex-messagebus\n"
+
+	tests := []struct {
+		Name           string
+		FileContents   string
+		EnvVars        map[string]string
+		Strict         bool
+		ExpectedIssues []ValidationIssue
+		ExpectedErrors bool
+	}{
+		{
+			Name:         "Valid - no issues",
+			FileContents: validContents,
+			EnvVars:      map[string]string{"WRITABLE_LOGLEVEL": "DEBUG"},
+		},
+		{
+			Name:         "Mixed errors and warnings",
+			FileContents: "Writable:\n  LogLevel: INFO\nTrigger:\n  SubscribeTopic: events/#\n",
+			EnvVars:      map[string]string{"WRITABLE_LOGLEVL": "DEBUG"},
+			ExpectedIssues: []ValidationIssue{
+				{Severity: ValidationSeverityWarning, Message: "environment variable override WRITABLE_LOGLEVL doesn't match any configuration setting"},
+				{Severity: ValidationSeverityWarning, Path: "Trigger.SubscribeTopic", Message: "setting is deprecated and will be removed: use Trigger.SubscribeTopics"},
+				{Severity: ValidationSeverityError, Message: "configuration validation failed: Trigger.Type is required"},
+			},
+			ExpectedErrors: true,
+		},
+		{
+			Name:         "Strict unmatched override",
+			FileContents: validContents,
+			EnvVars:      map[string]string{"WRITABLE_LOGLEVL": "DEBUG"},
+			Strict:       true,
+			ExpectedIssues: []ValidationIssue{
+				{Severity: ValidationSeverityError, Message: "environment variable override WRITABLE_LOGLEVL doesn't match any configuration setting"},
+			},
+			ExpectedErrors: true,
+		},
+		{
+			Name:         "Rejected override type",
+			FileContents: "Writable:\n  LogLevel: INFO\n  StoreAndForward:\n    MaxRetryCount: 3\nTrigger:\n  Type: edgex-messagebus\n",
+			EnvVars:      map[string]string{"WRITABLE_STOREANDFORWARD_MAXRETRYCOUNT": "many"},
+			ExpectedIssues: []ValidationIssue{
+				{Severity: ValidationSeverityWarning, Path: "Writable.StoreAndForward.MaxRetryCount",
+					Message: "environment variable override WRITABLE_STOREANDFORWARD_MAXRETRYCOUNT='many' is not a valid int"},
+			},
+		},
+		{
+			Name:         "Strict rejected override type",
+			FileContents: "Writable:\n  LogLevel: INFO\n  StoreAndForward:\n    MaxRetryCount: 3\nTrigger:\n  Type: edgex-messagebus\n",
+			EnvVars:      map[string]string{"WRITABLE_STOREANDFORWARD_MAXRETRYCOUNT": "many"},
+			Strict:       true,
+			ExpectedIssues: []ValidationIssue{
+				{Severity: ValidationSeverityError, Path: "Writable.StoreAndForward.MaxRetryCount",
+					Message: "environment variable override WRITABLE_STOREANDFORWARD_MAXRETRYCOUNT='many' is not a valid int"},
+			},
+			ExpectedErrors: true,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			proc := newValidateConfigProcessor(t, tc.FileContents, tc.Strict)
+			serviceConfig := &ValidatingConfigurationMockStruct{}
+			report, err := proc.ValidateConfig(serviceConfig)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedIssues, report.Issues)
+			assert.Equal(t, tc.ExpectedErrors, report.HasErrors())
+		})
+	}
+}
+
+func TestValidateConfigTypeErrors(t *testing.T) {
+	tests := []struct {
+		Name            string
+		FileContents    string
+		EnvVars         map[string]string
+		ExpectedMessage string
+	}{
+		{"Invalid - file type", "Writable:\n  StoreAndForward:\n    MaxRetryCount: many\n", nil,
+			"failed to merge private configuration"},
+		{"Invalid - file syntax", "Writable: [\n", nil, "failed to load private configuration"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			proc := newValidateConfigProcessor(t, tc.FileContents, false)
+			report, err := proc.ValidateConfig(&ConfigurationMockStruct{})
+			require.NoError(t, err)
+
+			require.NotEmpty(t, report.Issues)
+			assert.True(t, report.HasErrors())
+			assert.Equal(t, ValidationSeverityError, report.Issues[0].Severity)
+			assert.Contains(t, report.Issues[0].Message, tc.ExpectedMessage)
+		})
+	}
+}
+
+// newValidateConfigProcessor returns a Processor for a configuration directory with the configuration file contents
+func newValidateConfigProcessor(t *testing.T, fileContents string, strict bool) *Processor {
+	configDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(configDir, "configuration.yaml"), []byte(fileContents), 0644))
+
+	args := []string{"-cd", configDir}
+	if strict {
+		args = append(args, "--strictOverrides")
+	}
+	f := flags.New()
+	f.Parse(args)
+	mockLogger := logger.NewMockClient()
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return mockLogger },
+	})
+
+	proc := NewProcessor(f, environment.NewVariables(mockLogger), startup.NewTimer(5, 1), context.Background(), &sync.WaitGroup{}, nil, dic)
+	proc.SetServiceType(config.ServiceTypeOther)
+	proc.SetDeprecatedSettings(map[string]string{"Trigger.SubscribeTopic": "use Trigger.SubscribeTopics"})
+	return proc
+}
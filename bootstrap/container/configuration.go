@@ -46,3 +46,34 @@ func ConfigClientFrom(get di.Get) configuration.Client {
 
 	return client
 }
+
+// ConfigClientFactory creates the configuration.Client rooted at the basePath in the Configuration Provider, i.e.
+// "edgex/v3/core-common-config-bootstrapper/all-services" for the common configuration or "edgex/v3/core-data" for the
+// service's private configuration.
+type ConfigClientFactory func(basePath string) (configuration.Client, error)
+
+// ConfigClientFactoryName contains the name of the injected ConfigClientFactory in the DIC.
+var ConfigClientFactoryName = di.TypeInstanceToName((*ConfigClientFactory)(nil))
+
+// InjectConfigClientFactory registers the ConfigClientFactory in the DIC, so the config.Processor uses it, i.e. to
+// create test doubles, rather than creating its own clients for each of the common, app or device common, and private
+// configuration trees, which are rooted at different base paths, and for each of the custom configuration watchers.
+// The private configuration's client is registered in the DIC under ConfigClientInterfaceName as usual.
+func InjectConfigClientFactory(dic *di.Container, factory ConfigClientFactory) {
+	dic.Update(di.ServiceConstructorMap{
+		ConfigClientFactoryName: func(get di.Get) interface{} {
+			return factory
+		},
+	})
+}
+
+// ConfigClientFactoryFrom helper function queries the DIC and returns the injected ConfigClientFactory, or nil if none
+// was injected.
+func ConfigClientFactoryFrom(get di.Get) ConfigClientFactory {
+	factory, ok := get(ConfigClientFactoryName).(ConfigClientFactory)
+	if !ok {
+		return nil
+	}
+
+	return factory
+}
@@ -0,0 +1,34 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package container
+
+import (
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// HealthCheckRegistryName contains the name of the interfaces.HealthCheckRegistry implementation in the DIC.
+var HealthCheckRegistryName = di.TypeInstanceToName((*interfaces.HealthCheckRegistry)(nil))
+
+// HealthCheckRegistryFrom helper function queries the DIC and returns the interfaces.HealthCheckRegistry
+// implementation.
+func HealthCheckRegistryFrom(get di.Get) interfaces.HealthCheckRegistry {
+	registry, ok := get(HealthCheckRegistryName).(interfaces.HealthCheckRegistry)
+	if !ok {
+		return nil
+	}
+
+	return registry
+}
@@ -20,7 +20,8 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 )
 
-// LoggingClientInterfaceName contains the name of the logger.LoggingClient implementation in the DIC.
+// LoggingClientInterfaceName contains the name of the logger.LoggingClient implementation in the DIC. Any implementation
+// may be used, i.e. one created by utils.NewFuncLogger to route the logging to the host application's logging.
 var LoggingClientInterfaceName = di.TypeInstanceToName((*logger.LoggingClient)(nil))
 
 // LoggingClientFrom helper function queries the DIC and returns the logger.loggingClient implementation.
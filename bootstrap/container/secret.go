@@ -47,3 +47,17 @@ func SecretProviderExtFrom(get di.Get) interfaces.SecretProviderExt {
 
 	return provider
 }
+
+// SecretNameResolverName contains the name of the interfaces.SecretNameResolver implementation in the DIC.
+var SecretNameResolverName = di.TypeInstanceToName((*interfaces.SecretNameResolver)(nil))
+
+// SecretNameResolverFrom helper function queries the DIC and returns the interfaces.SecretNameResolver
+// implementation, which is nil when the default resolver is to be used.
+func SecretNameResolverFrom(get di.Get) interfaces.SecretNameResolver {
+	resolver, ok := get(SecretNameResolverName).(interfaces.SecretNameResolver)
+	if !ok {
+		return nil
+	}
+
+	return resolver
+}
@@ -0,0 +1,124 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// OverrideRejection describes an environment variable override which was rejected since its value can't be converted
+// to the type of the setting it overrides, i.e. SERVICE_PORT=abc for an int setting.
+type OverrideRejection struct {
+	// Name is the name of the environment variable
+	Name string
+	// Path is the path of the overridden setting, i.e. "Service/Port"
+	Path string
+	// TargetType is the type of the overridden setting, i.e. "int" or "time.Duration"
+	TargetType string
+	// Value is the environment variable's value
+	Value string
+}
+
+func (r OverrideRejection) String() string {
+	return fmt.Sprintf("%s='%s' is not a valid %s for %s", r.Name, r.Value, r.TargetType, r.Path)
+}
+
+// OverrideRejectedError is the error returned by OverrideConfiguration and OverrideConfigMapValues when overrides are
+// rejected due to their values not matching the types of their settings. The other overrides are still applied, so the
+// caller may choose to only report the rejections rather than fail.
+type OverrideRejectedError struct {
+	// Rejections are the rejected overrides, sorted by name
+	Rejections []OverrideRejection
+}
+
+func (e *OverrideRejectedError) Error() string {
+	rejections := make([]string, len(e.Rejections))
+	for index, rejection := range e.Rejections {
+		rejections[index] = rejection.String()
+	}
+	return fmt.Sprintf("environment variable override(s) rejected due to type mismatch: %s", strings.Join(rejections, "; "))
+}
+
+// newOverrideRejectedError returns the error for the rejections, or nil when there are none
+func newOverrideRejectedError(rejections []OverrideRejection) error {
+	if len(rejections) == 0 {
+		return nil
+	}
+
+	sort.Slice(rejections, func(i, j int) bool {
+		return rejections[i].Name < rejections[j].Name
+	})
+	return &OverrideRejectedError{Rejections: rejections}
+}
+
+// buildSettingTypes returns the Go types of the settings of the struct type keyed by their path, using the same keys
+// as the configuration map converted from the struct, so the types lost by the conversion, i.e. time.Duration which is
+// converted to a number, are known. The settings within maps and slices aren't included.
+func buildSettingTypes(structType reflect.Type) map[string]reflect.Type {
+	settingTypes := make(map[string]reflect.Type)
+	collectSettingTypes(structType, "", settingTypes)
+	return settingTypes
+}
+
+func collectSettingTypes(structType reflect.Type, path string, settingTypes map[string]reflect.Type) {
+	for structType.Kind() == reflect.Pointer {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return
+	}
+
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		// The fields of embedded structs are promoted to the embedding struct, the same as by encoding/json
+		if field.Anonymous && len(name) == 0 && fieldType.Kind() == reflect.Struct {
+			collectSettingTypes(fieldType, path, settingTypes)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+		settingPath := name
+		if len(path) > 0 {
+			settingPath = path + configPathSeparator + name
+		}
+
+		if fieldType.Kind() == reflect.Struct {
+			collectSettingTypes(fieldType, settingPath, settingTypes)
+			continue
+		}
+		settingTypes[settingPath] = fieldType
+	}
+}
@@ -0,0 +1,69 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package environment
+
+import (
+	"fmt"
+	"strings"
+)
+
+// SecretReferencePrefix is the prefix of the override values which reference a secret in the secret store rather than
+// holding the value itself, i.e. "secretref:redisdb/password" for the "password" key of the "redisdb" secret, so
+// sensitive values aren't visible in the process environment.
+const SecretReferencePrefix = "secretref:"
+
+// SecretGetter is the subset of the secret provider used to resolve the override values which reference a secret.
+// interfaces.SecretProvider can't be used here as it would be an import cycle.
+type SecretGetter interface {
+	GetSecret(secretName string, keys ...string) (map[string]string, error)
+}
+
+// SetSecretProvider sets the secret provider used to resolve the override values of the form
+// "secretref:<secretName>/<key>" to the value of the key in the secret before they are applied to the configuration.
+// Overrides referencing a secret fail when no secret provider is set, which is the default.
+func (e *Variables) SetSecretProvider(secretProvider SecretGetter) {
+	e.secretProvider = secretProvider
+}
+
+// resolveSecretReference returns the value of the referenced secret when the value is a secret reference, otherwise
+// the value unchanged
+func (e *Variables) resolveSecretReference(value string) (string, error) {
+	if !strings.HasPrefix(value, SecretReferencePrefix) {
+		return value, nil
+	}
+
+	reference := strings.TrimPrefix(value, SecretReferencePrefix)
+	separator := strings.LastIndex(reference, "/")
+	if separator <= 0 || separator == len(reference)-1 {
+		return "", fmt.Errorf("secret reference must be in the form %s<secretName>/<key>", SecretReferencePrefix)
+	}
+
+	if e.secretProvider == nil {
+		return "", fmt.Errorf("secret reference can't be resolved without a secret provider")
+	}
+
+	secretName, key := reference[:separator], reference[separator+1:]
+	secrets, err := e.secretProvider.GetSecret(secretName, key)
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret '%s': %v", secretName, err)
+	}
+
+	resolved, found := secrets[key]
+	if !found {
+		return "", fmt.Errorf("secret '%s' has no key '%s'", secretName, key)
+	}
+
+	return resolved, nil
+}
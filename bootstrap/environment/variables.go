@@ -17,14 +17,19 @@ package environment
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"net/url"
 	"os"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
 
@@ -44,6 +49,7 @@ const (
 	envKeyConfigDir       = "EDGEX_CONFIG_DIR"
 	envKeyProfile         = "EDGEX_PROFILE"
 	envKeyConfigFile      = "EDGEX_CONFIG_FILE"
+	envKeyEnvironment     = "EDGEX_ENV"
 
 	noConfigProviderValue = "none"
 
@@ -57,12 +63,23 @@ const (
 	//			Matches: Writable.InsecureSecrets.credentials001.Secrets.password
 	//	 Does Not Match: Writable.InsecureSecrets.credentials001.Path
 	insecureSecretsRegexStr = "^Writable\\.InsecureSecrets\\.[^.]+\\.Secrets\\..+$" //#nosec G101 -- This is a false positive
+	// insecureSecretDataRegexStr is a regex to look for keys that are under the SecretData sub-key of values within the
+	// Writable.InsecureSecrets topology.
+	insecureSecretDataRegexStr = "^Writable\\.InsecureSecrets\\.[^.]+\\.SecretData\\..+$" //#nosec G101 -- This is a false positive
 	// redactedStr is the value to print for redacted variable values
 	redactedStr = "<redacted>"
 )
 
 var (
-	insecureSecretsRegex = regexp.MustCompile(insecureSecretsRegexStr)
+	insecureSecretsRegex    = regexp.MustCompile(insecureSecretsRegexStr)
+	insecureSecretDataRegex = regexp.MustCompile(insecureSecretDataRegexStr)
+
+	// serviceTypeQualifiers are the prefixes of the names of the overrides which only apply to a type of service,
+	// i.e. EDGEX_DEVICE_WRITABLE_LOGLEVEL, keyed by the service type
+	serviceTypeQualifiers = map[string]string{
+		config.ServiceTypeApp:    "EDGEX_APP_",
+		config.ServiceTypeDevice: "EDGEX_DEVICE_",
+	}
 )
 
 // Variables is a receiver that holds Variables and encapsulates toml.Tree-based configuration field
@@ -76,6 +93,13 @@ var (
 type Variables struct {
 	variables map[string]string
 	lc        logger.LoggingClient
+	// overridePrefixes are the accepted prefixes of the override environment variable names, in increasing order of
+	// precedence. Overrides without a prefix are used when empty.
+	overridePrefixes []string
+	// serviceType is the type of the running service, which determines the qualified overrides that apply
+	serviceType string
+	// secretProvider resolves the override values which reference a secret. Such overrides fail when nil.
+	secretProvider SecretGetter
 }
 
 // NewVariables constructor reads/stores os.Environ() for use by Variables receiver methods.
@@ -112,6 +136,32 @@ func (e *Variables) UseRegistry() (bool, bool) {
 	return value == "true", true
 }
 
+// SetOverridePrefixes sets the accepted prefixes of the names of the environment variables which override the
+// configuration, i.e. "APP_" and "EDGEX_" for APP_WRITABLE_LOGLEVEL and EDGEX_WRITABLE_LOGLEVEL. The prefixes are
+// in increasing order of precedence, so when overrides with different prefixes match the same setting the one with the
+// last prefix wins. All but the last prefix are deprecated, so a warning is logged when an override using one of them
+// is applied. An empty prefix accepts the names without a prefix, which is the default.
+func (e *Variables) SetOverridePrefixes(prefixes ...string) {
+	e.overridePrefixes = prefixes
+}
+
+// SetServiceType sets the type of the running service, i.e. config.ServiceTypeDevice. Overrides whose names are
+// qualified with a service type, i.e. EDGEX_DEVICE_WRITABLE_LOGLEVEL or EDGEX_APP_WRITABLE_LOGLEVEL, are only applied
+// when it matches the service's type and win over the unqualified overrides, i.e. WRITABLE_LOGLEVEL, of the same
+// settings. The qualifier replaces any override prefix. Qualified overrides aren't applied when the type isn't set.
+func (e *Variables) SetServiceType(serviceType string) {
+	e.serviceType = serviceType
+}
+
+// getOverridePrefixes returns the accepted prefixes of the override names, in increasing order of precedence
+func (e *Variables) getOverridePrefixes() []string {
+	if len(e.overridePrefixes) == 0 {
+		return []string{""}
+	}
+
+	return e.overridePrefixes
+}
+
 // OverrideConfiguration method replaces values in the configuration for matching Variables variable keys.
 // serviceConfig must be pointer to the service configuration.
 func (e *Variables) OverrideConfiguration(serviceConfig any) (int, error) {
@@ -127,49 +177,283 @@ func (e *Variables) OverrideConfiguration(serviceConfig any) (int, error) {
 		return 0, err
 	}
 
-	overrideCount, err := e.OverrideConfigMapValues(configMap)
+	schemaMap, err := buildSchemaMap(serviceConfig)
 	if err != nil {
 		return 0, err
 	}
 
+	settingTypes := buildSettingTypes(reflect.TypeOf(serviceConfig))
+	overrideCount, err := e.overrideConfigMapValues(configMap, schemaMap, settingTypes)
+	var rejectedErr *OverrideRejectedError
+	if err != nil && !errors.As(err, &rejectedErr) {
+		return 0, err
+	}
+
 	// Put the configuration back into the services configuration struct with the overridden values
-	err = utils.ConvertFromMap(configMap, serviceConfig)
-	if err != nil {
+	if err := utils.ConvertFromMap(configMap, serviceConfig); err != nil {
 		return 0, fmt.Errorf("failed to convert map of configuratuion into service's configuration struct: %v", err)
 	}
 
-	return overrideCount, nil
+	return overrideCount, err
 }
 
+// OverrideConfigMapValues applies the overrides to the settings of the configMap. When overrides are rejected, due to
+// their values not matching the types of their settings, an OverrideRejectedError is returned along with the number of
+// other overrides, which are still applied.
 func (e *Variables) OverrideConfigMapValues(configMap map[string]any) (int, error) {
+	return e.overrideConfigMapValues(configMap, configMap, nil)
+}
+
+// overrideConfigMapValues applies the overrides to the configMap for the settings in the schemaMap, which has the
+// same structure as the configMap plus any sections that are absent from the configMap, i.e. those for nil pointers
+// to structs. The sections for overridden settings that are absent from the configMap are added to it. settingTypes
+// are the Go types of the settings, when known, which the values are converted to. The overrides whose values can't
+// be converted are skipped and returned in an OverrideRejectedError.
+func (e *Variables) overrideConfigMapValues(configMap map[string]any, schemaMap map[string]any, settingTypes map[string]reflect.Type) (int, error) {
 	var overrideCount int
+	var rejections []OverrideRejection
 
 	// The toml.Tree API keys() only return to top level keys, rather that paths.
 	// It is also missing a GetPaths so have to spin our own
-	paths := e.buildPaths(configMap)
+	paths := e.buildPaths(schemaMap)
 	// Now that we have all the paths in the config tree, we need to create map of corresponding override names that
 	// could match override environment variable names.
 	overrideNames := e.buildOverrideNames(paths)
 
-	for envVar, envValue := range e.variables {
-		path, found := overrideNames[envVar]
-		if !found {
-			continue
+	// Overrides are applied a prefix at a time so those with later prefixes replace those with earlier prefixes
+	prefixes := e.getOverridePrefixes()
+	currentPrefix := prefixes[len(prefixes)-1]
+	for index, prefix := range prefixes {
+		deprecated := index < len(prefixes)-1
+		for envVar, envValue := range e.variables {
+			if !strings.HasPrefix(envVar, prefix) {
+				continue
+			}
+
+			name := strings.TrimPrefix(envVar, prefix)
+			path, found := overrideNames[name]
+			if !found {
+				continue
+			}
+
+			rejection, err := e.applyOverride(envVar, envValue, path, configMap, schemaMap, settingTypes[path])
+			if err != nil {
+				return 0, err
+			}
+			if rejection != nil {
+				rejections = append(rejections, *rejection)
+				continue
+			}
+			overrideCount++
+			if deprecated {
+				e.lc.Warnf("Environment variable %s uses deprecated prefix '%s'. Use %s%s instead", envVar, prefix, currentPrefix, name)
+			}
+		}
+	}
+
+	// Overrides qualified with the service's type are applied last so they win over the unqualified overrides
+	if qualifier, found := serviceTypeQualifiers[e.serviceType]; found {
+		for envVar, envValue := range e.variables {
+			if !strings.HasPrefix(envVar, qualifier) {
+				continue
+			}
+
+			path, found := overrideNames[strings.TrimPrefix(envVar, qualifier)]
+			if !found {
+				continue
+			}
+
+			rejection, err := e.applyOverride(envVar, envValue, path, configMap, schemaMap, settingTypes[path])
+			if err != nil {
+				return 0, err
+			}
+			if rejection != nil {
+				rejections = append(rejections, *rejection)
+				continue
+			}
+			overrideCount++
 		}
+	}
 
-		oldValue := getConfigMapValue(path, configMap)
+	return overrideCount, newOverrideRejectedError(rejections)
+}
 
-		newValue, err := e.convertToType(oldValue, envValue)
-		if err != nil {
-			return 0, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+// applyOverride sets the setting at the path in the configMap to the environment variable's value converted to the
+// setting's type. settingType is the Go type of the setting, or nil when unknown. The override is rejected, rather than
+// failing, when its value can't be converted to the setting's type.
+func (e *Variables) applyOverride(
+	envVar string,
+	envValue string,
+	path string,
+	configMap map[string]any,
+	schemaMap map[string]any,
+	settingType reflect.Type) (*OverrideRejection, error) {
+	oldValue := getConfigMapValue(path, configMap)
+	if oldValue == nil {
+		// The setting's type is only known from the schema when its section is absent from the configuration
+		oldValue = getConfigMapValue(path, schemaMap)
+	}
+
+	value, err := e.resolveSecretReference(envValue)
+	if err != nil {
+		return nil, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+	}
+
+	newValue, err := e.convertToSettingType(settingType, oldValue, value)
+	var numErr *strconv.NumError
+	if errors.As(err, &numErr) {
+		targetType := fmt.Sprintf("%T", oldValue)
+		if settingType != nil {
+			targetType = settingType.String()
+		}
+		// The value from the environment is reported, since the resolved value of a secret reference is sensitive
+		return &OverrideRejection{Name: envVar, Path: path, TargetType: targetType, Value: envValue}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("environment value override failed for %s=%s: %s", envVar, envValue, err.Error())
+	}
+
+	setConfigMapValue(path, newValue, configMap)
+	logEnvironmentOverride(e.lc, path, envVar, envValue)
+	return nil, nil
+}
+
+// convertToSettingType converts the value to the Go type of the setting, when known, since the type of the old value
+// in a configuration map converted from a struct may differ, i.e. float64 for int and time.Duration settings.
+// Otherwise, the value is converted to the type of the old value.
+func (e *Variables) convertToSettingType(settingType reflect.Type, oldValue any, value string) (any, error) {
+	if settingType == nil {
+		return e.convertToType(oldValue, value)
+	}
+
+	if settingType == durationType {
+		if duration, err := time.ParseDuration(value); err == nil {
+			return int64(duration), nil
+		}
+		// Durations may also be overridden by their number of nanoseconds, as held in the configuration map
+		return strconv.ParseInt(value, 10, 64)
+	}
+
+	switch settingType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(value, 10, settingType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(value, 10, settingType.Bits())
+	default:
+		return e.convertToType(oldValue, value)
+	}
+}
+
+// UnmatchedOverrides returns the sorted names of the environment variables which look like overrides of the
+// configuration, but don't match any of its settings, i.e. due to a typo. serviceConfig must be pointer to the
+// service configuration.
+func (e *Variables) UnmatchedOverrides(serviceConfig any) ([]string, error) {
+	schemaMap, err := buildSchemaMap(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.UnmatchedOverrideNames(schemaMap), nil
+}
+
+// UnmatchedOverrideNames returns the sorted names of the environment variables which look like overrides of the
+// configuration map, but don't match any of its settings. An environment variable looks like an override when the
+// start of its name, following any accepted prefix, matches one of the configuration's top level sections, i.e.
+// WRITABLE_LOGLEVL. Overrides qualified with any service type are checked the same way, i.e. EDGEX_APP_WRITABLE_LOGLEVL.
+func (e *Variables) UnmatchedOverrideNames(configMap map[string]any) []string {
+	overrideNames := e.buildOverrideNames(e.buildPaths(configMap))
+
+	sectionPrefixes := make([]string, 0, len(configMap))
+	for key, item := range configMap {
+		if _, isMap := item.(map[string]any); isMap {
+			sectionPrefixes = append(sectionPrefixes, e.getOverrideNameFor(key)+envNameSeparator)
+		}
+	}
+
+	// The qualified overrides are matched regardless of the service's type, since the same environment is typically
+	// used for all types of services
+	overridePrefixes := append([]string{}, e.getOverridePrefixes()...)
+	qualifiedNames := make(map[string]bool)
+	for _, qualifier := range serviceTypeQualifiers {
+		overridePrefixes = append(overridePrefixes, qualifier)
+		for envVar := range e.variables {
+			if _, found := overrideNames[strings.TrimPrefix(envVar, qualifier)]; found && strings.HasPrefix(envVar, qualifier) {
+				qualifiedNames[envVar] = true
+			}
+		}
+	}
+
+	unmatchedNames := make(map[string]bool)
+	for _, overridePrefix := range overridePrefixes {
+		for envVar := range e.variables {
+			if !strings.HasPrefix(envVar, overridePrefix) || qualifiedNames[envVar] {
+				continue
+			}
+
+			name := strings.TrimPrefix(envVar, overridePrefix)
+			if _, found := overrideNames[name]; found {
+				continue
+			}
+
+			for _, prefix := range sectionPrefixes {
+				if strings.HasPrefix(name, prefix) {
+					unmatchedNames[envVar] = true
+					break
+				}
+			}
 		}
+	}
 
-		setConfigMapValue(path, newValue, configMap)
-		overrideCount++
-		logEnvironmentOverride(e.lc, path, envVar, envValue)
+	var unmatched []string
+	for envVar := range unmatchedNames {
+		unmatched = append(unmatched, envVar)
 	}
 
-	return overrideCount, nil
+	sort.Strings(unmatched)
+	return unmatched
+}
+
+// OverriddenPaths returns the sorted paths, using "/" as the separator, of the configuration's settings which are
+// overridden by environment variables. serviceConfig must be pointer to the service configuration.
+func (e *Variables) OverriddenPaths(serviceConfig any) ([]string, error) {
+	schemaMap, err := buildSchemaMap(serviceConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return e.OverriddenMapPaths(schemaMap), nil
+}
+
+// OverriddenMapPaths returns the sorted paths, using "/" as the separator, of the configuration map's settings which
+// are overridden by environment variables, including those overridden by overrides qualified with the service's type.
+func (e *Variables) OverriddenMapPaths(configMap map[string]any) []string {
+	overrideNames := e.buildOverrideNames(e.buildPaths(configMap))
+
+	overridePrefixes := e.getOverridePrefixes()
+	if qualifier, found := serviceTypeQualifiers[e.serviceType]; found {
+		overridePrefixes = append(append([]string{}, overridePrefixes...), qualifier)
+	}
+
+	overridden := make(map[string]bool)
+	for _, overridePrefix := range overridePrefixes {
+		for envVar := range e.variables {
+			if !strings.HasPrefix(envVar, overridePrefix) {
+				continue
+			}
+
+			if path, found := overrideNames[strings.TrimPrefix(envVar, overridePrefix)]; found {
+				overridden[path] = true
+			}
+		}
+	}
+
+	paths := make([]string, 0, len(overridden))
+	for path := range overridden {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+	return paths
 }
 
 func getConfigMapValue(path string, configMap map[string]any) any {
@@ -215,12 +499,18 @@ func setConfigMapValue(path string, value any, configMap map[string]any) {
 
 	currentMap := configMap
 
-	for _, key := range keys {
+	for index, key := range keys {
 		item := currentMap[key]
 		itemMap, isMap := item.(map[string]any)
 		if !isMap {
-			currentMap[key] = value
-			return
+			if item != nil || index == len(keys)-1 {
+				currentMap[key] = value
+				return
+			}
+
+			// The section is absent, i.e. from a nil pointer, so is added to hold the setting
+			itemMap = make(map[string]any)
+			currentMap[key] = itemMap
 		}
 
 		currentMap = itemMap
@@ -228,6 +518,55 @@ func setConfigMapValue(path string, value any, configMap map[string]any) {
 	}
 }
 
+// buildSchemaMap returns the map of the serviceConfig, which must be a pointer, as if its nil pointers to structs,
+// including embedded structs, were allocated. This allows overrides of the settings beneath them to be matched, so the
+// pointers are only allocated when one of their settings is overridden. The serviceConfig is not modified.
+func buildSchemaMap(serviceConfig any) (map[string]any, error) {
+	value := reflect.ValueOf(serviceConfig)
+	if value.Kind() != reflect.Pointer || value.IsNil() {
+		return nil, fmt.Errorf("service configuration must be a non-nil pointer, not %T", serviceConfig)
+	}
+
+	schema := reflect.New(value.Elem().Type())
+	schema.Elem().Set(value.Elem())
+	allocateNilStructPointers(schema.Elem())
+
+	schemaMap := make(map[string]any)
+	if err := utils.ConvertToMap(schema.Interface(), &schemaMap); err != nil {
+		return nil, err
+	}
+
+	return schemaMap, nil
+}
+
+// allocateNilStructPointers allocates the struct's nil pointers to structs with zero values. Non-nil pointers are
+// replaced with pointers to copies, so the structs they point to, which are shared with the original configuration,
+// aren't modified.
+func allocateNilStructPointers(value reflect.Value) {
+	if value.Kind() != reflect.Struct {
+		return
+	}
+
+	for i := 0; i < value.NumField(); i++ {
+		field := value.Field(i)
+		if !field.CanSet() {
+			continue
+		}
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			allocateNilStructPointers(field)
+		case field.Kind() == reflect.Pointer && field.Type().Elem().Kind() == reflect.Struct:
+			allocated := reflect.New(field.Type().Elem())
+			if !field.IsNil() {
+				allocated.Elem().Set(field.Elem())
+			}
+			allocateNilStructPointers(allocated.Elem())
+			field.Set(allocated)
+		}
+	}
+}
+
 // buildPaths create the path strings for all settings in the Config key map
 func (e *Variables) buildPaths(keyMap map[string]any) []string {
 	var paths []string
@@ -259,6 +598,12 @@ func (e *Variables) buildOverrideNames(paths []string) map[string]string {
 }
 
 func (_ *Variables) getOverrideNameFor(path string) string {
+	return OverrideNameFor(path)
+}
+
+// OverrideNameFor returns the name of the environment variable which overrides the configuration setting at the
+// path, using "/" as the separator, i.e. WRITABLE_LOGLEVEL for Writable/LogLevel.
+func OverrideNameFor(path string) string {
 	// "/" & "-" are the only special character allowed in path not allowed in environment variable Name
 	override := strings.ReplaceAll(path, configPathSeparator, envNameSeparator)
 	override = strings.ReplaceAll(override, configNameSeparator, envNameSeparator)
@@ -266,6 +611,70 @@ func (_ *Variables) getOverrideNameFor(path string) string {
 	return override
 }
 
+// RenderOverrides renders the configuration as the environment variable assignments, in the NAME=value form used by
+// os.Environ(), that OverrideConfiguration would consume to reconstruct it. The names are sorted and values of
+// Writable.InsecureSecrets secrets are redacted. cfg may be the configuration struct or a pointer to it.
+func RenderOverrides(cfg any) ([]string, error) {
+	contents, err := json.Marshal(reflect.Indirect(reflect.ValueOf(cfg)).Interface())
+	if err != nil {
+		return nil, err
+	}
+
+	configMap := make(map[string]any)
+	if err = json.Unmarshal(contents, &configMap); err != nil {
+		return nil, err
+	}
+
+	e := &Variables{}
+	var overrides []string
+	for _, path := range e.buildPaths(configMap) {
+		value := getConfigMapValue(path, configMap)
+		if value == nil {
+			// Nil values can't be overridden, so there is nothing to render
+			continue
+		}
+
+		valueStr, err := renderOverrideValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("unable to render override for %s: %v", path, err)
+		}
+
+		dottedPath := strings.ReplaceAll(path, configPathSeparator, ".")
+		if insecureSecretsRegex.MatchString(dottedPath) || insecureSecretDataRegex.MatchString(dottedPath) {
+			valueStr = redactedStr
+		}
+
+		overrides = append(overrides, fmt.Sprintf("%s=%s", e.getOverrideNameFor(path), valueStr))
+	}
+
+	sort.Strings(overrides)
+	return overrides, nil
+}
+
+// renderOverrideValue renders the configuration value as the string convertToType would parse back into it
+func renderOverrideValue(value any) (string, error) {
+	switch v := value.(type) {
+	case []any:
+		items := make([]string, len(v))
+		for i, item := range v {
+			itemStr, err := renderOverrideValue(item)
+			if err != nil {
+				return "", err
+			}
+			items[i] = itemStr
+		}
+		return strings.Join(items, ","), nil
+	case map[string]any:
+		return "", fmt.Errorf("configuration type of '%s' is not supported for environment variable override",
+			reflect.TypeOf(value).String())
+	case float64:
+		// JSON numbers are always float64, so format without exponent or trailing zeros so integers render as integers
+		return strconv.FormatFloat(v, 'f', -1, 64), nil
+	default:
+		return fmt.Sprint(v), nil
+	}
+}
+
 // OverrideConfigProviderInfo overrides the Configuration Provider ServiceConfig values
 // from an Variables variable value (if it exists).
 func (e *Variables) OverrideConfigProviderInfo(configProviderInfo types.ServiceConfig) (types.ServiceConfig, error) {
@@ -278,6 +687,10 @@ func (e *Variables) OverrideConfigProviderInfo(configProviderInfo types.ServiceC
 			return types.ServiceConfig{}, nil
 		}
 
+		if err := ValidateProviderUrl(url); err != nil {
+			return types.ServiceConfig{}, fmt.Errorf("%s environment variable: %w", envKeyConfigUrl, err)
+		}
+
 		if err := configProviderInfo.PopulateFromUrl(url); err != nil {
 			return types.ServiceConfig{}, err
 		}
@@ -286,6 +699,44 @@ func (e *Variables) OverrideConfigProviderInfo(configProviderInfo types.ServiceC
 	return configProviderInfo, nil
 }
 
+// ValidateProviderUrl validates the Configuration Provider URL, i.e. consul.http://localhost:8500, before it is used,
+// so a malformed URL is reported with the component at fault rather than as a failure to connect to the provider.
+func ValidateProviderUrl(providerUrl string) error {
+	const expectedFormat = "expected {type}.{protocol}://{host}:{port}, i.e. consul.http://localhost:8500"
+
+	parsedUrl, err := url.Parse(providerUrl)
+	if err != nil {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': %w (%s)", providerUrl, err, expectedFormat)
+	}
+
+	// Without a scheme, "localhost:8500" is parsed as the scheme "localhost" with the opaque data "8500"
+	if len(parsedUrl.Scheme) == 0 || len(parsedUrl.Opaque) > 0 {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': missing the scheme with the provider type (%s)", providerUrl, expectedFormat)
+	}
+
+	schemeParts := strings.Split(parsedUrl.Scheme, ".")
+	if len(schemeParts) > 2 || len(schemeParts[0]) == 0 || (len(schemeParts) == 2 && len(schemeParts[1]) == 0) {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': scheme '%s' must be the provider type, optionally followed by '.' and the protocol (%s)",
+			providerUrl, parsedUrl.Scheme, expectedFormat)
+	}
+
+	if len(parsedUrl.Hostname()) == 0 {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': missing the host (%s)", providerUrl, expectedFormat)
+	}
+
+	port := parsedUrl.Port()
+	if len(port) == 0 {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': missing the port (%s)", providerUrl, expectedFormat)
+	}
+
+	portNumber, err := strconv.Atoi(port)
+	if err != nil || portNumber < 1 || portNumber > 65535 {
+		return fmt.Errorf("invalid Configuration Provider URL '%s': port '%s' must be a number from 1 to 65535", providerUrl, port)
+	}
+
+	return nil
+}
+
 // convertToType attempts to convert the string value to the specified type of the old value
 func (_ *Variables) convertToType(oldValue any, value string) (newValue any, err error) {
 	switch oldValue.(type) {
@@ -396,7 +847,8 @@ func GetConfigDir(lc logger.LoggingClient, configDir string) string {
 }
 
 // GetProfileDir get the profile directory value from a Variables variable value (if it exists)
-// or uses passed in value or default if previous result in blank.
+// or uses passed in value or default if previous result in blank. The EDGEX_PROFILE environment variable
+// takes precedence over the -p/--profile flag, so the profile can be selected consistently across services.
 func GetProfileDir(lc logger.LoggingClient, profileDir string) string {
 	envValue := os.Getenv(envKeyProfile)
 	if len(envValue) > 0 {
@@ -423,6 +875,17 @@ func GetConfigFileName(lc logger.LoggingClient, configFileName string) string {
 	return configFileName
 }
 
+// GetEnvironmentName gets the name of the deployment environment, i.e. "prod" or "staging", from the EDGEX_ENV
+// environment variable, which selects the configuration overlay file merged over the configuration file. Empty if not set.
+func GetEnvironmentName(lc logger.LoggingClient) string {
+	envValue := os.Getenv(envKeyEnvironment)
+	if len(envValue) > 0 {
+		logEnvironmentOverride(lc, "Environment", envKeyEnvironment, envValue)
+	}
+
+	return envValue
+}
+
 // GetCommonConfigFileName gets the common configuration value from the Variables value (if it exists)
 // or uses passed in value.
 func GetCommonConfigFileName(lc logger.LoggingClient, commonConfigFileName string) string {
@@ -16,11 +16,14 @@
 package environment
 
 import (
+	"errors"
 	"fmt"
 	"os"
+	"sort"
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	loggerMocks "github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger/mocks"
 	"github.com/stretchr/testify/mock"
@@ -444,6 +447,131 @@ func TestOverrideConfigurationWithBlankValue(t *testing.T) {
 	assert.Equal(t, expectedHost, serviceConfig.Registry.Host)
 }
 
+// EmbeddedInfo is exported since encoding/json can't allocate nil pointers to embedded structs of unexported types
+type EmbeddedInfo struct {
+	Timeout string
+}
+
+func TestOverrideConfigurationNilPointers(t *testing.T) {
+	_, lc := initializeTest()
+
+	serviceConfig := struct {
+		*EmbeddedInfo
+		Service    *config.ServiceInfo
+		MessageBus *config.MessageBusInfo
+		Registry   *config.RegistryInfo
+	}{
+		Registry: &config.RegistryInfo{Host: "localhost", Port: 8500},
+	}
+
+	_ = os.Setenv("MESSAGEBUS_HOST", "edgex-mqtt-broker")
+	_ = os.Setenv("MESSAGEBUS_PORT", "1883")
+	_ = os.Setenv("TIMEOUT", "5s")
+	_ = os.Setenv("REGISTRY_PORT", "8501")
+
+	env := NewVariables(lc)
+	actualCount, err := env.OverrideConfiguration(&serviceConfig)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, actualCount)
+	require.NotNil(t, serviceConfig.MessageBus)
+	assert.Equal(t, "edgex-mqtt-broker", serviceConfig.MessageBus.Host)
+	assert.Equal(t, 1883, serviceConfig.MessageBus.Port)
+	require.NotNil(t, serviceConfig.EmbeddedInfo)
+	assert.Equal(t, "5s", serviceConfig.Timeout)
+	assert.Equal(t, 8501, serviceConfig.Registry.Port)
+	assert.Equal(t, "localhost", serviceConfig.Registry.Host)
+	// Pointers are only allocated when one of their settings is overridden
+	assert.Nil(t, serviceConfig.Service)
+
+	sparseConfig := struct{ MessageBus *config.MessageBusInfo }{}
+	unmatched, err := env.UnmatchedOverrides(&sparseConfig)
+	require.NoError(t, err)
+	assert.Empty(t, unmatched)
+	assert.Nil(t, sparseConfig.MessageBus)
+}
+
+func TestOverrideConfigurationTypeMismatch(t *testing.T) {
+	type serviceInfo struct {
+		Host           string
+		Port           int
+		Enabled        bool
+		RequestTimeout time.Duration
+	}
+
+	tests := []struct {
+		Name               string
+		EnvVars            map[string]string
+		ExpectedRejections []OverrideRejection
+		ExpectedService    serviceInfo
+	}{
+		{"Valid - all types", map[string]string{"SERVICE_PORT": "59881", "SERVICE_ENABLED": "false", "SERVICE_REQUESTTIMEOUT": "30s"}, nil,
+			serviceInfo{Host: "localhost", Port: 59881, Enabled: false, RequestTimeout: 30 * time.Second}},
+		{"Invalid - int", map[string]string{"SERVICE_HOST": "edgex-core-data", "SERVICE_PORT": "abc"},
+			[]OverrideRejection{{Name: "SERVICE_PORT", Path: "Service/Port", TargetType: "int", Value: "abc"}},
+			serviceInfo{Host: "edgex-core-data", Port: 59880, Enabled: true, RequestTimeout: 5 * time.Second}},
+		{"Invalid - bool", map[string]string{"SERVICE_ENABLED": "maybe"},
+			[]OverrideRejection{{Name: "SERVICE_ENABLED", Path: "Service/Enabled", TargetType: "bool", Value: "maybe"}},
+			serviceInfo{Host: "localhost", Port: 59880, Enabled: true, RequestTimeout: 5 * time.Second}},
+		{"Invalid - duration", map[string]string{"SERVICE_REQUESTTIMEOUT": "soon"},
+			[]OverrideRejection{{Name: "SERVICE_REQUESTTIMEOUT", Path: "Service/RequestTimeout", TargetType: "time.Duration", Value: "soon"}},
+			serviceInfo{Host: "localhost", Port: 59880, Enabled: true, RequestTimeout: 5 * time.Second}},
+		{"Invalid - multiple", map[string]string{"SERVICE_PORT": "abc", "SERVICE_ENABLED": "maybe", "SERVICE_REQUESTTIMEOUT": "10s"},
+			[]OverrideRejection{
+				{Name: "SERVICE_ENABLED", Path: "Service/Enabled", TargetType: "bool", Value: "maybe"},
+				{Name: "SERVICE_PORT", Path: "Service/Port", TargetType: "int", Value: "abc"},
+			},
+			serviceInfo{Host: "localhost", Port: 59880, Enabled: true, RequestTimeout: 10 * time.Second}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, lc := initializeTest()
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			serviceConfig := struct {
+				Service serviceInfo
+			}{
+				Service: serviceInfo{Host: "localhost", Port: 59880, Enabled: true, RequestTimeout: 5 * time.Second},
+			}
+
+			env := NewVariables(lc)
+			actualCount, err := env.OverrideConfiguration(&serviceConfig)
+			assert.Equal(t, len(tc.EnvVars)-len(tc.ExpectedRejections), actualCount)
+			assert.Equal(t, tc.ExpectedService, serviceConfig.Service)
+
+			if len(tc.ExpectedRejections) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			var rejectedErr *OverrideRejectedError
+			require.True(t, errors.As(err, &rejectedErr))
+			assert.Equal(t, tc.ExpectedRejections, rejectedErr.Rejections)
+			assert.Contains(t, err.Error(), tc.ExpectedRejections[0].String())
+		})
+	}
+}
+
+func TestOverrideConfigMapValuesTypeMismatch(t *testing.T) {
+	_, lc := initializeTest()
+	t.Setenv("SERVICE_PORT", "abc")
+	t.Setenv("SERVICE_HOST", "edgex-core-data")
+
+	configMap := map[string]any{"Service": map[string]any{"Host": "localhost", "Port": 59880}}
+
+	env := NewVariables(lc)
+	actualCount, err := env.OverrideConfigMapValues(configMap)
+	assert.Equal(t, 1, actualCount)
+	assert.Equal(t, map[string]any{"Service": map[string]any{"Host": "edgex-core-data", "Port": 59880}}, configMap)
+
+	var rejectedErr *OverrideRejectedError
+	require.True(t, errors.As(err, &rejectedErr))
+	assert.Equal(t, []OverrideRejection{{Name: "SERVICE_PORT", Path: "Service/Port", TargetType: "int", Value: "abc"}}, rejectedErr.Rejections)
+}
+
 func TestOverrideSecretStoreInfo(t *testing.T) {
 	_, lc := initializeTest()
 
@@ -586,3 +714,355 @@ func TestOverrideConfigMapValues(t *testing.T) {
 		})
 	}
 }
+
+func TestRenderOverrides(t *testing.T) {
+	_, lc := initializeTest()
+
+	type writableInfo struct {
+		LogLevel        string
+		InsecureSecrets config.InsecureSecrets
+	}
+
+	serviceConfig := struct {
+		Writable writableInfo
+		Registry config.RegistryInfo
+		List     []string
+		FloatVal float32
+		Enabled  bool
+		Clients  map[string]config.ClientInfo
+	}{
+		Writable: writableInfo{
+			LogLevel: "DEBUG",
+			InsecureSecrets: config.InsecureSecrets{
+				"DB": {SecretName: "redisdb", SecretData: map[string]string{"password": "MySecret"}},
+			},
+		},
+		Registry: config.RegistryInfo{Host: "edgex-core-consul", Port: 8500, Type: "consul"},
+		List:     []string{"joe", "mary", "bob"},
+		FloatVal: float32(24.5),
+		Enabled:  true,
+		Clients: map[string]config.ClientInfo{
+			"core-data": {Host: "edgex-core-data", Port: 59880, Protocol: "http"},
+		},
+	}
+
+	actual, err := RenderOverrides(&serviceConfig)
+	require.NoError(t, err)
+
+	assert.Contains(t, actual, "WRITABLE_LOGLEVEL=DEBUG")
+	assert.Contains(t, actual, "WRITABLE_INSECURESECRETS_DB_SECRETNAME=redisdb")
+	assert.Contains(t, actual, "WRITABLE_INSECURESECRETS_DB_SECRETDATA_PASSWORD="+redactedStr)
+	assert.Contains(t, actual, "REGISTRY_PORT=8500")
+	assert.Contains(t, actual, "LIST=joe,mary,bob")
+	assert.Contains(t, actual, "FLOATVAL=24.5")
+	assert.Contains(t, actual, "ENABLED=true")
+	assert.Contains(t, actual, "CLIENTS_CORE_DATA_HOST=edgex-core-data")
+	assert.True(t, sort.StringsAreSorted(actual))
+
+	// Rendering a value rather than a pointer gives the same result
+	actualFromValue, err := RenderOverrides(serviceConfig)
+	require.NoError(t, err)
+	assert.Equal(t, actual, actualFromValue)
+
+	// Every rendered name must be consumed by OverrideConfiguration and reconstruct the original configuration
+	expectedConfig := serviceConfig
+	serviceConfig.Writable.LogLevel = "INFO"
+	serviceConfig.Registry = config.RegistryInfo{}
+	serviceConfig.List = []string{"val1"}
+	serviceConfig.FloatVal = 0
+	serviceConfig.Enabled = false
+	serviceConfig.Clients = map[string]config.ClientInfo{"core-data": {}}
+
+	for _, override := range actual {
+		index := strings.Index(override, "=")
+		require.NotEqual(t, -1, index)
+		_ = os.Setenv(override[:index], override[index+1:])
+	}
+
+	env := NewVariables(lc)
+	actualCount, err := env.OverrideConfiguration(&serviceConfig)
+	require.NoError(t, err)
+	assert.Equal(t, len(actual), actualCount)
+
+	// The redacted secret can't be reconstructed
+	assert.Equal(t, redactedStr, serviceConfig.Writable.InsecureSecrets["DB"].SecretData["password"])
+	serviceConfig.Writable.InsecureSecrets = expectedConfig.Writable.InsecureSecrets
+	assert.Equal(t, expectedConfig, serviceConfig)
+}
+
+func TestRenderOverridesUnsupportedType(t *testing.T) {
+	serviceConfig := struct {
+		Items []map[string]string
+	}{
+		Items: []map[string]string{{"name": "value"}},
+	}
+
+	_, err := RenderOverrides(&serviceConfig)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Items")
+}
+
+func TestOverrideConfigMapValuesPrefixes(t *testing.T) {
+	tests := []struct {
+		Name             string
+		EnvVars          map[string]string
+		ExpectedLogLevel string
+		ExpectedHost     string
+		ExpectedCount    int
+		ExpectedWarnings []string
+	}{
+		{"New prefix", map[string]string{"EDGEX_WRITABLE_LOGLEVEL": "DEBUG"}, "DEBUG", "localhost", 1, nil},
+		{"Deprecated prefix", map[string]string{"APP_WRITABLE_LOGLEVEL": "TRACE"}, "TRACE", "localhost", 1,
+			[]string{"APP_WRITABLE_LOGLEVEL"}},
+		{"Conflict - new prefix wins", map[string]string{"APP_WRITABLE_LOGLEVEL": "TRACE", "EDGEX_WRITABLE_LOGLEVEL": "DEBUG"}, "DEBUG", "localhost", 2,
+			[]string{"APP_WRITABLE_LOGLEVEL"}},
+		{"Mixed", map[string]string{"APP_SERVICE_HOST": "app-host", "EDGEX_WRITABLE_LOGLEVEL": "DEBUG"}, "DEBUG", "app-host", 2,
+			[]string{"APP_SERVICE_HOST"}},
+		{"No prefix not accepted", map[string]string{"WRITABLE_LOGLEVEL": "DEBUG"}, "INFO", "localhost", 0, nil},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			os.Clearenv()
+			defer os.Clearenv()
+			for name, value := range test.EnvVars {
+				_ = os.Setenv(name, value)
+			}
+
+			configMap := map[string]any{
+				"Writable": map[string]any{"LogLevel": "INFO"},
+				"Service":  map[string]any{"Host": "localhost"},
+			}
+
+			mockLogger := &loggerMocks.LoggingClient{}
+			mockLogger.On("Infof", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			mockLogger.On("Warnf", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+			target := NewVariables(mockLogger)
+			target.SetOverridePrefixes("APP_", "EDGEX_")
+
+			actualCount, err := target.OverrideConfigMapValues(configMap)
+			require.NoError(t, err)
+			assert.Equal(t, test.ExpectedCount, actualCount)
+			assert.Equal(t, test.ExpectedLogLevel, configMap["Writable"].(map[string]any)["LogLevel"])
+			assert.Equal(t, test.ExpectedHost, configMap["Service"].(map[string]any)["Host"])
+
+			mockLogger.AssertNumberOfCalls(t, "Warnf", len(test.ExpectedWarnings))
+			for _, envVar := range test.ExpectedWarnings {
+				mockLogger.AssertCalled(t, "Warnf", mock.Anything, envVar, "APP_", "EDGEX_", strings.TrimPrefix(envVar, "APP_"))
+			}
+		})
+	}
+}
+
+func TestUnmatchedOverridesPrefixes(t *testing.T) {
+	_, lc := initializeTest()
+	defer os.Clearenv()
+	_ = os.Setenv("APP_WRITABLE_LOGLEVL", "DEBUG")
+	_ = os.Setenv("EDGEX_WRITABLE_LOGLEVEL", "DEBUG")
+	_ = os.Setenv("WRITABLE_LOGLEVL", "DEBUG")
+
+	env := NewVariables(lc)
+	env.SetOverridePrefixes("APP_", "EDGEX_")
+	actual := env.UnmatchedOverrideNames(map[string]any{"Writable": map[string]any{"LogLevel": "INFO"}})
+	assert.Equal(t, []string{"APP_WRITABLE_LOGLEVL"}, actual)
+}
+
+func TestOverrideConfigurationServiceTypeQualifiers(t *testing.T) {
+	envVars := map[string]string{
+		"WRITABLE_LOGLEVEL":              "DEBUG",
+		"EDGEX_APP_WRITABLE_LOGLEVEL":    "TRACE",
+		"EDGEX_DEVICE_WRITABLE_LOGLEVEL": "WARN",
+		"EDGEX_DEVICE_SERVICE_HOST":      "device-host",
+		"SERVICE_PORT":                   "59999",
+	}
+
+	tests := []struct {
+		Name             string
+		ServiceType      string
+		ExpectedLogLevel string
+		ExpectedHost     string
+		ExpectedCount    int
+	}{
+		{"App service", config.ServiceTypeApp, "TRACE", "localhost", 3},
+		{"Device service", config.ServiceTypeDevice, "WARN", "device-host", 4},
+		{"Other service", config.ServiceTypeOther, "DEBUG", "localhost", 2},
+		{"Service type not set", "", "DEBUG", "localhost", 2},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, lc := initializeTest()
+			defer os.Clearenv()
+			for name, value := range envVars {
+				_ = os.Setenv(name, value)
+			}
+
+			serviceConfig := struct {
+				Writable struct {
+					LogLevel string
+				}
+				Service config.ServiceInfo
+			}{}
+			serviceConfig.Writable.LogLevel = "INFO"
+			serviceConfig.Service.Host = "localhost"
+			serviceConfig.Service.Port = 59880
+
+			env := NewVariables(lc)
+			env.SetServiceType(test.ServiceType)
+			actualCount, err := env.OverrideConfiguration(&serviceConfig)
+			require.NoError(t, err)
+
+			// The unqualified overrides apply to all types of services, but the qualified ones win
+			assert.Equal(t, test.ExpectedCount, actualCount)
+			assert.Equal(t, test.ExpectedLogLevel, serviceConfig.Writable.LogLevel)
+			assert.Equal(t, test.ExpectedHost, serviceConfig.Service.Host)
+			assert.Equal(t, 59999, serviceConfig.Service.Port)
+		})
+	}
+}
+
+func TestUnmatchedOverridesServiceTypeQualifiers(t *testing.T) {
+	_, lc := initializeTest()
+	defer os.Clearenv()
+	_ = os.Setenv("EDGEX_APP_WRITABLE_LOGLEVEL", "DEBUG")
+	_ = os.Setenv("EDGEX_DEVICE_WRITABLE_LOGLEVL", "DEBUG")
+	_ = os.Setenv("EDGEX_DEVICE_DEVICE_PROFILESDIR", "/profiles")
+	_ = os.Setenv("EDGEX_DEVICE_DEVICE_PROFILESDR", "/profiles")
+
+	env := NewVariables(lc)
+	env.SetOverridePrefixes("EDGEX_")
+	env.SetServiceType(config.ServiceTypeDevice)
+	actual := env.UnmatchedOverrideNames(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO"},
+		"Device":   map[string]any{"ProfilesDir": "./res"},
+	})
+
+	// The overrides qualified with other service types are still matched since the environment is shared
+	assert.Equal(t, []string{"EDGEX_DEVICE_DEVICE_PROFILESDR", "EDGEX_DEVICE_WRITABLE_LOGLEVL"}, actual)
+}
+
+func TestOverriddenMapPaths(t *testing.T) {
+	_, lc := initializeTest()
+	defer os.Clearenv()
+	_ = os.Setenv("EDGEX_WRITABLE_LOGLEVEL", "DEBUG")
+	_ = os.Setenv("EDGEX_APP_SERVICE_PORT", "59700")
+	_ = os.Setenv("EDGEX_DEVICE_SERVICE_HOST", "localhost")
+	_ = os.Setenv("EDGEX_WRITABLE_LOGLEVL", "DEBUG")
+
+	env := NewVariables(lc)
+	env.SetOverridePrefixes("EDGEX_")
+	env.SetServiceType(config.ServiceTypeApp)
+	actual := env.OverriddenMapPaths(map[string]any{
+		"Writable": map[string]any{"LogLevel": "INFO"},
+		"Service":  map[string]any{"Host": "edgex-app", "Port": 59701},
+	})
+
+	// Only the overrides qualified with the service's own type apply
+	assert.Equal(t, []string{"Service/Port", "Writable/LogLevel"}, actual)
+}
+
+func TestUnmatchedOverrides(t *testing.T) {
+	serviceConfig := struct {
+		Writable struct {
+			LogLevel string
+		}
+		Clients  map[string]config.ClientInfo
+		FloatVal float32
+	}{
+		Clients: map[string]config.ClientInfo{
+			"core-data": {Host: "localhost", Port: 59880},
+		},
+	}
+
+	tests := []struct {
+		Name     string
+		EnvVars  map[string]string
+		Expected []string
+	}{
+		{"Matched", map[string]string{"WRITABLE_LOGLEVEL": "DEBUG", "CLIENTS_CORE_DATA_HOST": "edgex-core-data", "FLOATVAL": "1.5"}, nil},
+		{"Unmatched", map[string]string{"WRITABLE_LOGLEVL": "DEBUG", "CLIENTS_CORE_METADATA_HOST": "edgex-core-metadata"},
+			[]string{"CLIENTS_CORE_METADATA_HOST", "WRITABLE_LOGLEVL"}},
+		{"Non-override", map[string]string{"HOME": "/root", "EDGEX_CONFIG_DIR": "/res", "Writable_LogLevl": "DEBUG", "FLOATVALUE": "1.5"}, nil},
+		{"Mixed", map[string]string{"WRITABLE_LOGLEVEL": "DEBUG", "WRITABLE_LOGLEVL": "DEBUG", "PATH": "/bin"}, []string{"WRITABLE_LOGLEVL"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.Name, func(t *testing.T) {
+			_, lc := initializeTest()
+			for name, value := range test.EnvVars {
+				_ = os.Setenv(name, value)
+			}
+
+			env := NewVariables(lc)
+			actual, err := env.UnmatchedOverrides(&serviceConfig)
+			require.NoError(t, err)
+			assert.Equal(t, test.Expected, actual)
+		})
+	}
+}
+
+// testSecretGetter is a SecretGetter returning the secrets from the map, keyed by secret name
+type testSecretGetter map[string]map[string]string
+
+func (g testSecretGetter) GetSecret(secretName string, keys ...string) (map[string]string, error) {
+	secrets, found := g[secretName]
+	if !found {
+		return nil, fmt.Errorf("no secret data found for %s", secretName)
+	}
+	return secrets, nil
+}
+
+func TestOverrideConfigurationSecretReferences(t *testing.T) {
+	secretProvider := testSecretGetter{
+		"redisdb":  {"password": "p@ssw0rd"},
+		"registry": {"port": "8501"},
+	}
+
+	tests := []struct {
+		Name           string
+		EnvVars        map[string]string
+		SecretProvider SecretGetter
+		ExpectedHost   string
+		ExpectedPort   int
+		ExpectedError  string
+	}{
+		{"Valid - resolved", map[string]string{"REGISTRY_HOST": "secretref:redisdb/password", "REGISTRY_PORT": "secretref:registry/port"}, secretProvider, "p@ssw0rd", 8501, ""},
+		{"Valid - not a reference", map[string]string{"REGISTRY_HOST": "edgex-core-consul"}, nil, "edgex-core-consul", 8500, ""},
+		{"Invalid - missing secret", map[string]string{"REGISTRY_HOST": "secretref:missing/password"}, secretProvider, "", 0, "failed to get secret 'missing'"},
+		{"Invalid - missing key", map[string]string{"REGISTRY_HOST": "secretref:redisdb/username"}, secretProvider, "", 0, "secret 'redisdb' has no key 'username'"},
+		{"Invalid - malformed", map[string]string{"REGISTRY_HOST": "secretref:redisdb"}, secretProvider, "", 0, "must be in the form secretref:<secretName>/<key>"},
+		{"Invalid - no secret provider", map[string]string{"REGISTRY_HOST": "secretref:redisdb/password"}, nil, "", 0, "can't be resolved without a secret provider"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, lc := initializeTest()
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			serviceConfig := struct {
+				Registry config.RegistryInfo
+			}{
+				Registry: config.RegistryInfo{Host: "localhost", Port: 8500},
+			}
+
+			env := NewVariables(lc)
+			if tc.SecretProvider != nil {
+				env.SetSecretProvider(tc.SecretProvider)
+			}
+
+			actualCount, err := env.OverrideConfiguration(&serviceConfig)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, len(tc.EnvVars), actualCount)
+			assert.Equal(t, tc.ExpectedHost, serviceConfig.Registry.Host)
+			assert.Equal(t, tc.ExpectedPort, serviceConfig.Registry.Port)
+		})
+	}
+}
@@ -19,11 +19,16 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"time"
 )
 
 const (
 	DefaultConfigProvider = "consul.http://localhost:8500"
 	DefaultConfigFile     = "configuration.yaml"
+	// DefaultAppConfigFile is the configuration file name used by application services when none is specified
+	DefaultAppConfigFile = "app-configuration.yaml"
+	// DefaultDeviceConfigFile is the configuration file name used by device services when none is specified
+	DefaultDeviceConfigFile = "device-configuration.yaml"
 )
 
 // Common is an interface that defines AP for the common command-line flags used by most EdgeX services
@@ -40,6 +45,50 @@ type Common interface {
 	Help()
 }
 
+// ConfigFileSpecifier is optionally implemented by Common implementations to report whether the configuration file
+// name was explicitly specified on the command-line, rather than being the default value.
+type ConfigFileSpecifier interface {
+	ConfigFileNameSpecified() bool
+}
+
+// StrictOverridesOption is optionally implemented by Common implementations to report whether startup should fail
+// when environment variables look like configuration overrides, but don't match any configuration setting, or have
+// values which don't match the type of their setting.
+type StrictOverridesOption interface {
+	StrictOverrides() bool
+}
+
+// LogLevelOption is optionally implemented by Common implementations to report the log level specified on the
+// command-line, which is used from startup instead of the configured log level. Empty if not specified.
+type LogLevelOption interface {
+	LogLevel() string
+}
+
+// CommonConfigPollOption is optionally implemented by Common implementations to report the interval and jitter of the
+// polling for the common configuration to be ready in the Configuration Provider. Zero if not specified.
+type CommonConfigPollOption interface {
+	CommonConfigPollInterval() time.Duration
+	CommonConfigPollJitter() time.Duration
+}
+
+// ProviderSeedOption is optionally implemented by Common implementations to report whether the configuration loaded
+// from file must not be pushed into the Configuration Provider when it doesn't already have it.
+type ProviderSeedOption interface {
+	NoProviderSeed() bool
+}
+
+// LazyConfigOption is optionally implemented by Common implementations to report whether the configuration sections
+// the service has set to be loaded lazily are only loaded from the Configuration Provider when the service accesses them.
+type LazyConfigOption interface {
+	LazyConfig() bool
+}
+
+// PruneProviderKeysOption is optionally implemented by Common implementations to report whether the keys in the
+// Configuration Provider which the service's configuration no longer has may be deleted, rather than only reported.
+type PruneProviderKeysOption interface {
+	PruneProviderKeys() bool
+}
+
 // Default is the Default implementation of Common used by most EdgeX services
 type Default struct {
 	FlagSet           *flag.FlagSet
@@ -52,6 +101,14 @@ type Default struct {
 	profile           string
 	configDir         string
 	configFileName    string
+	configFileSet     bool
+	strictOverrides   bool
+	logLevel          string
+	pollInterval      time.Duration
+	pollJitter        time.Duration
+	noProviderSeed    bool
+	lazyConfig        bool
+	pruneProviderKeys bool
 }
 
 // NewWithUsage returns a Default struct.
@@ -101,6 +158,13 @@ func (d *Default) Parse(arguments []string) {
 	d.FlagSet.BoolVar(&d.useRegistry, "r", false, "")
 	d.FlagSet.BoolVar(&d.devMode, "dev", false, "")
 	d.FlagSet.BoolVar(&d.devMode, "d", false, "")
+	d.FlagSet.BoolVar(&d.strictOverrides, "strictOverrides", false, "")
+	d.FlagSet.StringVar(&d.logLevel, "logLevel", "", "")
+	d.FlagSet.DurationVar(&d.pollInterval, "commonConfigPollInterval", 0, "")
+	d.FlagSet.DurationVar(&d.pollJitter, "commonConfigPollJitter", 0, "")
+	d.FlagSet.BoolVar(&d.noProviderSeed, "noProviderSeed", false, "")
+	d.FlagSet.BoolVar(&d.lazyConfig, "lazyConfig", false, "")
+	d.FlagSet.BoolVar(&d.pruneProviderKeys, "pruneProviderKeys", false, "")
 
 	d.FlagSet.Usage = d.helpCallback
 
@@ -109,6 +173,12 @@ func (d *Default) Parse(arguments []string) {
 		fmt.Println(err)
 		os.Exit(0)
 	}
+
+	d.FlagSet.Visit(func(f *flag.Flag) {
+		if f.Name == "cf" || f.Name == "configFile" {
+			d.configFileSet = true
+		}
+	})
 }
 
 // OverwriteConfig returns whether the local configuration should be pushed (overwrite) into the Configuration provider
@@ -146,6 +216,50 @@ func (d *Default) ConfigFileName() string {
 	return d.configFileName
 }
 
+// ConfigFileNameSpecified returns whether the name of the local configuration file was specified on the command-line
+func (d *Default) ConfigFileNameSpecified() bool {
+	return d.configFileSet
+}
+
+// StrictOverrides returns whether startup should fail when environment variable overrides don't match any configuration
+// setting or the type of their setting
+func (d *Default) StrictOverrides() bool {
+	return d.strictOverrides
+}
+
+// LogLevel returns the log level to use from startup, if one was specified
+func (d *Default) LogLevel() string {
+	return d.logLevel
+}
+
+// CommonConfigPollInterval returns the interval of the polling for the common configuration to be ready, if specified
+func (d *Default) CommonConfigPollInterval() time.Duration {
+	return d.pollInterval
+}
+
+// CommonConfigPollJitter returns the maximum random jitter added to the interval of the polling for the common
+// configuration to be ready, if specified
+func (d *Default) CommonConfigPollJitter() time.Duration {
+	return d.pollJitter
+}
+
+// NoProviderSeed returns whether the configuration loaded from file must not be pushed into the Configuration Provider
+func (d *Default) NoProviderSeed() bool {
+	return d.noProviderSeed
+}
+
+// LazyConfig returns whether the lazily loaded configuration sections are only loaded from the Configuration Provider
+// when accessed
+func (d *Default) LazyConfig() bool {
+	return d.lazyConfig
+}
+
+// PruneProviderKeys returns whether the keys in the Configuration Provider which the service's configuration no longer
+// has may be deleted
+func (d *Default) PruneProviderKeys() bool {
+	return d.pruneProviderKeys
+}
+
 // CommonConfig returns the location for the common configuration
 func (d *Default) CommonConfig() string {
 	return d.commonConfig
@@ -168,12 +282,33 @@ func (d *Default) helpCallback() {
 			"    -o, --overwrite                 Overwrite configuration in provider with local configuration\n"+
 			"                                    *** Use with cation *** Use will clobber existing settings in provider,\n"+
 			"                                    problematic if those settings were edited by hand intentionally\n"+
-			"    -cf, --configFile <name>        Indicates name of the local configuration file. Defaults to configuration.toml\n"+
-			"    -p, --profile <name>            Indicate configuration profile other than default\n"+
+			"    -cf, --configFile <name>        Indicates name of the local configuration file. Defaults to configuration.yaml,\n"+
+			"                                    app-configuration.yaml for app services or device-configuration.yaml\n"+
+			"                                    for device services. The overlay file for the environment set by the EDGEX_ENV\n"+
+			"                                    environment variable, i.e. configuration.prod.yaml, is merged over it\n"+
+			"    -p, --profile <name>            Indicate configuration profile other than default. The EDGEX_PROFILE\n"+
+			"                                    environment variable takes precedence over this flag\n"+
 			"    -cd, --configDir                Specify local configuration directory\n"+
 			"    -r, --registry                  Indicates service should use Registry.\n"+
 			"    -d, --dev                       Indicates service to run in developer mode which causes Host configuration values to be overridden.\n"+
 			"                                    with `localhost`. This is so that it will run with other services running in Docker (aka hybrid mode)\n"+
+			"    --strictOverrides               Indicates service should fail to start when environment variables look like configuration\n"+
+			"                                    overrides, i.e. WRITABLE_LOGLEVL, but don't match any configuration setting, or\n"+
+			"                                    have values which don't match the type of their setting, i.e. SERVICE_PORT=abc\n"+
+			"    --logLevel <level>              Indicates the log level to use from startup, i.e. DEBUG, instead of the configured\n"+
+			"                                    log level, which otherwise only takes effect once the configuration is loaded\n"+
+			"    --commonConfigPollInterval <duration>\n"+
+			"                                    Indicates the interval, i.e. 2s, of polling for the common configuration to be\n"+
+			"                                    ready in the Configuration Provider. Defaults to the startup interval\n"+
+			"    --commonConfigPollJitter <duration>\n"+
+			"                                    Indicates the maximum random jitter, i.e. 500ms, added to each poll interval so\n"+
+			"                                    services restarted together don't poll in lockstep. Defaults to 1s\n"+
+			"    --noProviderSeed                Indicates the configuration loaded from file must not be pushed into the\n"+
+			"                                    Configuration Provider when it doesn't have the service's configuration\n"+
+			"    --lazyConfig                    Indicates the configuration sections the service loads lazily, i.e. large device\n"+
+			"                                    lists, are only loaded from the Configuration Provider when the service accesses them\n"+
+			"    --pruneProviderKeys             Confirms the keys in the Configuration Provider which the service's configuration no\n"+
+			"                                    longer has may be deleted when the service prunes them, otherwise they're only reported\n"+
 			"%s\n"+
 			"Common Options:\n"+
 			"	-h, --help                      Show this message\n",
@@ -16,6 +16,7 @@ package flags
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,6 +33,7 @@ func TestNewAllFlags(t *testing.T) {
 	expectedConfigDirectory := "/res"
 	expectedFileName := "config.toml"
 	expectedCommonConfig := "config.yaml"
+	expectedLogLevel := "DEBUG"
 
 	actual := newSUT(
 		[]string{
@@ -41,6 +43,13 @@ func TestNewAllFlags(t *testing.T) {
 			"-cd=" + expectedConfigDirectory,
 			"-cf=" + expectedFileName,
 			"-cc=" + expectedCommonConfig,
+			"--strictOverrides",
+			"--logLevel=" + expectedLogLevel,
+			"--commonConfigPollInterval=2s",
+			"--commonConfigPollJitter=500ms",
+			"--noProviderSeed",
+			"--lazyConfig",
+			"--pruneProviderKeys",
 		},
 	)
 
@@ -49,7 +58,15 @@ func TestNewAllFlags(t *testing.T) {
 	assert.Equal(t, expectedProfile, actual.Profile())
 	assert.Equal(t, expectedConfigDirectory, actual.ConfigDirectory())
 	assert.Equal(t, expectedFileName, actual.ConfigFileName())
+	assert.True(t, actual.ConfigFileNameSpecified())
 	assert.Equal(t, expectedCommonConfig, actual.CommonConfig())
+	assert.True(t, actual.StrictOverrides())
+	assert.Equal(t, expectedLogLevel, actual.LogLevel())
+	assert.Equal(t, 2*time.Second, actual.CommonConfigPollInterval())
+	assert.Equal(t, 500*time.Millisecond, actual.CommonConfigPollJitter())
+	assert.True(t, actual.NoProviderSeed())
+	assert.True(t, actual.LazyConfig())
+	assert.True(t, actual.PruneProviderKeys())
 }
 
 func TestNewDefaultsNoFlags(t *testing.T) {
@@ -61,7 +78,15 @@ func TestNewDefaultsNoFlags(t *testing.T) {
 	assert.Equal(t, "", actual.Profile())
 	assert.Equal(t, "", actual.ConfigDirectory())
 	assert.Equal(t, DefaultConfigFile, actual.ConfigFileName())
+	assert.False(t, actual.ConfigFileNameSpecified())
 	assert.Equal(t, "", actual.CommonConfig())
+	assert.False(t, actual.StrictOverrides())
+	assert.Equal(t, "", actual.LogLevel())
+	assert.Zero(t, actual.CommonConfigPollInterval())
+	assert.Zero(t, actual.CommonConfigPollJitter())
+	assert.False(t, actual.NoProviderSeed())
+	assert.False(t, actual.LazyConfig())
+	assert.False(t, actual.PruneProviderKeys())
 }
 
 func TestNewDefaultForCP(t *testing.T) {
@@ -15,19 +15,33 @@
 package handlers
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+const (
+	// EnvAuthRealm is the environment variable used to override the realm of the WWW-Authenticate challenge
+	EnvAuthRealm = "EDGEX_AUTH_REALM"
+	// DefaultAuthRealm is the realm of the WWW-Authenticate challenge when not overridden
+	DefaultAuthRealm = "edgex"
 )
 
 // VaultAuthenticationHandlerFunc prefixes an existing HandlerFunc
-// with a Vault-based JWT authentication check.  Usage:
+// with a Vault-based JWT authentication check. The request's X-Correlation-ID,
+// or a newly generated one, is added to the request context along with a LoggingClient
+// that tags all messages with it (see LoggingClientFromContext). Once the JWT is validated, the AuthInfo of the
+// caller is also added to the request context (see AuthInfoFromContext). Usage:
 //
 //	 authenticationHook := handlers.NilAuthenticationHandlerFunc()
 //	 if secret.IsSecurityEnabled() {
@@ -41,36 +55,147 @@ import (
 //	 For unauthenticated requests
 //	 r.HandleFunc("path", handlerFunc).Methods(http.MethodGet)
 //
+// Unauthorized responses include a `WWW-Authenticate: Bearer realm="edgex"` challenge header. The realm can be
+// overridden via the EDGEX_AUTH_REALM environment variable.
+//
+// The JWT's "exp" and "nbf" claims are also checked against the local clock, as by secret.ValidateJWTTimes, allowing
+// a leeway of 30s for the clock skew between services. The leeway can be overridden via the EDGEX_AUTH_JWT_LEEWAY
+// environment variable (see secret.JWTLeeway).
+//
 // For typical usage, it is preferred to use AutoConfigAuthenticationFunc which
 // will automatically select between a real and a fake JWT validation handler.
 func VaultAuthenticationHandlerFunc(secretProvider interfaces.SecretProviderExt, lc logger.LoggingClient) func(inner http.HandlerFunc) http.HandlerFunc {
 	return func(inner http.HandlerFunc) http.HandlerFunc {
-		return func(w http.ResponseWriter, r *http.Request) {
-			authHeader := r.Header.Get("Authorization")
-			lc.Debugf("Authorizing incoming call to '%s' via JWT (Authorization len=%d)", r.URL.Path, len(authHeader))
-			authParts := strings.Split(authHeader, " ")
-			if len(authParts) >= 2 && strings.EqualFold(authParts[0], "Bearer") {
-				token := authParts[1]
-				validToken, err := secretProvider.IsJWTValid(token)
-				if err != nil {
-					lc.Errorf("Error checking JWT validity: %v", err)
-					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return vaultAuthenticationHandler(secretProvider, lc, inner, false)
+	}
+}
+
+// VaultAuthenticationWithClaimsHandlerFunc is the same as VaultAuthenticationHandlerFunc, except that the claims of
+// the validated JWT are also added to the request context, so the inner handler can retrieve the caller's identity
+// via ClaimsFromContext without re-parsing the token.
+func VaultAuthenticationWithClaimsHandlerFunc(secretProvider interfaces.SecretProviderExt, lc logger.LoggingClient) func(inner http.HandlerFunc) http.HandlerFunc {
+	return func(inner http.HandlerFunc) http.HandlerFunc {
+		return vaultAuthenticationHandler(secretProvider, lc, inner, true)
+	}
+}
+
+// vaultAuthenticationHandler returns a HandlerFunc which validates the request's JWT before invoking inner, optionally
+// adding the JWT's claims to the request context.
+func vaultAuthenticationHandler(secretProvider interfaces.SecretProviderExt, lc logger.LoggingClient, inner http.HandlerFunc, addClaims bool) http.HandlerFunc {
+	challenge := authChallenge()
+	leeway := secret.JWTLeeway(lc)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		r, lc := withCorrelationId(w, r, lc)
+
+		authHeader := r.Header.Get("Authorization")
+		lc.Debugf("Authorizing incoming call to '%s' via JWT (Authorization len=%d)", r.URL.Path, len(authHeader))
+		authParts := strings.Split(authHeader, " ")
+		if len(authParts) >= 2 && strings.EqualFold(authParts[0], "Bearer") {
+			token := authParts[1]
+			validToken, err := secretProvider.IsJWTValid(token)
+			if err != nil {
+				lc.Errorf("Error checking JWT validity: %v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			} else if !validToken {
+				lc.Warnf("Request to '%s' UNAUTHORIZED", r.URL.Path)
+				unauthorized(w, challenge)
+				return
+			}
+
+			claims, err := secret.ParseJWTClaims(token)
+			if err == nil {
+				if err := secret.ValidateJWTTimes(claims, time.Now(), leeway); err != nil {
+					lc.Warnf("Request to '%s' UNAUTHORIZED: %v", r.URL.Path, err)
+					unauthorized(w, challenge)
 					return
-				} else if !validToken {
-					lc.Warnf("Request to '%s' UNAUTHORIZED", r.URL.Path)
-					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				}
+			}
+			if addClaims {
+				if err != nil {
+					lc.Errorf("Unable to parse JWT claims for call to '%s'; unauthorized: %v", r.URL.Path, err)
+					unauthorized(w, challenge)
 					return
 				}
-				lc.Debug("Request to '%s' authorized", r.URL.Path)
-				inner(w, r)
-				return
+				r = r.WithContext(context.WithValue(r.Context(), claimsContextKey{}, claims))
 			}
-			lc.Errorf("Unable to parse JWT for call to '%s'; unauthorized", r.URL.Path)
-			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			// The JWT has been validated, so the auth info is added even when its claims can't be parsed
+			r = r.WithContext(context.WithValue(r.Context(), authInfoContextKey{}, newAuthInfo(claims)))
+
+			lc.Debugf("Request to '%s' authorized", r.URL.Path)
+			inner(w, r)
+			return
 		}
+		lc.Errorf("Unable to parse JWT for call to '%s'; unauthorized", r.URL.Path)
+		unauthorized(w, challenge)
 	}
 }
 
+// authChallenge returns the WWW-Authenticate challenge sent with unauthorized responses
+func authChallenge() string {
+	realm := os.Getenv(EnvAuthRealm)
+	if len(realm) == 0 {
+		realm = DefaultAuthRealm
+	}
+
+	return fmt.Sprintf("Bearer realm=%q", realm)
+}
+
+// unauthorized writes an unauthorized response with the WWW-Authenticate challenge
+func unauthorized(w http.ResponseWriter, challenge string) {
+	w.Header().Set("WWW-Authenticate", challenge)
+	http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+}
+
+// claimsContextKey is the request context key for the claims of the request's validated JWT
+type claimsContextKey struct{}
+
+// ClaimsFromContext returns the claims of the request's validated JWT, which are added to the context by
+// VaultAuthenticationWithClaimsHandlerFunc, or nil if not present.
+func ClaimsFromContext(ctx context.Context) map[string]any {
+	claims, ok := ctx.Value(claimsContextKey{}).(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	return claims
+}
+
+// AuthInfo describes the successful authentication of a request, which is added to the request context by
+// VaultAuthenticationHandlerFunc and VaultAuthenticationWithClaimsHandlerFunc.
+type AuthInfo struct {
+	// Subject is the "sub" claim of the validated JWT, or empty if not present
+	Subject string
+	// ExpiresAt is the time from the "exp" claim of the validated JWT, or the zero time if not present
+	ExpiresAt time.Time
+	// Validated is whether the request's JWT was validated by the secret store
+	Validated bool
+}
+
+// authInfoContextKey is the request context key for the AuthInfo of the request
+type authInfoContextKey struct{}
+
+// AuthInfoFromContext returns the AuthInfo of the request's successful authentication, with false if the request
+// wasn't authenticated, i.e. when JWT validation is disabled.
+func AuthInfoFromContext(ctx context.Context) (AuthInfo, bool) {
+	info, ok := ctx.Value(authInfoContextKey{}).(AuthInfo)
+	return info, ok
+}
+
+// newAuthInfo returns the AuthInfo for a validated JWT with the claims, which may be nil
+func newAuthInfo(claims map[string]any) AuthInfo {
+	info := AuthInfo{Validated: true}
+	if subject, ok := claims["sub"].(string); ok {
+		info.Subject = subject
+	}
+	if expiresAt, ok := claims["exp"].(float64); ok {
+		info.ExpiresAt = time.Unix(int64(expiresAt), 0)
+	}
+
+	return info
+}
+
 // NilAuthenticationHandlerFunc just invokes a nested handler
 func NilAuthenticationHandlerFunc() func(inner http.HandlerFunc) http.HandlerFunc {
 	return func(inner http.HandlerFunc) http.HandlerFunc {
@@ -89,12 +214,37 @@ func NilAuthenticationHandlerFunc() func(inner http.HandlerFunc) http.HandlerFun
 // to disable JWT validation.  This might be wanted for an EdgeX
 // adopter that wanted to only validate JWT's at the proxy layer,
 // or as an escape hatch for a caller that cannot authenticate.
+//
+// Set EDGEX_AUTH_INTROSPECTION_URL to the URL of an RFC 7662 introspection
+// endpoint to introspect the tokens rather than validate them with the
+// secret store (see IntrospectionAuthenticationHandlerFunc). The endpoint
+// is authenticated with the username and password of the "introspection"
+// secret, when present.
 func AutoConfigAuthenticationFunc(secretProvider interfaces.SecretProviderExt, lc logger.LoggingClient) func(inner http.HandlerFunc) http.HandlerFunc {
 	// Golang standard library treats an error as false
 	disableJWTValidation, _ := strconv.ParseBool(os.Getenv("EDGEX_DISABLE_JWT_VALIDATION"))
 	authenticationHook := NilAuthenticationHandlerFunc()
 	if secret.IsSecurityEnabled() && !disableJWTValidation {
+		if introspectURL := os.Getenv(EnvAuthIntrospectionUrl); len(introspectURL) > 0 {
+			lc.Infof("Using token introspection at %s for authentication", introspectURL)
+			return IntrospectionAuthenticationHandlerFunc(introspectURL, introspectionCredentials(secretProvider, lc), lc)
+		}
 		authenticationHook = VaultAuthenticationHandlerFunc(secretProvider, lc)
 	}
 	return authenticationHook
 }
+
+// introspectionCredentials returns the credentials for the introspection endpoint from the introspection secret.
+// Empty credentials are returned when the secret isn't available, so the endpoint is called unauthenticated.
+func introspectionCredentials(secretProvider interfaces.SecretProviderExt, lc logger.LoggingClient) config.Credentials {
+	secrets, err := secretProvider.GetSecret(IntrospectionSecretName, secret.UsernameKey, secret.PasswordKey)
+	if err != nil {
+		lc.Warnf("Unable to get the '%s' secret, so the introspection endpoint is called without credentials: %v", IntrospectionSecretName, err)
+		return config.Credentials{}
+	}
+
+	return config.Credentials{
+		Username: secrets[secret.UsernameKey],
+		Password: secrets[secret.PasswordKey],
+	}
+}
@@ -0,0 +1,333 @@
+//
+// Copyright (C) 2023 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+)
+
+// recordingLogger records all formatted log messages so tests can assert on their content
+type recordingLogger struct {
+	logger.MockLogger
+	messages []string
+}
+
+func (l *recordingLogger) record(msg string, args ...interface{}) {
+	l.messages = append(l.messages, fmt.Sprintf(msg, args...))
+}
+
+func (l *recordingLogger) Debugf(msg string, args ...interface{}) { l.record(msg, args...) }
+func (l *recordingLogger) Infof(msg string, args ...interface{})  { l.record(msg, args...) }
+func (l *recordingLogger) Warnf(msg string, args ...interface{})  { l.record(msg, args...) }
+func (l *recordingLogger) Errorf(msg string, args ...interface{}) { l.record(msg, args...) }
+
+func TestVaultAuthenticationHandlerFunc_CorrelationId(t *testing.T) {
+	validJWT := "valid.jwt.token"
+	invalidJWT := "invalid.jwt.token"
+
+	secretProvider := &mocks.SecretProvider{}
+	secretProvider.On("IsJWTValid", validJWT).Return(true, nil)
+	secretProvider.On("IsJWTValid", invalidJWT).Return(false, nil)
+
+	tests := []struct {
+		Name           string
+		CorrelationId  string
+		AuthHeader     string
+		ExpectedStatus int
+		ExpectReplaced bool
+	}{
+		{"Valid - correlation ID provided", "my-correlation-id", "Bearer " + validJWT, http.StatusOK, false},
+		{"Valid - correlation ID generated", "", "Bearer " + validJWT, http.StatusOK, false},
+		{"Valid - correlation ID with newline replaced", "my-id\nINFO: forged message", "Bearer " + validJWT, http.StatusOK, true},
+		{"Valid - correlation ID too long replaced", strings.Repeat("a", 129), "Bearer " + validJWT, http.StatusOK, true},
+		{"Unauthorized - correlation ID provided", "my-correlation-id", "Bearer " + invalidJWT, http.StatusUnauthorized, false},
+		{"Unauthorized - no JWT", "my-correlation-id", "", http.StatusUnauthorized, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			lc := &recordingLogger{}
+
+			var innerCorrelationId string
+			innerCalled := false
+			inner := func(w http.ResponseWriter, r *http.Request) {
+				innerCalled = true
+				innerCorrelationId = CorrelationIdFromContext(r.Context())
+				requestLc := LoggingClientFromContext(r.Context())
+				require.NotNil(t, requestLc)
+				requestLc.Infof("handling request")
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			if len(tc.CorrelationId) > 0 {
+				req.Header.Set(common.CorrelationHeader, tc.CorrelationId)
+			}
+			if len(tc.AuthHeader) > 0 {
+				req.Header.Set("Authorization", tc.AuthHeader)
+			}
+
+			recorder := httptest.NewRecorder()
+			VaultAuthenticationHandlerFunc(secretProvider, lc)(inner)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+
+			correlationId := recorder.Header().Get(common.CorrelationHeader)
+			require.NotEmpty(t, correlationId)
+			if tc.ExpectReplaced {
+				assert.NotEqual(t, tc.CorrelationId, correlationId)
+				_, err := uuid.Parse(correlationId)
+				assert.NoError(t, err, "replaced correlation ID must be generated")
+			} else if len(tc.CorrelationId) > 0 {
+				assert.Equal(t, tc.CorrelationId, correlationId)
+			}
+
+			require.NotEmpty(t, lc.messages)
+			for _, message := range lc.messages {
+				assert.True(t, strings.Contains(message, "correlation-id="+correlationId),
+					"log message missing correlation ID: %s", message)
+			}
+
+			assert.Equal(t, tc.ExpectedStatus == http.StatusOK, innerCalled)
+			if innerCalled {
+				assert.Equal(t, correlationId, innerCorrelationId)
+			}
+		})
+	}
+}
+
+func TestVaultAuthenticationWithClaimsHandlerFunc(t *testing.T) {
+	encode := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+
+	validJWT := encode(`{"sub":"core-command","iss":"/v1/identity/oidc","exp":4102444800}`)
+	malformedJWT := encode(`not json`)
+	invalidJWT := encode(`{"sub":"intruder"}`)
+
+	secretProvider := &mocks.SecretProvider{}
+	secretProvider.On("IsJWTValid", validJWT).Return(true, nil)
+	secretProvider.On("IsJWTValid", malformedJWT).Return(true, nil)
+	secretProvider.On("IsJWTValid", invalidJWT).Return(false, nil)
+
+	tests := []struct {
+		Name           string
+		Token          string
+		ExpectedStatus int
+		ExpectedClaims map[string]any
+	}{
+		{"Valid - claims added", validJWT, http.StatusOK,
+			map[string]any{"sub": "core-command", "iss": "/v1/identity/oidc", "exp": float64(4102444800)}},
+		{"Unauthorized - invalid JWT", invalidJWT, http.StatusUnauthorized, nil},
+		{"Unauthorized - malformed claims", malformedJWT, http.StatusUnauthorized, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var innerClaims map[string]any
+			innerCalled := false
+			inner := func(w http.ResponseWriter, r *http.Request) {
+				innerCalled = true
+				innerClaims = ClaimsFromContext(r.Context())
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.Token)
+
+			recorder := httptest.NewRecorder()
+			VaultAuthenticationWithClaimsHandlerFunc(secretProvider, logger.NewMockClient())(inner)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+			assert.Equal(t, tc.ExpectedStatus == http.StatusOK, innerCalled)
+			assert.Equal(t, tc.ExpectedClaims, innerClaims)
+		})
+	}
+}
+
+func TestVaultAuthenticationHandlerFunc_NoClaims(t *testing.T) {
+	token := "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"core-command"}`)) + ".c2lnbmF0dXJl"
+
+	secretProvider := &mocks.SecretProvider{}
+	secretProvider.On("IsJWTValid", token).Return(true, nil)
+
+	innerCalled := false
+	inner := func(w http.ResponseWriter, r *http.Request) {
+		innerCalled = true
+		assert.Nil(t, ClaimsFromContext(r.Context()))
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	recorder := httptest.NewRecorder()
+	VaultAuthenticationHandlerFunc(secretProvider, logger.NewMockClient())(inner)(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, innerCalled)
+}
+
+func TestVaultAuthenticationHandlerFunc_Challenge(t *testing.T) {
+	validJWT := "valid.jwt.token"
+	invalidJWT := "invalid.jwt.token"
+
+	secretProvider := &mocks.SecretProvider{}
+	secretProvider.On("IsJWTValid", validJWT).Return(true, nil)
+	secretProvider.On("IsJWTValid", invalidJWT).Return(false, nil)
+
+	tests := []struct {
+		Name              string
+		Realm             string
+		AuthHeader        string
+		ExpectedStatus    int
+		ExpectedChallenge string
+	}{
+		{"Valid - no challenge", "", "Bearer " + validJWT, http.StatusOK, ""},
+		{"Unauthorized - invalid JWT", "", "Bearer " + invalidJWT, http.StatusUnauthorized, `Bearer realm="edgex"`},
+		{"Unauthorized - no JWT", "", "", http.StatusUnauthorized, `Bearer realm="edgex"`},
+		{"Unauthorized - realm overridden", "my-gateway", "", http.StatusUnauthorized, `Bearer realm="my-gateway"`},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(EnvAuthRealm, tc.Realm)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			if len(tc.AuthHeader) > 0 {
+				req.Header.Set("Authorization", tc.AuthHeader)
+			}
+
+			recorder := httptest.NewRecorder()
+			inner := func(w http.ResponseWriter, r *http.Request) {}
+			VaultAuthenticationHandlerFunc(secretProvider, logger.NewMockClient())(inner)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+			if len(tc.ExpectedChallenge) == 0 {
+				assert.Empty(t, recorder.Header().Values("WWW-Authenticate"))
+				return
+			}
+
+			assert.Equal(t, tc.ExpectedChallenge, recorder.Header().Get("WWW-Authenticate"))
+		})
+	}
+}
+
+func TestVaultAuthenticationHandlerFunc_AuthInfo(t *testing.T) {
+	encode := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+
+	validJWT := encode(`{"sub":"core-command","exp":4102444800}`)
+	noClaimsJWT := encode(`not json`)
+
+	secretProvider := &mocks.SecretProvider{}
+	secretProvider.On("IsJWTValid", validJWT).Return(true, nil)
+	secretProvider.On("IsJWTValid", noClaimsJWT).Return(true, nil)
+
+	tests := []struct {
+		Name     string
+		Token    string
+		Expected AuthInfo
+	}{
+		{"Valid", validJWT, AuthInfo{Subject: "core-command", ExpiresAt: time.Unix(4102444800, 0), Validated: true}},
+		{"Valid - claims not parsable", noClaimsJWT, AuthInfo{Validated: true}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var innerInfo AuthInfo
+			innerFound := false
+			inner := func(w http.ResponseWriter, r *http.Request) {
+				innerInfo, innerFound = AuthInfoFromContext(r.Context())
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+tc.Token)
+
+			recorder := httptest.NewRecorder()
+			VaultAuthenticationHandlerFunc(secretProvider, logger.NewMockClient())(inner)(recorder, req)
+
+			require.Equal(t, http.StatusOK, recorder.Code)
+			require.True(t, innerFound)
+			assert.Equal(t, tc.Expected, innerInfo)
+		})
+	}
+}
+
+func TestVaultAuthenticationHandlerFunc_Leeway(t *testing.T) {
+	encode := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+	now := time.Now()
+
+	tests := []struct {
+		Name           string
+		Leeway         string
+		Claims         string
+		ExpectedStatus int
+	}{
+		{"Valid - expired within leeway", "30s", fmt.Sprintf(`{"exp":%d}`, now.Add(-20*time.Second).Unix()), http.StatusOK},
+		{"Unauthorized - expired outside leeway", "30s", fmt.Sprintf(`{"exp":%d}`, now.Add(-40*time.Second).Unix()), http.StatusUnauthorized},
+		{"Valid - not yet valid within leeway", "30s", fmt.Sprintf(`{"nbf":%d}`, now.Add(20*time.Second).Unix()), http.StatusOK},
+		{"Unauthorized - not yet valid outside leeway", "30s", fmt.Sprintf(`{"nbf":%d}`, now.Add(40*time.Second).Unix()), http.StatusUnauthorized},
+		{"Unauthorized - expired without leeway", "0s", fmt.Sprintf(`{"exp":%d}`, now.Add(-20*time.Second).Unix()), http.StatusUnauthorized},
+		{"Valid - expired within default leeway", "", fmt.Sprintf(`{"exp":%d}`, now.Add(-20*time.Second).Unix()), http.StatusOK},
+		{"Unauthorized - expired outside default leeway", "", fmt.Sprintf(`{"exp":%d}`, now.Add(-40*time.Second).Unix()), http.StatusUnauthorized},
+		{"Valid - default leeway when invalid", "soon", fmt.Sprintf(`{"exp":%d}`, now.Add(-20*time.Second).Unix()), http.StatusOK},
+		{"Unauthorized - default leeway when invalid", "soon", fmt.Sprintf(`{"exp":%d}`, now.Add(-40*time.Second).Unix()), http.StatusUnauthorized},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(secret.EnvAuthJWTLeeway, tc.Leeway)
+
+			token := encode(tc.Claims)
+			secretProvider := &mocks.SecretProvider{}
+			secretProvider.On("IsJWTValid", token).Return(true, nil)
+
+			innerCalled := false
+			inner := func(w http.ResponseWriter, r *http.Request) { innerCalled = true }
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+
+			recorder := httptest.NewRecorder()
+			VaultAuthenticationHandlerFunc(secretProvider, logger.NewMockClient())(inner)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+			assert.Equal(t, tc.ExpectedStatus == http.StatusOK, innerCalled)
+		})
+	}
+}
+
+func TestNilAuthenticationHandlerFunc_NoAuthInfo(t *testing.T) {
+	innerCalled := false
+	inner := func(w http.ResponseWriter, r *http.Request) {
+		innerCalled = true
+		_, found := AuthInfoFromContext(r.Context())
+		assert.False(t, found)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+	recorder := httptest.NewRecorder()
+	NilAuthenticationHandlerFunc()(inner)(recorder, req)
+
+	require.Equal(t, http.StatusOK, recorder.Code)
+	assert.True(t, innerCalled)
+}
@@ -0,0 +1,85 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	"github.com/google/uuid"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+)
+
+const (
+	correlationIdLogKey = "correlation-id"
+	// maxCorrelationIdLength is the maximum length of a correlation ID accepted from a request
+	maxCorrelationIdLength = 128
+)
+
+// validCorrelationId matches the characters accepted in a correlation ID from a request, which covers UUIDs and the
+// common trace ID formats, while excluding the characters, i.e. newlines, which could forge log messages
+var validCorrelationId = regexp.MustCompile(`^[A-Za-z0-9._:-]+$`)
+
+// loggingClientContextKey is the request context key for the request scoped LoggingClient
+type loggingClientContextKey struct{}
+
+// LoggingClientFromContext returns the request scoped LoggingClient, which tags all messages with the request's
+// correlation ID, or nil if one has not been added to the context.
+func LoggingClientFromContext(ctx context.Context) logger.LoggingClient {
+	lc, ok := ctx.Value(loggingClientContextKey{}).(logger.LoggingClient)
+	if !ok {
+		return nil
+	}
+
+	return lc
+}
+
+// CorrelationIdFromContext returns the correlation ID found in the context or an empty string if not present.
+func CorrelationIdFromContext(ctx context.Context) string {
+	correlationId, ok := ctx.Value(common.CorrelationHeader).(string)
+	if !ok {
+		return ""
+	}
+
+	return correlationId
+}
+
+// withCorrelationId extracts the correlation ID from the request, generating a new one if not present, and returns
+// the request with the correlation ID and a request scoped LoggingClient added to its context. The correlation ID is
+// also set on the response so callers can correlate their requests with the service's log messages. A correlation ID
+// which is longer than maxCorrelationIdLength or has characters other than letters, digits, '.', '_', ':' and '-' is
+// replaced by a generated one, since it is included in the log messages.
+func withCorrelationId(w http.ResponseWriter, r *http.Request, lc logger.LoggingClient) (*http.Request, logger.LoggingClient) {
+	correlationId := r.Header.Get(common.CorrelationHeader)
+	if len(correlationId) > maxCorrelationIdLength || !validCorrelationId.MatchString(correlationId) {
+		correlationId = uuid.NewString()
+		r.Header.Set(common.CorrelationHeader, correlationId)
+	}
+
+	w.Header().Set(common.CorrelationHeader, correlationId)
+
+	requestLc := utils.NewContextLogger(lc, correlationIdLogKey, correlationId)
+
+	// The core-contracts clients look up the correlation ID in the context using the header name as the key,
+	// so it must be stored using the same key for the value to be propagated to outbound requests.
+	ctx := context.WithValue(r.Context(), common.CorrelationHeader, correlationId) //nolint: staticcheck
+	ctx = context.WithValue(ctx, loggingClientContextKey{}, requestLc)
+
+	return r.WithContext(ctx), requestLc
+}
@@ -16,9 +16,12 @@
 package handlers
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"os"
 	"strconv"
@@ -115,10 +118,17 @@ func (b *HttpServer) BootstrapHandler(
 		return r.Header.Get(AccessControlRequestMethod) != ""
 	}).HandlerFunc(HandlePreflight(bootstrapConfig.Service.CORSConfiguration))
 
+	tlsConfig, err := NewServiceTLSConfig(*bootstrapConfig.Service, lc)
+	if err != nil {
+		lc.Errorf("unable to configure TLS for the web server: %v", err)
+		return false
+	}
+
 	server := &http.Server{
 		Addr:              addr,
 		Handler:           b.router,
 		ReadHeaderTimeout: 5 * time.Second, // G112: A configured ReadHeaderTimeout in the http.Server averts a potential Slowloris Attack
+		TLSConfig:         tlsConfig,
 	}
 
 	wg.Add(1)
@@ -130,7 +140,11 @@ func (b *HttpServer) BootstrapHandler(
 		lc.Info("Web server shut down")
 	}()
 
-	lc.Info("Web server starting (" + addr + ")")
+	if tlsConfig != nil {
+		lc.Info("Web server starting with TLS (" + addr + ")")
+	} else {
+		lc.Info("Web server starting (" + addr + ")")
+	}
 
 	wg.Add(1)
 	go func() {
@@ -140,7 +154,13 @@ func (b *HttpServer) BootstrapHandler(
 		}()
 
 		b.isRunning = true
-		err := server.ListenAndServe()
+		var err error
+		if tlsConfig != nil {
+			// The certificate is provided by the TLSConfig, so the file names aren't needed
+			err = server.ListenAndServeTLS("", "")
+		} else {
+			err = server.ListenAndServe()
+		}
 		// "Server closed" error occurs when Shutdown above is called in the Done processing, so it can be ignored
 		if err != nil && err != http.ErrServerClosed {
 			// Other errors occur during bootstrapping, like port bind fails, are considered fatal
@@ -188,3 +208,49 @@ func RequestLimitMiddleware(sizeLimit int64, lc logger.LoggingClient) func(http.
 		})
 	}
 }
+
+// MaxBodyBytesHandlerFunc returns middleware which limits the size of the request body to limit bytes, responding with
+// 413 Request Entity Too Large when it is exceeded. Unlike RequestLimitMiddleware, which relies on the Content-Length
+// header, bodies of unknown length, i.e. chunked bodies, are also limited by reading them up to the limit before
+// invoking the inner handler. A limit of zero or less disables the limit.
+func MaxBodyBytesHandlerFunc(limit int64) func(inner http.HandlerFunc) http.HandlerFunc {
+	return func(inner http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			if limit <= 0 || r.Body == nil || r.Body == http.NoBody {
+				inner(w, r)
+				return
+			}
+
+			if r.ContentLength > limit {
+				writeBodyTooLarge(w, limit)
+				return
+			}
+
+			r.Body = http.MaxBytesReader(w, r.Body, limit)
+			if r.ContentLength < 0 {
+				// The size is only known once the body has been read, which must be done before the inner handler
+				// starts writing its response
+				body, err := io.ReadAll(r.Body)
+				if err != nil {
+					var maxBytesErr *http.MaxBytesError
+					if errors.As(err, &maxBytesErr) {
+						writeBodyTooLarge(w, limit)
+						return
+					}
+					http.Error(w, fmt.Sprintf("failed to read request body: %v", err), http.StatusBadRequest)
+					return
+				}
+				r.Body = io.NopCloser(bytes.NewReader(body))
+			}
+
+			inner(w, r)
+		}
+	}
+}
+
+func writeBodyTooLarge(w http.ResponseWriter, limit int64) {
+	response := commonDTO.NewBaseResponse("", fmt.Sprintf("request body exceeds the limit of %d bytes", limit), http.StatusRequestEntityTooLarge)
+	w.Header().Set(common.ContentType, common.ContentTypeJSON)
+	w.WriteHeader(response.StatusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
@@ -12,6 +12,7 @@ import (
 	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -55,3 +56,57 @@ func TestRequestLimitMiddleware(t *testing.T) {
 		}
 	}
 }
+
+func TestMaxBodyBytesHandlerFunc(t *testing.T) {
+	payload := strings.Repeat("x", 1024)
+	tests := []struct {
+		name          string
+		limit         int64
+		chunked       bool
+		errorExpected bool
+	}{
+		{"Valid unlimited size", 0, false, false},
+		{"Valid under limit", 2048, false, false},
+		{"Valid exactly at limit", 1024, false, false},
+		{"Valid chunked under limit", 2048, true, false},
+		{"Invalid over limit", 512, false, true},
+		{"Invalid chunked over limit", 512, true, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			var received string
+			handler := MaxBodyBytesHandlerFunc(testCase.limit)(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				received = string(body)
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req, err := http.NewRequest(http.MethodPost, "/", strings.NewReader(payload))
+			require.NoError(t, err)
+			if testCase.chunked {
+				// Hides the length of the body, as for a chunked request
+				req.Body = io.NopCloser(strings.NewReader(payload))
+				req.ContentLength = -1
+			}
+
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+			resp := recorder.Result()
+
+			if !testCase.errorExpected {
+				assert.Equal(t, http.StatusOK, resp.StatusCode)
+				assert.Equal(t, payload, received)
+				return
+			}
+
+			var res commonDTO.BaseResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+			assert.Equal(t, common.ContentTypeJSON, resp.Header.Get(common.ContentType))
+			assert.Equal(t, http.StatusRequestEntityTooLarge, int(res.StatusCode))
+			assert.Empty(t, received, "inner handler must not be invoked")
+		})
+	}
+}
@@ -0,0 +1,193 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+const (
+	// EnvAuthIntrospectionUrl is the environment variable which, when set, selects token introspection at the URL
+	// rather than JWT validation by the secret store in AutoConfigAuthenticationFunc
+	EnvAuthIntrospectionUrl = "EDGEX_AUTH_INTROSPECTION_URL"
+	// IntrospectionSecretName is the name of the secret with the username and password used to authenticate to the
+	// introspection endpoint by AutoConfigAuthenticationFunc
+	IntrospectionSecretName = "introspection"
+	// IntrospectionCacheTTL is the maximum time the introspection result of a token is cached
+	IntrospectionCacheTTL = 10 * time.Second
+
+	introspectionTimeout      = 10 * time.Second
+	introspectionMaxCacheSize = 1000
+)
+
+// introspectionResponse is the subset of the RFC 7662 introspection response used to authorize the request
+type introspectionResponse struct {
+	Active  bool   `json:"active"`
+	Subject string `json:"sub"`
+	Expires int64  `json:"exp"`
+}
+
+// introspectionResult is the cached introspection result of a token
+type introspectionResult struct {
+	response  introspectionResponse
+	expiresAt time.Time
+}
+
+// tokenIntrospector introspects tokens at an RFC 7662 introspection endpoint, caching the results briefly so a burst
+// of requests with the same token results in a single introspection request
+type tokenIntrospector struct {
+	introspectURL string
+	credentials   config.Credentials
+	client        *http.Client
+	mutex         sync.Mutex
+	cache         map[string]introspectionResult
+}
+
+// IntrospectionAuthenticationHandlerFunc prefixes an existing HandlerFunc with a token check using the RFC 7662
+// introspection endpoint at introspectURL, rather than validating the JWT with the secret store. The token is POSTed
+// to the endpoint, authenticated with the credentials when the Username is set, and the request is unauthorized when
+// the token isn't active. The result is cached for up to IntrospectionCacheTTL, or until the token expires if sooner.
+// As with VaultAuthenticationHandlerFunc, the correlation ID and the AuthInfo of the caller are added to the request
+// context.
+func IntrospectionAuthenticationHandlerFunc(introspectURL string, credentials config.Credentials, lc logger.LoggingClient) func(inner http.HandlerFunc) http.HandlerFunc {
+	introspector := &tokenIntrospector{
+		introspectURL: introspectURL,
+		credentials:   credentials,
+		client:        &http.Client{Timeout: introspectionTimeout},
+		cache:         make(map[string]introspectionResult),
+	}
+	challenge := authChallenge()
+
+	return func(inner http.HandlerFunc) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			r, lc := withCorrelationId(w, r, lc)
+
+			authHeader := r.Header.Get("Authorization")
+			lc.Debugf("Authorizing incoming call to '%s' via token introspection (Authorization len=%d)", r.URL.Path, len(authHeader))
+			authParts := strings.Split(authHeader, " ")
+			if len(authParts) < 2 || !strings.EqualFold(authParts[0], "Bearer") {
+				lc.Errorf("Unable to parse token for call to '%s'; unauthorized", r.URL.Path)
+				unauthorized(w, challenge)
+				return
+			}
+
+			response, err := introspector.introspect(r.Context(), authParts[1])
+			if err != nil {
+				lc.Errorf("Error introspecting token: %v", err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			if !response.Active {
+				lc.Warnf("Request to '%s' UNAUTHORIZED", r.URL.Path)
+				unauthorized(w, challenge)
+				return
+			}
+
+			info := AuthInfo{Subject: response.Subject, Validated: true}
+			if response.Expires > 0 {
+				info.ExpiresAt = time.Unix(response.Expires, 0)
+			}
+			r = r.WithContext(context.WithValue(r.Context(), authInfoContextKey{}, info))
+
+			lc.Debugf("Request to '%s' authorized", r.URL.Path)
+			inner(w, r)
+		}
+	}
+}
+
+// introspect returns the introspection response for the token, from the cache when still fresh
+func (t *tokenIntrospector) introspect(ctx context.Context, token string) (introspectionResponse, error) {
+	now := time.Now()
+
+	t.mutex.Lock()
+	cached, found := t.cache[token]
+	t.mutex.Unlock()
+	if found && now.Before(cached.expiresAt) {
+		return cached.response, nil
+	}
+
+	response, err := t.requestIntrospection(ctx, token)
+	if err != nil {
+		return introspectionResponse{}, err
+	}
+
+	expiresAt := now.Add(IntrospectionCacheTTL)
+	if response.Active && response.Expires > 0 && time.Unix(response.Expires, 0).Before(expiresAt) {
+		expiresAt = time.Unix(response.Expires, 0)
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	if len(t.cache) >= introspectionMaxCacheSize {
+		for cachedToken, result := range t.cache {
+			if !now.Before(result.expiresAt) {
+				delete(t.cache, cachedToken)
+			}
+		}
+	}
+	if len(t.cache) < introspectionMaxCacheSize {
+		t.cache[token] = introspectionResult{response: response, expiresAt: expiresAt}
+	}
+
+	return response, nil
+}
+
+// requestIntrospection POSTs the token to the introspection endpoint and returns its response
+func (t *tokenIntrospector) requestIntrospection(ctx context.Context, token string) (introspectionResponse, error) {
+	form := url.Values{}
+	form.Set("token", token)
+	form.Set("token_type_hint", "access_token")
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, t.introspectURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return introspectionResponse{}, fmt.Errorf("failed to create introspection request: %v", err)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if len(t.credentials.Username) > 0 {
+		request.SetBasicAuth(t.credentials.Username, t.credentials.Password)
+	}
+
+	response, err := t.client.Do(request)
+	if err != nil {
+		return introspectionResponse{}, fmt.Errorf("failed to send introspection request to %s: %v", t.introspectURL, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	if response.StatusCode != http.StatusOK {
+		_, _ = io.Copy(io.Discard, response.Body)
+		return introspectionResponse{}, fmt.Errorf("introspection request to %s failed with status %d", t.introspectURL, response.StatusCode)
+	}
+
+	result := introspectionResponse{}
+	if err := json.NewDecoder(response.Body).Decode(&result); err != nil {
+		return introspectionResponse{}, fmt.Errorf("failed to decode introspection response from %s: %v", t.introspectURL, err)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,175 @@
+//
+// Copyright (C) 2023 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+const (
+	activeToken   = "active.jwt.token"
+	inactiveToken = "inactive.jwt.token"
+	failingToken  = "failing.jwt.token"
+)
+
+// newIntrospectionServer returns a mock introspection endpoint, which counts the requests and requires the username
+// and password when the username isn't empty
+func newIntrospectionServer(t *testing.T, username string, password string, requests *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(requests, 1)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "application/x-www-form-urlencoded", r.Header.Get("Content-Type"))
+
+		if len(username) > 0 {
+			actualUsername, actualPassword, ok := r.BasicAuth()
+			if !ok || actualUsername != username || actualPassword != password {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+		}
+
+		require.NoError(t, r.ParseForm())
+		response := map[string]any{"active": false}
+		switch r.PostForm.Get("token") {
+		case activeToken:
+			response = map[string]any{"active": true, "sub": "core-command", "exp": time.Now().Add(time.Hour).Unix()}
+		case failingToken:
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestIntrospectionAuthenticationHandlerFunc(t *testing.T) {
+	tests := []struct {
+		Name              string
+		AuthHeader        string
+		Credentials       config.Credentials
+		ExpectedStatus    int
+		ExpectedChallenge bool
+	}{
+		{"Valid - active token", "Bearer " + activeToken, config.Credentials{Username: "bootstrap", Password: "secret"}, http.StatusOK, false},
+		{"Unauthorized - inactive token", "Bearer " + inactiveToken, config.Credentials{Username: "bootstrap", Password: "secret"}, http.StatusUnauthorized, true},
+		{"Unauthorized - no token", "", config.Credentials{Username: "bootstrap", Password: "secret"}, http.StatusUnauthorized, true},
+		{"Error - introspection failed", "Bearer " + failingToken, config.Credentials{Username: "bootstrap", Password: "secret"}, http.StatusInternalServerError, false},
+		{"Error - wrong credentials", "Bearer " + activeToken, config.Credentials{Username: "bootstrap", Password: "wrong"}, http.StatusInternalServerError, false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var requests int32
+			server := newIntrospectionServer(t, "bootstrap", "secret", &requests)
+
+			var innerInfo AuthInfo
+			innerFound := false
+			inner := func(w http.ResponseWriter, r *http.Request) {
+				innerInfo, innerFound = AuthInfoFromContext(r.Context())
+			}
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			if len(tc.AuthHeader) > 0 {
+				req.Header.Set("Authorization", tc.AuthHeader)
+			}
+
+			recorder := httptest.NewRecorder()
+			IntrospectionAuthenticationHandlerFunc(server.URL, tc.Credentials, logger.NewMockClient())(inner)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+			assert.Equal(t, tc.ExpectedChallenge, len(recorder.Header().Get("WWW-Authenticate")) > 0)
+			assert.Equal(t, tc.ExpectedStatus == http.StatusOK, innerFound)
+			if innerFound {
+				assert.Equal(t, "core-command", innerInfo.Subject)
+				assert.True(t, innerInfo.Validated)
+				assert.False(t, innerInfo.ExpiresAt.IsZero())
+			}
+		})
+	}
+}
+
+func TestIntrospectionAuthenticationHandlerFunc_Cache(t *testing.T) {
+	var requests int32
+	server := newIntrospectionServer(t, "", "", &requests)
+
+	handler := IntrospectionAuthenticationHandlerFunc(server.URL, config.Credentials{}, logger.NewMockClient())(
+		func(w http.ResponseWriter, r *http.Request) {})
+
+	send := func(token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		recorder := httptest.NewRecorder()
+		handler(recorder, req)
+		return recorder.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		require.Equal(t, http.StatusOK, send(activeToken))
+		require.Equal(t, http.StatusUnauthorized, send(inactiveToken))
+	}
+	assert.Equal(t, int32(2), atomic.LoadInt32(&requests), "results must be cached")
+
+	// Failures aren't cached, so each request is introspected again
+	require.Equal(t, http.StatusInternalServerError, send(failingToken))
+	require.Equal(t, http.StatusInternalServerError, send(failingToken))
+	assert.Equal(t, int32(4), atomic.LoadInt32(&requests))
+}
+
+func TestAutoConfigAuthenticationFunc_Introspection(t *testing.T) {
+	tests := []struct {
+		Name           string
+		SecretErr      error
+		ExpectedStatus int
+	}{
+		{"Valid - credentials from secret", nil, http.StatusOK},
+		{"Error - no credentials secret", errors.New("secret not found"), http.StatusInternalServerError},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var requests int32
+			server := newIntrospectionServer(t, "bootstrap", "secret", &requests)
+			t.Setenv(secret.EnvSecretStore, "true")
+			t.Setenv("EDGEX_DISABLE_JWT_VALIDATION", "false")
+			t.Setenv(EnvAuthIntrospectionUrl, server.URL)
+
+			secretProvider := &mocks.SecretProvider{}
+			secrets := map[string]string{secret.UsernameKey: "bootstrap", secret.PasswordKey: "secret"}
+			if tc.SecretErr != nil {
+				secrets = nil
+			}
+			secretProvider.On("GetSecret", IntrospectionSecretName, secret.UsernameKey, secret.PasswordKey).Return(secrets, tc.SecretErr)
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v3/ping", nil)
+			req.Header.Set("Authorization", "Bearer "+activeToken)
+
+			recorder := httptest.NewRecorder()
+			AutoConfigAuthenticationFunc(secretProvider, logger.NewMockClient())(func(w http.ResponseWriter, r *http.Request) {})(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+			assert.Equal(t, int32(1), atomic.LoadInt32(&requests))
+			secretProvider.AssertNotCalled(t, "IsJWTValid", activeToken)
+		})
+	}
+}
@@ -0,0 +1,162 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+const (
+	ConfigProviderComponentName = "ConfigurationProvider"
+	SecretStoreComponentName    = "SecretStore"
+)
+
+// ReadinessResponse is the response returned by the readiness handler.
+type ReadinessResponse struct {
+	commonDTO.BaseResponse `json:",inline"`
+	// Failures contains the reason each failing component is not ready, keyed by component name
+	Failures map[string]string `json:"failures,omitempty"`
+}
+
+// healthCheckRegistry implements the interfaces.HealthCheckRegistry contract
+type healthCheckRegistry struct {
+	checks map[string]interfaces.HealthCheck
+	mutex  sync.RWMutex
+}
+
+// NewHealthCheckRegistry returns a new, empty interfaces.HealthCheckRegistry
+func NewHealthCheckRegistry() interfaces.HealthCheckRegistry {
+	return &healthCheckRegistry{
+		checks: make(map[string]interfaces.HealthCheck),
+	}
+}
+
+// Register registers a health check for the named component
+func (r *healthCheckRegistry) Register(name string, check interfaces.HealthCheck) error {
+	if len(name) == 0 {
+		return errors.New("health check component name must not be empty")
+	}
+
+	if check == nil {
+		return fmt.Errorf("health check for component '%s' must not be nil", name)
+	}
+
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if _, exists := r.checks[name]; exists {
+		return fmt.Errorf("health check already registered for component '%s'", name)
+	}
+
+	r.checks[name] = check
+	return nil
+}
+
+// Unregister removes the health check for the named component
+func (r *healthCheckRegistry) Unregister(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.checks, name)
+}
+
+// Checks returns a copy of all the registered health checks keyed by component name
+func (r *healthCheckRegistry) Checks() map[string]interfaces.HealthCheck {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	checks := make(map[string]interfaces.HealthCheck, len(r.checks))
+	for name, check := range r.checks {
+		checks[name] = check
+	}
+
+	return checks
+}
+
+// ReadinessHandlerFunc returns a HandlerFunc that reports the readiness of the service. It probes the Configuration
+// Provider (if used), the Secret Store (if security is enabled) and all the health checks registered in the
+// interfaces.HealthCheckRegistry found in the DIC. A 200 is returned if all components are ready, otherwise a 503
+// is returned with the failing components listed in the response.
+func ReadinessHandlerFunc(dic *di.Container) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		lc := container.LoggingClientFrom(dic.Get)
+
+		failures := make(map[string]string)
+		for name, check := range readinessChecks(dic) {
+			if err := check(); err != nil {
+				failures[name] = err.Error()
+			}
+		}
+
+		response := ReadinessResponse{
+			BaseResponse: commonDTO.NewBaseResponse("", "", http.StatusOK),
+		}
+
+		if len(failures) > 0 {
+			response.BaseResponse = commonDTO.NewBaseResponse("", "one or more components are not ready", http.StatusServiceUnavailable)
+			response.Failures = failures
+			if lc != nil {
+				lc.Warnf("Readiness check failed: %v", failures)
+			}
+		}
+
+		w.Header().Set(common.ContentType, common.ContentTypeJSON)
+		w.WriteHeader(response.StatusCode)
+		if err := json.NewEncoder(w).Encode(response); err != nil && lc != nil {
+			lc.Errorf("Error encoding the readiness response: %v", err)
+		}
+	}
+}
+
+// readinessChecks gathers the built-in dependency checks along with the checks from the registry in the DIC.
+func readinessChecks(dic *di.Container) map[string]interfaces.HealthCheck {
+	checks := make(map[string]interfaces.HealthCheck)
+
+	if registry := container.HealthCheckRegistryFrom(dic.Get); registry != nil {
+		checks = registry.Checks()
+	}
+
+	if configClient := container.ConfigClientFrom(dic.Get); configClient != nil {
+		checks[ConfigProviderComponentName] = func() error {
+			if !configClient.IsAlive() {
+				return errors.New("configuration provider is not available")
+			}
+			return nil
+		}
+	}
+
+	if secretProvider := container.SecretProviderExtFrom(dic.Get); secretProvider != nil && secret.IsSecurityEnabled() {
+		checks[SecretStoreComponentName] = func() error {
+			if _, err := secretProvider.ListSecretNames(); err != nil {
+				return fmt.Errorf("secret store is not reachable: %v", err)
+			}
+			return nil
+		}
+	}
+
+	return checks
+}
@@ -0,0 +1,118 @@
+//
+// Copyright (C) 2023 Intel Corporation
+//
+// SPDX-License-Identifier: Apache-2.0
+
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	configMocks "github.com/edgexfoundry/go-mod-configuration/v3/configuration/mocks"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces/mocks"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/secret"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestReadinessHandlerFunc(t *testing.T) {
+	t.Setenv(secret.EnvSecretStore, "true")
+
+	tests := []struct {
+		Name             string
+		ConfigAlive      bool
+		SecretStoreErr   error
+		CustomCheckErr   error
+		ExpectedStatus   int
+		ExpectedFailures []string
+	}{
+		{"All ready", true, nil, nil, http.StatusOK, nil},
+		{"Config Provider down", false, nil, nil, http.StatusServiceUnavailable, []string{ConfigProviderComponentName}},
+		{"Secret Store down", true, errors.New("connection refused"), nil, http.StatusServiceUnavailable, []string{SecretStoreComponentName}},
+		{"Custom check failing", true, nil, errors.New("not connected"), http.StatusServiceUnavailable, []string{"MessageBus"}},
+		{"Multiple down", false, nil, errors.New("not connected"), http.StatusServiceUnavailable, []string{ConfigProviderComponentName, "MessageBus"}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			configClient := &configMocks.Client{}
+			configClient.On("IsAlive").Return(tc.ConfigAlive)
+
+			secretProvider := &mocks.SecretProvider{}
+			secretProvider.On("ListSecretNames").Return([]string{}, tc.SecretStoreErr)
+
+			registry := NewHealthCheckRegistry()
+			err := registry.Register("MessageBus", func() error { return tc.CustomCheckErr })
+			require.NoError(t, err)
+
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} {
+					return logger.NewMockClient()
+				},
+				container.ConfigClientInterfaceName: func(get di.Get) interface{} {
+					return configClient
+				},
+				container.SecretProviderExtName: func(get di.Get) interface{} {
+					return secretProvider
+				},
+				container.HealthCheckRegistryName: func(get di.Get) interface{} {
+					return registry
+				},
+			})
+
+			recorder := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+			ReadinessHandlerFunc(dic)(recorder, req)
+
+			require.Equal(t, tc.ExpectedStatus, recorder.Code)
+
+			var response ReadinessResponse
+			err = json.Unmarshal(recorder.Body.Bytes(), &response)
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedStatus, response.StatusCode)
+			assert.Len(t, response.Failures, len(tc.ExpectedFailures))
+			for _, name := range tc.ExpectedFailures {
+				assert.Contains(t, response.Failures, name)
+			}
+		})
+	}
+}
+
+func TestReadinessHandlerFunc_NoDependencies(t *testing.T) {
+	dic := di.NewContainer(di.ServiceConstructorMap{})
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/ready", nil)
+	ReadinessHandlerFunc(dic)(recorder, req)
+
+	assert.Equal(t, http.StatusOK, recorder.Code)
+}
+
+func TestHealthCheckRegistry(t *testing.T) {
+	registry := NewHealthCheckRegistry()
+	check := func() error { return nil }
+
+	require.NoError(t, registry.Register("Database", check))
+	require.Error(t, registry.Register("Database", check), "duplicate registration expected to fail")
+	require.Error(t, registry.Register("", check), "empty name expected to fail")
+	require.Error(t, registry.Register("Nil", nil), "nil check expected to fail")
+
+	checks := registry.Checks()
+	require.Len(t, checks, 1)
+
+	// Modifying the returned copy must not modify the registry
+	checks["Other"] = interfaces.HealthCheck(check)
+	assert.Len(t, registry.Checks(), 1)
+
+	registry.Unregister("Database")
+	assert.Empty(t, registry.Checks())
+}
@@ -0,0 +1,158 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+)
+
+// TimeoutHandlerFunc returns middleware which limits the time the inner handler has to respond to timeout. The inner
+// handler is invoked with a request whose context is cancelled once the timeout elapses, so the work it does using the
+// context is abandoned. When the timeout elapses before the inner handler returns, 503 Service
+// Unavailable is responded with a message stating the timeout and anything the inner handler writes afterwards is
+// discarded, with its writes returning http.ErrHandlerTimeout. The inner handler's response is buffered, so it is only
+// sent once the inner handler returns. A timeout of zero or less disables the timeout. It composes with the other
+// middleware, i.e. TimeoutHandlerFunc(timeout)(authenticationHook(handler)), in which case the authentication is also
+// subject to the timeout.
+func TimeoutHandlerFunc(timeout time.Duration) func(inner http.HandlerFunc) http.HandlerFunc {
+	return func(inner http.HandlerFunc) http.HandlerFunc {
+		if timeout <= 0 {
+			return inner
+		}
+
+		return func(w http.ResponseWriter, r *http.Request) {
+			ctx, cancel := context.WithCancel(r.Context())
+			defer cancel()
+
+			tw := &timeoutWriter{header: make(http.Header)}
+
+			// The inner handler's writes are rejected from the moment the timeout elapses, before its context is
+			// cancelled, so it can't write its response while the timeout response is written
+			timer := time.AfterFunc(timeout, func() {
+				tw.mutex.Lock()
+				tw.timedOut = true
+				tw.mutex.Unlock()
+				cancel()
+			})
+			defer timer.Stop()
+
+			done := make(chan struct{})
+			panicked := make(chan any, 1)
+			go func() {
+				defer func() {
+					if p := recover(); p != nil {
+						panicked <- p
+					}
+				}()
+				inner(tw, r.WithContext(ctx))
+				close(done)
+			}()
+
+			select {
+			case p := <-panicked:
+				panic(p)
+
+			case <-done:
+			case <-ctx.Done():
+			}
+
+			tw.mutex.Lock()
+			defer tw.mutex.Unlock()
+
+			// The request's context may also be cancelled by the client, in which case the inner handler is abandoned
+			// the same as when the timeout elapses
+			if ctx.Err() != nil {
+				select {
+				case <-done:
+				default:
+					tw.timedOut = true
+				}
+			}
+
+			if tw.timedOut {
+				writeRequestTimedOut(w, timeout)
+				return
+			}
+
+			dst := w.Header()
+			for key, values := range tw.header {
+				dst[key] = values
+			}
+			if !tw.wroteHeader {
+				tw.code = http.StatusOK
+			}
+			w.WriteHeader(tw.code)
+			_, _ = w.Write(tw.body.Bytes())
+		}
+	}
+}
+
+func writeRequestTimedOut(w http.ResponseWriter, timeout time.Duration) {
+	response := commonDTO.NewBaseResponse("", fmt.Sprintf("request timed out after %s", timeout), http.StatusServiceUnavailable)
+	w.Header().Set(common.ContentType, common.ContentTypeJSON)
+	w.WriteHeader(response.StatusCode)
+	_ = json.NewEncoder(w).Encode(response)
+}
+
+// timeoutWriter buffers the inner handler's response, so it can be discarded when the timeout elapses
+type timeoutWriter struct {
+	mutex       sync.Mutex
+	header      http.Header
+	body        bytes.Buffer
+	code        int
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) Header() http.Header {
+	return tw.header
+}
+
+func (tw *timeoutWriter) Write(data []byte) (int, error) {
+	tw.mutex.Lock()
+	defer tw.mutex.Unlock()
+
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	if !tw.wroteHeader {
+		tw.writeHeaderLocked(http.StatusOK)
+	}
+	return tw.body.Write(data)
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	tw.mutex.Lock()
+	defer tw.mutex.Unlock()
+
+	if tw.timedOut || tw.wroteHeader {
+		return
+	}
+	tw.writeHeaderLocked(code)
+}
+
+func (tw *timeoutWriter) writeHeaderLocked(code int) {
+	tw.wroteHeader = true
+	tw.code = code
+}
@@ -0,0 +1,88 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/common"
+	commonDTO "github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTimeoutHandlerFunc(t *testing.T) {
+	tests := []struct {
+		name          string
+		timeout       time.Duration
+		delay         time.Duration
+		errorExpected bool
+	}{
+		{"Valid fast handler", time.Second, 0, false},
+		{"Valid timeout disabled", 0, 50 * time.Millisecond, false},
+		{"Invalid slow handler", 50 * time.Millisecond, 5 * time.Second, true},
+	}
+
+	for _, testCase := range tests {
+		t.Run(testCase.name, func(t *testing.T) {
+			writeErr := make(chan error, 1)
+			inner := func(w http.ResponseWriter, r *http.Request) {
+				select {
+				case <-time.After(testCase.delay):
+				case <-r.Context().Done():
+				}
+				w.Header().Set("X-Inner", "true")
+				w.WriteHeader(http.StatusCreated)
+				_, err := w.Write([]byte("created"))
+				writeErr <- err
+			}
+			handler := TimeoutHandlerFunc(testCase.timeout)(NilAuthenticationHandlerFunc()(inner))
+
+			req, err := http.NewRequest(http.MethodGet, "/", http.NoBody)
+			require.NoError(t, err)
+			recorder := httptest.NewRecorder()
+			handler(recorder, req)
+			resp := recorder.Result()
+
+			if !testCase.errorExpected {
+				assert.Equal(t, http.StatusCreated, resp.StatusCode)
+				assert.Equal(t, "true", resp.Header.Get("X-Inner"))
+				assert.Equal(t, "created", recorder.Body.String())
+				assert.NoError(t, <-writeErr)
+				return
+			}
+
+			var res commonDTO.BaseResponse
+			require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &res))
+			assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+			assert.Equal(t, common.ContentTypeJSON, resp.Header.Get(common.ContentType))
+			assert.Equal(t, http.StatusServiceUnavailable, int(res.StatusCode))
+			assert.Contains(t, res.Message, "request timed out after 50ms")
+			assert.Empty(t, resp.Header.Get("X-Inner"), "inner handler's response must be discarded")
+
+			// The inner handler's context is cancelled, so it returns promptly and its late write is rejected
+			select {
+			case err := <-writeErr:
+				assert.ErrorIs(t, err, http.ErrHandlerTimeout)
+			case <-time.After(time.Second):
+				require.Fail(t, "inner handler's context wasn't cancelled")
+			}
+		})
+	}
+}
@@ -0,0 +1,139 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+// NewServiceTLSConfig creates the TLS configuration for serving HTTPS from the Service CertPath and KeyPath settings.
+// The certificate and key are loaded immediately, so invalid settings are reported at startup, and reloaded whenever
+// either file changes, so the certificate can be rotated without restarting the listener. nil is returned if neither
+// setting is set.
+func NewServiceTLSConfig(serviceInfo config.ServiceInfo, lc logger.LoggingClient) (*tls.Config, error) {
+	hasCert := len(serviceInfo.CertPath) > 0
+	hasKey := len(serviceInfo.KeyPath) > 0
+
+	switch {
+	case hasCert && !hasKey:
+		return nil, errors.New("Service KeyPath must be set when CertPath is set")
+	case hasKey && !hasCert:
+		return nil, errors.New("Service CertPath must be set when KeyPath is set")
+	case !hasCert:
+		return nil, nil
+	}
+
+	reloader := &certificateReloader{
+		certPath: serviceInfo.CertPath,
+		keyPath:  serviceInfo.KeyPath,
+		lc:       lc,
+	}
+	if err := reloader.reload(); err != nil {
+		return nil, err
+	}
+
+	return &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate,
+	}, nil
+}
+
+// certificateReloader provides the certificate loaded from the cert and key files, reloading it when they change
+type certificateReloader struct {
+	certPath    string
+	keyPath     string
+	lc          logger.LoggingClient
+	mutex       sync.Mutex
+	certificate *tls.Certificate
+	// certModTime and keyModTime are the modification times of the files when the certificate was last loaded
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// GetCertificate returns the certificate, first reloading it if the cert or key file has changed since it was last
+// loaded. The previous certificate continues to be used when reloading fails, i.e. when only one of the files has
+// been replaced so far, and reloading is retried on the next handshake.
+func (r *certificateReloader) GetCertificate(_ *tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.changed() {
+		if err := r.reloadLocked(); err != nil {
+			r.lc.Errorf("failed to reload TLS certificate, continuing to use previous certificate: %v", err)
+		} else {
+			r.lc.Infof("TLS certificate reloaded from %s", r.certPath)
+		}
+	}
+
+	return r.certificate, nil
+}
+
+func (r *certificateReloader) reload() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.reloadLocked()
+}
+
+func (r *certificateReloader) reloadLocked() error {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certPath, r.keyPath)
+	if err != nil {
+		return fmt.Errorf("failed to load TLS certificate %s and key %s: %v", r.certPath, r.keyPath, err)
+	}
+
+	r.certificate = &certificate
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	return nil
+}
+
+// changed returns whether the cert or key file has been modified since the certificate was last loaded
+func (r *certificateReloader) changed() bool {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		r.lc.Errorf("failed to check TLS certificate for changes: %v", err)
+		return false
+	}
+
+	return !certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime)
+}
+
+func (r *certificateReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certPath)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to read TLS certificate %s: %v", r.certPath, err)
+	}
+
+	keyInfo, err := os.Stat(r.keyPath)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to read TLS key %s: %v", r.keyPath, err)
+	}
+
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
@@ -0,0 +1,159 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package handlers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io"
+	"log"
+	"math/big"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+func TestNewServiceTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	writeTestCertificate(t, certFile, keyFile, "original", time.Now())
+
+	tests := []struct {
+		Name          string
+		CertPath      string
+		KeyPath       string
+		ExpectNil     bool
+		ExpectedError string
+	}{
+		{"Valid - TLS", certFile, keyFile, false, ""},
+		{"Valid - no TLS", "", "", true, ""},
+		{"Invalid - cert without key", certFile, "", true, "KeyPath must be set when CertPath is set"},
+		{"Invalid - key without cert", "", keyFile, true, "CertPath must be set when KeyPath is set"},
+		{"Invalid - bad key file", certFile, certFile, true, "failed to load TLS certificate"},
+		{"Invalid - missing cert file", filepath.Join(dir, "missing.crt"), keyFile, true, "failed to read TLS certificate"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			tlsConfig, err := NewServiceTLSConfig(config.ServiceInfo{CertPath: tc.CertPath, KeyPath: tc.KeyPath}, logger.NewMockClient())
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.ExpectNil {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+
+			require.NotNil(t, tlsConfig)
+			assert.NotNil(t, tlsConfig.GetCertificate)
+		})
+	}
+}
+
+func TestNewServiceTLSConfig_Reload(t *testing.T) {
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "server.crt")
+	keyFile := filepath.Join(dir, "server.key")
+	now := time.Now()
+	writeTestCertificate(t, certFile, keyFile, "original", now)
+
+	tlsConfig, err := NewServiceTLSConfig(config.ServiceInfo{CertPath: certFile, KeyPath: keyFile}, logger.NewMockClient())
+	require.NoError(t, err)
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", tlsConfig)
+	require.NoError(t, err)
+	server := &http.Server{
+		Handler:           http.NotFoundHandler(),
+		ReadHeaderTimeout: time.Second,
+		// The client closes each connection once the handshake completes, which the server would log as an error
+		ErrorLog: log.New(io.Discard, "", 0),
+	}
+	go func() { _ = server.Serve(listener) }()
+	defer func() { _ = server.Close() }()
+
+	assert.Equal(t, "original", servedCertificateName(t, listener.Addr().String()))
+
+	// The cert is rotated while the listener keeps running
+	writeTestCertificate(t, certFile, keyFile, "rotated", now.Add(time.Minute))
+	assert.Equal(t, "rotated", servedCertificateName(t, listener.Addr().String()))
+
+	// A cert which can't be loaded, i.e. while the key hasn't been replaced yet, leaves the previous cert in use
+	require.NoError(t, os.WriteFile(keyFile, []byte("not a key"), 0600))
+	touch(t, keyFile, now.Add(2*time.Minute))
+	assert.Equal(t, "rotated", servedCertificateName(t, listener.Addr().String()))
+}
+
+// servedCertificateName returns the common name of the certificate presented by the TLS server at the address
+func servedCertificateName(t *testing.T, addr string) string {
+	conn, err := tls.Dial("tcp", addr, &tls.Config{InsecureSkipVerify: true}) //nolint:gosec // self-signed test certificate
+	require.NoError(t, err)
+	defer func() { _ = conn.Close() }()
+
+	peerCertificates := conn.ConnectionState().PeerCertificates
+	require.NotEmpty(t, peerCertificates)
+	return peerCertificates[0].Subject.CommonName
+}
+
+// writeTestCertificate writes a self-signed certificate with the common name and its private key to the PEM files.
+// The files' modification times are set to the modTime, so each change is detected regardless of the file system's
+// time resolution.
+func writeTestCertificate(t *testing.T, certFile string, keyFile string, commonName string, modTime time.Time) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: commonName},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		BasicConstraintsValid: true,
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+
+	touch(t, certFile, modTime)
+	touch(t, keyFile, modTime)
+}
+
+func touch(t *testing.T, file string, modTime time.Time) {
+	require.NoError(t, os.Chtimes(file, modTime, modTime))
+}
@@ -65,3 +65,20 @@ type Configuration interface {
 	// GetWritablePtr gets the config.WritablePtr section from the ConfigurationStruct
 	GetWritablePtr() any
 }
+
+// Validator is an optional interface a Configuration implementation can satisfy to enforce service specific
+// invariants, i.e. "if TLS is enabled then the certificate path is required". The bootstrap calls Validate once the
+// configuration has been fully loaded and merged. A non-nil error aborts the service's start-up.
+type Validator interface {
+	// Validate returns an error describing the first invariant of the configuration that is not met.
+	Validate() error
+}
+
+// SecretStoreConfiguration is an optional interface a Configuration implementation can satisfy to source the
+// SecretStore settings from its SecretStore section, so they can be managed centrally, i.e. in the common
+// configuration. The settings set in the section are used in place of the defaults and the environment variable
+// overrides still take precedence.
+type SecretStoreConfiguration interface {
+	// GetSecretStoreInfo returns the SecretStore section, or nil if the configuration doesn't have one.
+	GetSecretStoreInfo() *config.SecretStoreInfo
+}
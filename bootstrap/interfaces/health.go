@@ -0,0 +1,29 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package interfaces
+
+// HealthCheck defines the signature of a function that probes a component's health. A non-nil error
+// indicates the component is not healthy and describes why.
+type HealthCheck func() error
+
+// HealthCheckRegistry manages the set of component health checks that are probed to determine service readiness.
+type HealthCheckRegistry interface {
+	// Register registers a health check for the named component
+	Register(name string, check HealthCheck) error
+	// Unregister removes the health check for the named component
+	Unregister(name string)
+	// Checks returns a copy of all the registered health checks keyed by component name
+	Checks() map[string]HealthCheck
+}
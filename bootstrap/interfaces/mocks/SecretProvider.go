@@ -3,9 +3,10 @@
 package mocks
 
 import (
-	time "time"
-
+	interfaces "github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // SecretProvider is an autogenerated mock type for the SecretProvider type
@@ -42,6 +43,39 @@ func (_m *SecretProvider) GetAccessToken(tokenType string, serviceKey string) (s
 	return r0, r1
 }
 
+// GetAccessTokens provides a mock function with given fields: tokenType, serviceKeys
+func (_m *SecretProvider) GetAccessTokens(tokenType string, serviceKeys ...string) (map[string]string, error) {
+	_va := make([]interface{}, len(serviceKeys))
+	for _i := range serviceKeys {
+		_va[_i] = serviceKeys[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, tokenType)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 map[string]string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, ...string) (map[string]string, error)); ok {
+		return rf(tokenType, serviceKeys...)
+	}
+	if rf, ok := ret.Get(0).(func(string, ...string) map[string]string); ok {
+		r0 = rf(tokenType, serviceKeys...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, ...string) error); ok {
+		r1 = rf(tokenType, serviceKeys...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMetricsToRegister provides a mock function with given fields:
 func (_m *SecretProvider) GetMetricsToRegister() map[string]interface{} {
 	ret := _m.Called()
@@ -189,6 +223,46 @@ func (_m *SecretProvider) ListSecretNames() ([]string, error) {
 	return r0, r1
 }
 
+// ListSecretsMetadata provides a mock function with given fields:
+func (_m *SecretProvider) ListSecretsMetadata() ([]interfaces.SecretMetadata, error) {
+	ret := _m.Called()
+
+	var r0 []interfaces.SecretMetadata
+	var r1 error
+	if rf, ok := ret.Get(0).(func() ([]interfaces.SecretMetadata, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() []interfaces.SecretMetadata); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]interfaces.SecretMetadata)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func() error); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchSecret provides a mock function with given fields: secretName, updates
+func (_m *SecretProvider) PatchSecret(secretName string, updates map[string]string) error {
+	ret := _m.Called(secretName, updates)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, map[string]string) error); ok {
+		r0 = rf(secretName, updates)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RegisteredSecretUpdatedCallback provides a mock function with given fields: secretName, callback
 func (_m *SecretProvider) RegisteredSecretUpdatedCallback(secretName string, callback func(string)) error {
 	ret := _m.Called(secretName, callback)
@@ -203,6 +277,56 @@ func (_m *SecretProvider) RegisteredSecretUpdatedCallback(secretName string, cal
 	return r0
 }
 
+// RegisterTokenRenewedCallback provides a mock function with given fields: callback
+func (_m *SecretProvider) RegisterTokenRenewedCallback(callback func(string)) {
+	_m.Called(callback)
+}
+
+// RenewToken provides a mock function with given fields:
+func (_m *SecretProvider) RenewToken() error {
+	ret := _m.Called()
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func() error); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// SecretStoreHealth provides a mock function with given fields:
+func (_m *SecretProvider) SecretStoreHealth() (bool, bool, error) {
+	ret := _m.Called()
+
+	var r0 bool
+	var r1 bool
+	var r2 error
+	if rf, ok := ret.Get(0).(func() (bool, bool, error)); ok {
+		return rf()
+	}
+	if rf, ok := ret.Get(0).(func() bool); ok {
+		r0 = rf()
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func() bool); ok {
+		r1 = rf()
+	} else {
+		r1 = ret.Get(1).(bool)
+	}
+
+	if rf, ok := ret.Get(2).(func() error); ok {
+		r2 = rf()
+	} else {
+		r2 = ret.Error(2)
+	}
+
+	return r0, r1, r2
+}
+
 // SecretUpdatedAtSecretName provides a mock function with given fields: secretName
 func (_m *SecretProvider) SecretUpdatedAtSecretName(secretName string) {
 	_m.Called(secretName)
@@ -14,6 +14,10 @@
 
 import "time"
 
+// DeleteSecretKeyValue is the value used in the updates passed to SecretProvider.PatchSecret for the keys to be removed
+// from the secret.
+const DeleteSecretKeyValue = "<<delete-secret-key>>"
+
 // SecretProvider defines the contract for secret provider implementations that
 // allow secrets to be retrieved/stored from/to a services Secret Store and other secret related APIs.
 // This interface is limited to the APIs that individual service code need.
@@ -21,6 +25,11 @@ type SecretProvider interface {
 	// StoreSecret stores new secrets into the service's SecretStore at the specified secretName.
 	StoreSecret(secretName string, secrets map[string]string) error
 
+	// PatchSecret merges the updates into the secret at the specified secretName, so that keys not in the updates are
+	// preserved. Keys whose value is DeleteSecretKeyValue are removed from the secret. The secret is created if it
+	// doesn't already exist.
+	PatchSecret(secretName string, updates map[string]string) error
+
 	// GetSecret retrieves secrets from the service's SecretStore at the specified secretName.
 	GetSecret(secretName string, keys ...string) (map[string]string, error)
 
@@ -33,7 +42,8 @@ type SecretProvider interface {
 	// HasSecret returns true if the service's SecretStore contains a secret at the specified secretName.
 	HasSecret(secretName string) (bool, error)
 
-	// RegisteredSecretUpdatedCallback registers a callback for a secret.
+	// RegisteredSecretUpdatedCallback registers a callback for a secret. The secretName may be a glob pattern
+	// (see path.Match), i.e. "mqtt-*", to register a single callback for all matching secrets.
 	RegisteredSecretUpdatedCallback(secretName string, callback func(path string)) error
 
 	// DeregisterSecretUpdatedCallback removes a secret's registered callback secretName.
@@ -52,6 +62,11 @@ type SecretProviderExt interface {
 	// Service key is use as the access token role which must have be previously setup.
 	GetAccessToken(tokenType string, serviceKey string) (string, error)
 
+	// GetAccessTokens returns the access tokens for the specified token type and service keys, keyed by service key,
+	// for services acting on behalf of several roles. The tokens are requested concurrently. The tokens obtained are
+	// returned along with the errors for the service keys whose token couldn't be obtained.
+	GetAccessTokens(tokenType string, serviceKeys ...string) (map[string]string, error)
+
 	// SecretUpdatedAtSecretName performs updates and callbacks for an updated secret or secretName.
 	SecretUpdatedAtSecretName(secretName string)
 
@@ -63,4 +78,39 @@ type SecretProviderExt interface {
 
 	// IsJWTValid evaluates a given JWT and returns a true/false if the JWT is valid (i.e. belongs to us and current) or not
 	IsJWTValid(jwt string) (bool, error)
+
+	// ListSecretsMetadata returns the name, key names and last updated time for all the secrets of the current service.
+	// Secret values are never returned.
+	ListSecretsMetadata() ([]SecretMetadata, error)
+
+	// RenewToken forces an immediate renewal of the secret store token, i.e. after the token's policy has been changed,
+	// rather than waiting for the current token to expire.
+	RenewToken() error
+
+	// SecretStoreHealth probes the secret store's health without using the secret store token, so it can be used by
+	// readiness checks and to clarify startup failures even when the token is missing or invalid. reachable is false
+	// when the secret store couldn't be contacted, in which case err describes why.
+	SecretStoreHealth() (sealed bool, reachable bool, err error)
+
+	// RegisterTokenRenewedCallback registers a callback which is invoked with the new self JWT after each successful
+	// renewal of the secret store token, either by RenewToken or by replacing the token once it has expired, so
+	// components holding the token, i.e. sidecars or plugins, can be updated.
+	// The callback is never invoked when security is disabled.
+	RegisterTokenRenewedCallback(callback func(newSelfJWT string))
 }
+
+// SecretMetadata contains the non-sensitive information about a secret in the service's SecretStore.
+type SecretMetadata struct {
+	// SecretName is the name of the secret
+	SecretName string
+	// Keys is the list of key names contained in the secret.
+	Keys []string
+	// LastUpdated is the last time the secret is known to have been updated. It is zero for secrets that have not been
+	// updated since the provider was created, since the secret store doesn't report when secrets were last updated.
+	LastUpdated time.Time
+}
+
+// SecretNameResolver returns the path in the secret store of the secrets in the namespace named secretName, i.e. the
+// service's StoreName or one of its FallbackNamespaces, for the service with the serviceKey. The default resolver
+// returns "/v1/secret/edgex/<secretName>".
+type SecretNameResolver func(serviceKey string, secretName string) string
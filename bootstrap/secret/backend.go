@@ -0,0 +1,59 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"context"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+// BackendFactory creates the SecretProvider for a custom secret store backend. secretStoreInfo is the service's
+// SecretStore configuration with the environment overrides applied.
+type BackendFactory func(ctx context.Context, secretStoreInfo *config.SecretStoreInfo, dic *di.Container,
+	serviceKey string) (interfaces.SecretProviderExt, error)
+
+var (
+	backendsMutex sync.RWMutex
+	backends      = make(map[string]BackendFactory)
+)
+
+// RegisterBackend registers the factory for the secret store backend with the name, which NewSecretProvider uses
+// when security is enabled and the SecretStore Type matches the name. The built-in Vault secret store is used for
+// types with no registered backend. Registering a backend with the same name as a previous one replaces it, and a nil
+// factory removes it. Backends must be registered before NewSecretProvider is called.
+func RegisterBackend(name string, factory BackendFactory) {
+	backendsMutex.Lock()
+	defer backendsMutex.Unlock()
+
+	if factory == nil {
+		delete(backends, name)
+		return
+	}
+
+	backends[name] = factory
+}
+
+// getBackend returns the factory for the secret store backend registered with the name, if any
+func getBackend(name string) (BackendFactory, bool) {
+	backendsMutex.RLock()
+	defer backendsMutex.RUnlock()
+
+	factory, found := backends[name]
+	return factory, found
+}
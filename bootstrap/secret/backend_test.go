@@ -0,0 +1,141 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
+)
+
+func TestNewSecretProvider_RegisteredBackend(t *testing.T) {
+	fakeProvider := NewInsecureProvider(nil, logger.MockLogger{})
+
+	tests := []struct {
+		Name          string
+		FactoryError  error
+		ExpectedError string
+	}{
+		{"Valid", nil, ""},
+		{"Invalid - factory failed", errors.New("backend unavailable"), "backend unavailable"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			var actualInfo *config.SecretStoreInfo
+			var actualServiceKey string
+			RegisterBackend("fake", func(ctx context.Context, secretStoreInfo *config.SecretStoreInfo, dic *di.Container,
+				serviceKey string) (interfaces.SecretProviderExt, error) {
+				actualInfo = secretStoreInfo
+				actualServiceKey = serviceKey
+				if tc.FactoryError != nil {
+					return nil, tc.FactoryError
+				}
+				return fakeProvider, nil
+			})
+			t.Cleanup(func() { RegisterBackend("fake", nil) })
+
+			t.Setenv(EnvSecretStore, "true")
+			t.Setenv("SECRETSTORE_TYPE", "fake")
+			t.Setenv("SECRETSTORE_HOST", "fake-store")
+
+			lc := logger.NewMockClient()
+			dic := di.NewContainer(di.ServiceConstructorMap{
+				container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+			})
+
+			actual, err := NewSecretProvider(nil, environment.NewVariables(lc), context.Background(), startup.NewStartUpTimer("UnitTest"), dic, "testServiceKey")
+
+			require.NotNil(t, actualInfo)
+			assert.Equal(t, "fake-store", actualInfo.Host)
+			assert.Equal(t, "testServiceKey", actualServiceKey)
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				assert.Nil(t, container.SecretProviderExtFrom(dic.Get))
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Same(t, fakeProvider, actual)
+			assert.Same(t, fakeProvider, container.SecretProviderExtFrom(dic.Get))
+		})
+	}
+}
+
+func TestNewSecretProvider_CustomLoggingClient(t *testing.T) {
+	fakeProvider := NewInsecureProvider(nil, logger.MockLogger{})
+	RegisterBackend("fake", func(ctx context.Context, secretStoreInfo *config.SecretStoreInfo, dic *di.Container,
+		serviceKey string) (interfaces.SecretProviderExt, error) {
+		return fakeProvider, nil
+	})
+	t.Cleanup(func() { RegisterBackend("fake", nil) })
+
+	t.Setenv(EnvSecretStore, "true")
+	t.Setenv("SECRETSTORE_TYPE", "fake")
+
+	var mutex sync.Mutex
+	var logged []string
+	lc := utils.NewFuncLogger(models.InfoLog, func(logLevel string, msg string, _ []any) {
+		mutex.Lock()
+		defer mutex.Unlock()
+		logged = append(logged, logLevel+": "+msg)
+	})
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} { return lc },
+	})
+
+	actual, err := NewSecretProvider(nil, environment.NewVariables(lc), context.Background(), startup.NewStartUpTimer("UnitTest"), dic, "testServiceKey")
+	require.NoError(t, err)
+	assert.Same(t, fakeProvider, actual)
+
+	mutex.Lock()
+	defer mutex.Unlock()
+	assert.Contains(t, logged, "INFO: Creating SecretClient")
+	assert.Contains(t, logged, "INFO: Creating SecretProvider for the 'fake' secret store backend")
+}
+
+func TestRegisterBackend(t *testing.T) {
+	factory := func(ctx context.Context, secretStoreInfo *config.SecretStoreInfo, dic *di.Container,
+		serviceKey string) (interfaces.SecretProviderExt, error) {
+		return nil, nil
+	}
+
+	RegisterBackend("fake", factory)
+	_, found := getBackend("fake")
+	assert.True(t, found)
+
+	RegisterBackend("fake", nil)
+	_, found = getBackend("fake")
+	assert.False(t, found)
+
+	_, found = getBackend("vault")
+	assert.False(t, found, "the built-in Vault secret store is not a registered backend")
+}
@@ -0,0 +1,120 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// An encrypted secrets file contains the magic header, followed by the scrypt salt, the AES-GCM nonce and the
+// AES-GCM sealed secrets file contents. The header is also used as the additional authenticated data.
+var encryptedSecretsFileHeader = []byte("EDGEX-ENCRYPTED-SECRETS-V1\n")
+
+const (
+	encryptionSaltLength = 16
+	encryptionKeyLength  = 32 // AES-256
+
+	// scrypt cost parameters recommended for interactive logins
+	scryptN = 32768
+	scryptR = 8
+	scryptP = 1
+)
+
+// isEncryptedSecretsFile returns true if the secrets file contents are encrypted
+func isEncryptedSecretsFile(contents []byte) bool {
+	return bytes.HasPrefix(contents, encryptedSecretsFileHeader)
+}
+
+// encryptSecretsFile encrypts the secrets file contents with AES-256-GCM using a key derived from the passphrase
+func encryptSecretsFile(plaintext []byte, passphrase string) ([]byte, error) {
+	salt := make([]byte, encryptionSaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, fmt.Errorf("failed to generate salt: %v", err)
+	}
+
+	aead, err := newSecretsFileCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	contents := make([]byte, 0, len(encryptedSecretsFileHeader)+len(salt)+len(nonce)+len(plaintext)+aead.Overhead())
+	contents = append(contents, encryptedSecretsFileHeader...)
+	contents = append(contents, salt...)
+	contents = append(contents, nonce...)
+	return aead.Seal(contents, nonce, plaintext, encryptedSecretsFileHeader), nil
+}
+
+// decryptSecretsFile decrypts the secrets file contents previously encrypted by encryptSecretsFile
+func decryptSecretsFile(contents []byte, passphrase string) ([]byte, error) {
+	if !isEncryptedSecretsFile(contents) {
+		return nil, errors.New("secrets file is not encrypted")
+	}
+
+	remaining := contents[len(encryptedSecretsFileHeader):]
+	if len(remaining) < encryptionSaltLength {
+		return nil, errors.New("encrypted secrets file is truncated")
+	}
+
+	salt := remaining[:encryptionSaltLength]
+	aead, err := newSecretsFileCipher(passphrase, salt)
+	if err != nil {
+		return nil, err
+	}
+
+	remaining = remaining[encryptionSaltLength:]
+	if len(remaining) < aead.NonceSize() {
+		return nil, errors.New("encrypted secrets file is truncated")
+	}
+
+	nonce := remaining[:aead.NonceSize()]
+	plaintext, err := aead.Open(nil, nonce, remaining[aead.NonceSize():], encryptedSecretsFileHeader)
+	if err != nil {
+		// Don't expose the underlying error as it doesn't distinguish a wrong passphrase from a tampered file
+		return nil, errors.New("failed to decrypt secrets file: wrong passphrase or file has been modified")
+	}
+
+	return plaintext, nil
+}
+
+// newSecretsFileCipher creates the AES-GCM cipher using the key derived from the passphrase and salt
+func newSecretsFileCipher(passphrase string, salt []byte) (cipher.AEAD, error) {
+	if len(passphrase) == 0 {
+		return nil, errors.New("secrets file passphrase must not be empty")
+	}
+
+	key, err := scrypt.Key([]byte(passphrase), salt, scryptN, scryptR, scryptP, encryptionKeyLength)
+	if err != nil {
+		return nil, fmt.Errorf("failed to derive secrets file key: %v", err)
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create secrets file cipher: %v", err)
+	}
+
+	return cipher.NewGCM(block)
+}
@@ -0,0 +1,70 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptSecretsFile_RoundTrip(t *testing.T) {
+	plaintext := []byte(`{"secrets": [{"secretName": "redisdb", "secretData": [{"key": "password", "value": "MySecret"}]}]}`)
+
+	encrypted, err := encryptSecretsFile(plaintext, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.True(t, isEncryptedSecretsFile(encrypted))
+	assert.False(t, bytes.Contains(encrypted, []byte("MySecret")))
+
+	// A new salt and nonce are used each time, so encrypting the same contents twice must not give the same result
+	encryptedAgain, err := encryptSecretsFile(plaintext, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.NotEqual(t, encrypted, encryptedAgain)
+
+	decrypted, err := decryptSecretsFile(encrypted, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.Equal(t, plaintext, decrypted)
+}
+
+func TestDecryptSecretsFile_Invalid(t *testing.T) {
+	encrypted, err := encryptSecretsFile([]byte(`{"secrets": []}`), "passphrase")
+	require.NoError(t, err)
+
+	tampered := bytes.Clone(encrypted)
+	tampered[len(tampered)-1] ^= 0xff
+
+	tests := []struct {
+		Name          string
+		Contents      []byte
+		Passphrase    string
+		ExpectedError string
+	}{
+		{"Wrong passphrase", encrypted, "wrong passphrase", "wrong passphrase"},
+		{"Empty passphrase", encrypted, "", "passphrase must not be empty"},
+		{"Tampered", tampered, "passphrase", "file has been modified"},
+		{"Truncated", encrypted[:len(encryptedSecretsFileHeader)+4], "passphrase", "truncated"},
+		{"Not encrypted", []byte(`{"secrets": []}`), "passphrase", "not encrypted"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := decryptSecretsFile(tc.Contents, tc.Passphrase)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ExpectedError)
+		})
+	}
+}
@@ -14,7 +14,17 @@
 
 package secret
 
-import "os"
+import (
+	"fmt"
+	"os"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+)
 
 const (
 	EnvSecretStore = "EDGEX_SECURITY_SECRET_STORE"
@@ -22,8 +32,88 @@ const (
 	PasswordKey    = "password"
 )
 
+// secretNamePatternChars are the glob meta characters, as defined by path.Match, which indicate a registered
+// secretName is a pattern rather than an exact secretName.
+const secretNamePatternChars = "*?[\\"
+
 // IsSecurityEnabled determines if security has been enabled.
 func IsSecurityEnabled() bool {
 	env := os.Getenv(EnvSecretStore)
 	return env != "false" // Any other value is considered secure mode enabled
 }
+
+// isSecretNamePattern returns true if the secretName contains any glob meta characters.
+func isSecretNamePattern(secretName string) bool {
+	return strings.ContainsAny(secretName, secretNamePatternChars)
+}
+
+// validateSecretNamePattern verifies the secretName is a well-formed glob pattern when it contains glob meta characters.
+func validateSecretNamePattern(secretName string) error {
+	if !isSecretNamePattern(secretName) {
+		return nil
+	}
+
+	if _, err := path.Match(secretName, ""); err != nil {
+		return fmt.Errorf("invalid secretName pattern '%s': %v", secretName, err)
+	}
+
+	return nil
+}
+
+// invokeSecretUpdatedCallbacks executes the callback registered for the exact secretName, if any, followed by all
+// the callbacks registered with a glob pattern that matches the secretName.
+func invokeSecretUpdatedCallbacks(lc logger.LoggingClient, callbacks map[string]func(secretName string), secretName string) {
+	if callback := callbacks[secretName]; callback != nil {
+		lc.Debugf("invoking callback registered for secretName: '%s'", secretName)
+		callback(secretName)
+	}
+
+	for pattern, callback := range callbacks {
+		if callback == nil || pattern == secretName || !isSecretNamePattern(pattern) {
+			continue
+		}
+
+		// Patterns are validated when registered, so the error can safely be ignored here.
+		if matched, _ := path.Match(pattern, secretName); matched {
+			lc.Debugf("invoking callback registered for secretName pattern '%s' matching secretName: '%s'", pattern, secretName)
+			callback(secretName)
+		}
+	}
+}
+
+// secretDataKeys returns the sorted list of key names in the secret data. The values are intentionally not returned.
+func secretDataKeys(secretData map[string]string) []string {
+	keys := make([]string, 0, len(secretData))
+	for key := range secretData {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+	return keys
+}
+
+// sortSecretsMetadata sorts the list of secrets metadata by secretName so results are deterministic.
+func sortSecretsMetadata(metadata []interfaces.SecretMetadata) {
+	sort.Slice(metadata, func(i, j int) bool {
+		return metadata[i].SecretName < metadata[j].SecretName
+	})
+}
+
+// patchSecretData returns a copy of the existing secret data with the updates merged in. Keys whose updated value is
+// interfaces.DeleteSecretKeyValue are removed.
+func patchSecretData(existing map[string]string, updates map[string]string) map[string]string {
+	patched := make(map[string]string, len(existing)+len(updates))
+	for key, value := range existing {
+		patched[key] = value
+	}
+
+	for key, value := range updates {
+		if value == interfaces.DeleteSecretKeyValue {
+			delete(patched, key)
+			continue
+		}
+		patched[key] = value
+	}
+
+	return patched
+}
@@ -15,15 +15,24 @@
 package secret
 
 import (
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	gometrics "github.com/rcrowley/go-metrics"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
 )
 
 // InsecureProvider implements the SecretProvider interface for insecure secrets
@@ -31,17 +40,39 @@ type InsecureProvider struct {
 	lc                        logger.LoggingClient
 	configuration             interfaces.Configuration
 	lastUpdated               time.Time
+	secretsLastUpdated        map[string]time.Time
+	secretsLastUpdatedMutex   sync.RWMutex
 	registeredSecretCallbacks map[string]func(secretName string)
 	securitySecretsRequested  gometrics.Counter
 	securitySecretsStored     gometrics.Counter
+	// fileSecrets are the secrets loaded from the watched secrets file, keyed by secretName
+	fileSecrets      map[string]map[string]string
+	fileSecretsMutex sync.RWMutex
+	// secretsFile is the watched secrets file, which StoreSecret writes to
+	secretsFile string
+	// secretsFileContents are the contents of the secrets file last loaded or written
+	secretsFileContents []byte
+	// secretsFilePassphrase is the passphrase used to encrypt the secrets file. The file is plaintext when empty.
+	secretsFilePassphrase string
+	// directorySecrets are the secrets loaded from the secrets directory, keyed by secretName
+	directorySecrets map[string]map[string]string
+	// environmentSecrets are the secrets loaded from the environment variables, keyed by secretName
+	environmentSecrets map[string]map[string]string
 }
 
+// defaultSecretsFileWatchInterval is the interval at which the watched secrets file is checked for changes
+const defaultSecretsFileWatchInterval = 2 * time.Second
+
+// environmentSecretPrefix is the prefix of the environment variables the secrets are loaded from
+const environmentSecretPrefix = "SECRET_"
+
 // NewInsecureProvider creates, initializes Provider for insecure secrets.
 func NewInsecureProvider(config interfaces.Configuration, lc logger.LoggingClient) *InsecureProvider {
 	return &InsecureProvider{
 		configuration:             config,
 		lc:                        lc,
 		lastUpdated:               time.Now(),
+		secretsLastUpdated:        make(map[string]time.Time),
 		registeredSecretCallbacks: make(map[string]func(secretName string)),
 		securitySecretsRequested:  gometrics.NewCounter(),
 		securitySecretsStored:     gometrics.NewCounter(),
@@ -59,7 +90,7 @@ func (p *InsecureProvider) GetSecret(secretName string, keys ...string) (map[str
 	secretNameExists := false
 	var missingKeys []string
 
-	insecureSecrets := p.configuration.GetInsecureSecrets()
+	insecureSecrets := p.getInsecureSecrets()
 	if insecureSecrets == nil {
 		err := fmt.Errorf("InsecureSecrets missing from configuration")
 		return nil, err
@@ -101,9 +132,85 @@ func (p *InsecureProvider) GetSecret(secretName string, keys ...string) (map[str
 	return results, nil
 }
 
-// StoreSecret stores the secrets, but is not supported for Insecure Secrets
-func (p *InsecureProvider) StoreSecret(_ string, _ map[string]string) error {
-	return errors.New("storing secrets is not supported when running in insecure mode")
+// StoreSecret stores the secrets to the watched secrets file, encrypting the file if a passphrase has been set.
+// Storing secrets is not supported for the Insecure Secrets in the configuration, so an error is returned if a secrets
+// file is not being watched.
+func (p *InsecureProvider) StoreSecret(secretName string, secrets map[string]string) error {
+	p.fileSecretsMutex.Lock()
+	err := p.storeFileSecret(secretName, secrets)
+	p.fileSecretsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.SecretUpdatedAtSecretName(secretName)
+	return nil
+}
+
+// storeFileSecret writes the secrets to the watched secrets file and the secrets loaded from it. Must be called with
+// the fileSecretsMutex held.
+func (p *InsecureProvider) storeFileSecret(secretName string, secrets map[string]string) error {
+	if len(p.secretsFile) == 0 {
+		return errors.New("storing secrets is not supported when running in insecure mode without a watched secrets file")
+	}
+
+	if len(secrets) == 0 {
+		return fmt.Errorf("secret '%s' must contain at least one key", secretName)
+	}
+
+	updated := make(map[string]map[string]string, len(p.fileSecrets)+1)
+	for name, data := range p.fileSecrets {
+		updated[name] = data
+	}
+	updated[secretName] = make(map[string]string, len(secrets))
+	for key, value := range secrets {
+		updated[secretName][key] = value
+	}
+
+	contents, err := encodeSecretsFile(updated, p.secretsFilePassphrase)
+	if err == nil {
+		err = writeFileAtomic(p.secretsFile, contents)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to store secret '%s' to secrets file %s: %v", secretName, p.secretsFile, err)
+	}
+
+	p.fileSecrets = updated
+	p.secretsFileContents = contents
+	return nil
+}
+
+// SetSecretsFilePassphrase sets the passphrase used to decrypt the watched secrets file and to encrypt it when
+// secrets are stored. It must be set prior to calling WatchSecretsFile.
+func (p *InsecureProvider) SetSecretsFilePassphrase(passphrase string) {
+	p.fileSecretsMutex.Lock()
+	defer p.fileSecretsMutex.Unlock()
+
+	p.secretsFilePassphrase = passphrase
+}
+
+// PatchSecret merges the updates into the secret at the specified secretName in the watched secrets file and stores
+// the result, the same as StoreSecret. Existing keys not in the updates are preserved and keys whose value is
+// interfaces.DeleteSecretKeyValue are removed. The secret is created if it isn't in the secrets file. Patching secrets
+// is not supported for the Insecure Secrets in the configuration, so an error is returned if a secrets file is not
+// being watched.
+func (p *InsecureProvider) PatchSecret(secretName string, updates map[string]string) error {
+	p.fileSecretsMutex.Lock()
+
+	if len(p.secretsFile) == 0 {
+		p.fileSecretsMutex.Unlock()
+		return errors.New("patching secrets is not supported when running in insecure mode without a watched secrets file")
+	}
+
+	// The merge is done under the lock, so concurrent patches and reloads of the file don't overwrite each other
+	err := p.storeFileSecret(secretName, patchSecretData(p.fileSecrets[secretName], updates))
+	p.fileSecretsMutex.Unlock()
+	if err != nil {
+		return err
+	}
+
+	p.SecretUpdatedAtSecretName(secretName)
+	return nil
 }
 
 // SecretsUpdated resets LastUpdate time for the Insecure Secrets.
@@ -116,15 +223,387 @@ func (p *InsecureProvider) SecretsLastUpdated() time.Time {
 	return p.lastUpdated
 }
 
+// getInsecureSecrets returns the Insecure Secrets from the configuration overlaid with the secrets loaded from the
+// environment, secrets directory and watched secrets file, if any, in that order of increasing precedence. Secrets
+// from these sources replace configuration secrets with the same secretName.
+func (p *InsecureProvider) getInsecureSecrets() config.InsecureSecrets {
+	var configSecrets config.InsecureSecrets
+	if p.configuration != nil {
+		configSecrets = p.configuration.GetInsecureSecrets()
+	}
+
+	p.fileSecretsMutex.RLock()
+	defer p.fileSecretsMutex.RUnlock()
+
+	return p.overlayInsecureSecrets(configSecrets)
+}
+
+// overlayInsecureSecrets returns the configuration's Insecure Secrets overlaid with the secrets loaded from the other
+// sources. Must be called with the fileSecretsMutex held.
+func (p *InsecureProvider) overlayInsecureSecrets(configSecrets config.InsecureSecrets) config.InsecureSecrets {
+	if p.fileSecrets == nil && p.directorySecrets == nil && p.environmentSecrets == nil {
+		return configSecrets
+	}
+
+	results := make(config.InsecureSecrets, len(configSecrets)+len(p.environmentSecrets)+len(p.directorySecrets)+len(p.fileSecrets))
+	for key, insecureSecret := range configSecrets {
+		_, inEnvironment := p.environmentSecrets[insecureSecret.SecretName]
+		_, inDirectory := p.directorySecrets[insecureSecret.SecretName]
+		_, inFile := p.fileSecrets[insecureSecret.SecretName]
+		if !inEnvironment && !inDirectory && !inFile {
+			results[key] = insecureSecret
+		}
+	}
+
+	// Secrets from the secrets file take precedence over those from the secrets directory, which take precedence
+	// over those from the environment
+	for _, secrets := range []map[string]map[string]string{p.environmentSecrets, p.directorySecrets, p.fileSecrets} {
+		for secretName, secretData := range secrets {
+			results[secretName] = config.InsecureSecretsInfo{
+				SecretName: secretName,
+				SecretData: secretData,
+			}
+		}
+	}
+
+	return results
+}
+
+// SnapshotNames returns a consistent copy of the names of the Insecure Secrets, from all their sources, with the
+// sorted names of each secret's keys. The values aren't included. The copy is made under the read lock, so it is safe
+// to call concurrently with StoreSecret and the reloading of the secrets, and the copy can be iterated freely.
+func (p *InsecureProvider) SnapshotNames() map[string][]string {
+	var configSecrets config.InsecureSecrets
+	if p.configuration != nil {
+		configSecrets = p.configuration.GetInsecureSecrets()
+	}
+
+	p.fileSecretsMutex.RLock()
+	defer p.fileSecretsMutex.RUnlock()
+
+	// Several Insecure Secrets in the configuration may have the same secretName, so their keys are combined
+	keySets := make(map[string]map[string]bool)
+	for _, insecureSecret := range p.overlayInsecureSecrets(configSecrets) {
+		if keySets[insecureSecret.SecretName] == nil {
+			keySets[insecureSecret.SecretName] = make(map[string]bool, len(insecureSecret.SecretData))
+		}
+		for key := range insecureSecret.SecretData {
+			keySets[insecureSecret.SecretName][key] = true
+		}
+	}
+
+	names := make(map[string][]string, len(keySets))
+	for secretName, keySet := range keySets {
+		keys := make([]string, 0, len(keySet))
+		for key := range keySet {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		names[secretName] = keys
+	}
+
+	return names
+}
+
+// LoadSecretsDirectory loads the secrets from the specified directory, i.e. mounted Kubernetes secret volumes. Each
+// sub-directory is a secretName and each file within it is a key of the secret, with the file's contents as the value.
+// Hidden entries, such as the "..data" links Kubernetes uses to update the volume atomically, are ignored. The secrets
+// from the directory take precedence over the Insecure Secrets in the configuration, but not over the secrets file.
+func (p *InsecureProvider) LoadSecretsDirectory(secretsDir string) error {
+	secrets, err := readSecretsDirectory(secretsDir)
+	if err != nil {
+		return fmt.Errorf("failed to load secrets directory %s: %s", secretsDir, err.Error())
+	}
+
+	p.fileSecretsMutex.Lock()
+	p.directorySecrets = secrets
+	p.fileSecretsMutex.Unlock()
+
+	p.lc.Infof("Loaded %d secrets from directory %s", len(secrets), secretsDir)
+	return nil
+}
+
+// LoadSecretsFromEnvironment loads the secrets from the environment variables named SECRET_<SECRETNAME>_<KEY>, with
+// the variable's value as the value of the key. The secretName is the text up to the first underscore following the
+// prefix, so it can't contain an underscore, and the remainder is the key. Both are lowercased since environment
+// variables are conventionally uppercase, i.e. SECRET_REDISDB_PASSWORD is the "password" key of the "redisdb" secret.
+// The secrets are read once, so are read-only, and are overridden by those from the secrets directory and file.
+func (p *InsecureProvider) LoadSecretsFromEnvironment() {
+	secrets := parseEnvironmentSecrets(os.Environ())
+
+	p.fileSecretsMutex.Lock()
+	p.environmentSecrets = secrets
+	p.fileSecretsMutex.Unlock()
+
+	p.lc.Infof("Loaded %d secrets from environment variables", len(secrets))
+}
+
+// parseEnvironmentSecrets parses the secrets from the environment, in the form "NAME=value", into a map of secretData
+// keyed by secretName
+func parseEnvironmentSecrets(environment []string) map[string]map[string]string {
+	secrets := make(map[string]map[string]string)
+	for _, env := range environment {
+		name, value, found := strings.Cut(env, "=")
+		if !found || !strings.HasPrefix(name, environmentSecretPrefix) {
+			continue
+		}
+
+		secretName, key, found := strings.Cut(strings.TrimPrefix(name, environmentSecretPrefix), "_")
+		if !found || len(secretName) == 0 || len(key) == 0 {
+			continue
+		}
+
+		secretName = strings.ToLower(secretName)
+		if _, exists := secrets[secretName]; !exists {
+			secrets[secretName] = make(map[string]string)
+		}
+		secrets[secretName][strings.ToLower(key)] = value
+	}
+
+	return secrets
+}
+
+// readSecretsDirectory reads the secrets from the directory into a map of secretData keyed by secretName
+func readSecretsDirectory(secretsDir string) (map[string]map[string]string, error) {
+	entries, err := os.ReadDir(secretsDir)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]map[string]string)
+	for _, entry := range entries {
+		secretDir := filepath.Join(secretsDir, entry.Name())
+		// Stat rather than the entry's type is used so symbolic links are followed
+		if strings.HasPrefix(entry.Name(), ".") || !isDirectory(secretDir) {
+			continue
+		}
+
+		keyEntries, err := os.ReadDir(secretDir)
+		if err != nil {
+			return nil, err
+		}
+
+		secretData := make(map[string]string)
+		for _, keyEntry := range keyEntries {
+			keyFile := filepath.Join(secretDir, keyEntry.Name())
+			if strings.HasPrefix(keyEntry.Name(), ".") || isDirectory(keyFile) {
+				continue
+			}
+
+			value, err := os.ReadFile(keyFile)
+			if err != nil {
+				return nil, err
+			}
+
+			secretData[keyEntry.Name()] = string(value)
+		}
+
+		if len(secretData) > 0 {
+			secrets[entry.Name()] = secretData
+		}
+	}
+
+	return secrets, nil
+}
+
+// isDirectory returns true if the path, following symbolic links, is a directory
+func isDirectory(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
+
+// WatchSecretsFile loads the secrets from the specified secrets file, using the same JSON format used for seeding
+// secrets in secure mode, and then checks the file for changes at the specified interval until the context is done.
+// When the file changes, the secrets are reloaded and the registered callbacks are invoked for each changed secretName.
+// The secrets from the file take precedence over the Insecure Secrets in the configuration. The file is decrypted if
+// it has been encrypted, which requires the passphrase to have been set via SetSecretsFilePassphrase.
+func (p *InsecureProvider) WatchSecretsFile(ctx context.Context, secretsFile string, interval time.Duration) error {
+	contents, err := os.ReadFile(secretsFile)
+	if err != nil {
+		return fmt.Errorf("failed to read secrets file %s: %s", secretsFile, err.Error())
+	}
+
+	p.fileSecretsMutex.Lock()
+	secrets, err := decodeSecretsFile(contents, p.secretsFilePassphrase)
+	if err != nil {
+		p.fileSecretsMutex.Unlock()
+		return fmt.Errorf("failed to load secrets file %s: %s", secretsFile, err.Error())
+	}
+
+	p.fileSecrets = secrets
+	p.secretsFile = secretsFile
+	p.secretsFileContents = contents
+	p.fileSecretsMutex.Unlock()
+
+	p.lc.Infof("Loaded %d secrets from %s. Watching for changes", len(secrets), secretsFile)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				p.lc.Infof("Watching secrets file %s has stopped", secretsFile)
+				return
+
+			case <-ticker.C:
+				latest, err := os.ReadFile(secretsFile)
+				if err != nil {
+					p.lc.Errorf("failed to read secrets file %s: %v", secretsFile, err)
+					continue
+				}
+
+				if bytes.Equal(latest, contents) {
+					continue
+				}
+				contents = latest
+
+				if err := p.reloadSecretsFile(latest); err != nil {
+					p.lc.Errorf("failed to reload secrets file %s: %v", secretsFile, err)
+				}
+			}
+		}
+	}()
+
+	return nil
+}
+
+// reloadSecretsFile replaces the file secrets with the new contents and signals the secretNames that changed.
+func (p *InsecureProvider) reloadSecretsFile(contents []byte) error {
+	p.fileSecretsMutex.Lock()
+
+	// Nothing to reload if the contents were written by StoreSecret
+	if bytes.Equal(contents, p.secretsFileContents) {
+		p.fileSecretsMutex.Unlock()
+		return nil
+	}
+
+	secrets, err := decodeSecretsFile(contents, p.secretsFilePassphrase)
+	if err != nil {
+		p.fileSecretsMutex.Unlock()
+		return err
+	}
+
+	var changed []string
+	for secretName, secretData := range secrets {
+		if !reflect.DeepEqual(p.fileSecrets[secretName], secretData) {
+			changed = append(changed, secretName)
+		}
+	}
+	for secretName := range p.fileSecrets {
+		if _, exists := secrets[secretName]; !exists {
+			changed = append(changed, secretName)
+		}
+	}
+	p.fileSecrets = secrets
+	p.secretsFileContents = contents
+	p.fileSecretsMutex.Unlock()
+
+	p.lc.Infof("Secrets file reloaded with %d changed secrets", len(changed))
+
+	for _, secretName := range changed {
+		p.SecretUpdatedAtSecretName(secretName)
+	}
+
+	return nil
+}
+
+// decodeSecretsFile decrypts the secrets file contents, if encrypted, and parses them into a map of secretData keyed
+// by secretName. A plaintext file is accepted even when a passphrase is set, so an existing file can be migrated; it
+// is encrypted the next time secrets are stored.
+func decodeSecretsFile(contents []byte, passphrase string) (map[string]map[string]string, error) {
+	if isEncryptedSecretsFile(contents) {
+		if len(passphrase) == 0 {
+			return nil, errors.New("secrets file is encrypted, but no passphrase has been set")
+		}
+
+		var err error
+		contents, err = decryptSecretsFile(contents, passphrase)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return parseSecretsFile(contents)
+}
+
+// encodeSecretsFile formats the secrets as the secrets file JSON, which is encrypted if the passphrase is not empty
+func encodeSecretsFile(secrets map[string]map[string]string, passphrase string) ([]byte, error) {
+	secretNames := make([]string, 0, len(secrets))
+	for secretName := range secrets {
+		secretNames = append(secretNames, secretName)
+	}
+	sort.Strings(secretNames)
+
+	serviceSecrets := ServiceSecrets{Secrets: make([]ServiceSecret, 0, len(secrets))}
+	for _, secretName := range secretNames {
+		secret := ServiceSecret{SecretName: secretName}
+		for _, key := range secretDataKeys(secrets[secretName]) {
+			secret.SecretData = append(secret.SecretData, common.SecretDataKeyValue{Key: key, Value: secrets[secretName][key]})
+		}
+		serviceSecrets.Secrets = append(serviceSecrets.Secrets, secret)
+	}
+
+	contents, err := serviceSecrets.MarshalJson()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(passphrase) == 0 {
+		return contents, nil
+	}
+
+	return encryptSecretsFile(contents, passphrase)
+}
+
+// writeFileAtomic writes the contents to a temporary file which then replaces the file, so the file is never left
+// partially written.
+func writeFileAtomic(file string, contents []byte) error {
+	tempFile := file + ".tmp"
+	if err := os.WriteFile(tempFile, contents, 0600); err != nil {
+		return err
+	}
+
+	return os.Rename(tempFile, file)
+}
+
+// parseSecretsFile parses the secrets file contents into a map of secretData keyed by secretName
+func parseSecretsFile(contents []byte) (map[string]map[string]string, error) {
+	serviceSecrets, err := UnmarshalServiceSecretsJson(contents)
+	if err != nil {
+		return nil, err
+	}
+
+	secrets := make(map[string]map[string]string, len(serviceSecrets.Secrets))
+	for _, secret := range serviceSecrets.Secrets {
+		secretName, data := prepareSecret(secret)
+		secrets[secretName] = data
+	}
+
+	return secrets, nil
+}
+
 // GetAccessToken returns the AccessToken for the specified type, which in insecure mode is not need
 // so just returning an empty token.
 func (p *InsecureProvider) GetAccessToken(_ string, _ string) (string, error) {
 	return "", nil
 }
 
+// GetAccessTokens returns the AccessTokens for the specified type and service keys, which in insecure mode are not
+// needed so just returning empty tokens.
+func (p *InsecureProvider) GetAccessTokens(_ string, serviceKeys ...string) (map[string]string, error) {
+	tokens := make(map[string]string, len(serviceKeys))
+	for _, serviceKey := range serviceKeys {
+		tokens[serviceKey] = ""
+	}
+
+	return tokens, nil
+}
+
 // HasSecret returns true if the service's SecretStore contains a secret at the specified secretName.
 func (p *InsecureProvider) HasSecret(secretName string) (bool, error) {
-	insecureSecrets := p.configuration.GetInsecureSecrets()
+	insecureSecrets := p.getInsecureSecrets()
 	if insecureSecrets == nil {
 		err := fmt.Errorf("InsecureSecret missing from configuration")
 		return false, err
@@ -143,7 +622,7 @@ func (p *InsecureProvider) HasSecret(secretName string) (bool, error) {
 func (p *InsecureProvider) ListSecretNames() ([]string, error) {
 	var results []string
 
-	insecureSecrets := p.configuration.GetInsecureSecrets()
+	insecureSecrets := p.getInsecureSecrets()
 	if insecureSecrets == nil {
 		err := fmt.Errorf("InsecureSecrets missing from configuration")
 		return nil, err
@@ -156,8 +635,35 @@ func (p *InsecureProvider) ListSecretNames() ([]string, error) {
 	return results, nil
 }
 
-// RegisteredSecretUpdatedCallback registers a callback for a secret.
+// ListSecretsMetadata returns the name, key names and last updated time for all the secrets in the Insecure Secrets.
+// Secret values are never returned.
+func (p *InsecureProvider) ListSecretsMetadata() ([]interfaces.SecretMetadata, error) {
+	insecureSecrets := p.getInsecureSecrets()
+	if insecureSecrets == nil {
+		err := fmt.Errorf("InsecureSecrets missing from configuration")
+		return nil, err
+	}
+
+	results := make([]interfaces.SecretMetadata, 0, len(insecureSecrets))
+	for _, insecureSecret := range insecureSecrets {
+		results = append(results, interfaces.SecretMetadata{
+			SecretName:  insecureSecret.SecretName,
+			Keys:        secretDataKeys(insecureSecret.SecretData),
+			LastUpdated: p.secretLastUpdated(insecureSecret.SecretName),
+		})
+	}
+
+	sortSecretsMetadata(results)
+	return results, nil
+}
+
+// RegisteredSecretUpdatedCallback registers a callback for a secret. The secretName may be a glob pattern
+// (see path.Match), i.e. "mqtt-*", in which case the callback is invoked for every updated secretName that matches.
 func (p *InsecureProvider) RegisteredSecretUpdatedCallback(secretName string, callback func(secretName string)) error {
+	if err := validateSecretNamePattern(secretName); err != nil {
+		return err
+	}
+
 	if _, ok := p.registeredSecretCallbacks[secretName]; ok {
 		return fmt.Errorf("there is a callback already registered for secretName '%v'", secretName)
 	}
@@ -173,18 +679,22 @@ func (p *InsecureProvider) SecretUpdatedAtSecretName(secretName string) {
 	p.securitySecretsStored.Inc(1)
 
 	p.lastUpdated = time.Now()
+	p.secretsLastUpdatedMutex.Lock()
+	p.secretsLastUpdated[secretName] = p.lastUpdated
+	p.secretsLastUpdatedMutex.Unlock()
 	if p.registeredSecretCallbacks != nil {
-		// Execute Callback for provided secretName.
-		for k, v := range p.registeredSecretCallbacks {
-			if k == secretName {
-				p.lc.Debugf("invoking callback registered for secretName: '%s'", secretName)
-				v(secretName)
-				return
-			}
-		}
+		// Execute Callbacks registered for provided secretName or a pattern matching it.
+		invokeSecretUpdatedCallbacks(p.lc, p.registeredSecretCallbacks, secretName)
 	}
 }
 
+// secretLastUpdated returns the last time the secret was updated by this provider, which is zero when unknown
+func (p *InsecureProvider) secretLastUpdated(secretName string) time.Time {
+	p.secretsLastUpdatedMutex.RLock()
+	defer p.secretsLastUpdatedMutex.RUnlock()
+	return p.secretsLastUpdated[secretName]
+}
+
 // DeregisterSecretUpdatedCallback removes a secret's registered callback secretName.
 func (p *InsecureProvider) DeregisterSecretUpdatedCallback(secretName string) {
 	// Remove secretName from map.
@@ -199,6 +709,22 @@ func (p *InsecureProvider) GetMetricsToRegister() map[string]interface{} {
 	}
 }
 
+// RenewToken is a no-op when security is disabled as there is no secret store token
+func (p *InsecureProvider) RenewToken() error {
+	return nil
+}
+
+// RegisterTokenRenewedCallback is a no-op when security is disabled as there is no secret store token to be renewed,
+// so the callback is never invoked
+func (p *InsecureProvider) RegisterTokenRenewedCallback(_ func(newSelfJWT string)) {
+}
+
+// SecretStoreHealth always reports the secret store as reachable and unsealed when security is disabled, as the
+// secrets are held in the configuration rather than in a secret store
+func (p *InsecureProvider) SecretStoreHealth() (bool, bool, error) {
+	return false, true, nil
+}
+
 // GetSelfJWT returns an encoded JWT for the current identity-based secret store token
 func (p *InsecureProvider) GetSelfJWT() (string, error) {
 	// If security is disabled, return an empty string
@@ -15,11 +15,17 @@
 package secret
 
 import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"reflect"
 	"sort"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
@@ -82,6 +88,12 @@ func TestInsecureProvider_StoreSecrets_Secure(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestInsecureProvider_PatchSecret(t *testing.T) {
+	target := NewInsecureProvider(nil, nil)
+	err := target.PatchSecret("myPath", map[string]string{"Key": "value"})
+	require.Error(t, err)
+}
+
 func TestInsecureProvider_SecretsUpdated_SecretsLastUpdated(t *testing.T) {
 	target := NewInsecureProvider(nil, logger.MockLogger{})
 	previous := target.SecretsLastUpdated()
@@ -98,6 +110,13 @@ func TestInsecureProvider_GetAccessToken(t *testing.T) {
 	assert.Len(t, actualToken, 0)
 }
 
+func TestInsecureProvider_GetAccessTokens(t *testing.T) {
+	target := NewInsecureProvider(nil, logger.MockLogger{})
+	actualTokens, err := target.GetAccessTokens(TokenTypeConsul, "core-command", "core-metadata")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"core-command": "", "core-metadata": ""}, actualTokens)
+}
+
 func TestInsecureProvider_GetSelfJWT(t *testing.T) {
 	target := NewInsecureProvider(nil, logger.MockLogger{})
 	actualToken, err := target.GetSelfJWT()
@@ -105,6 +124,27 @@ func TestInsecureProvider_GetSelfJWT(t *testing.T) {
 	require.Equal(t, "", actualToken)
 }
 
+func TestInsecureProvider_RenewToken(t *testing.T) {
+	target := NewInsecureProvider(nil, logger.MockLogger{})
+	require.NoError(t, target.RenewToken())
+}
+
+func TestInsecureProvider_RegisterTokenRenewedCallback(t *testing.T) {
+	target := NewInsecureProvider(nil, logger.MockLogger{})
+	invoked := false
+	target.RegisterTokenRenewedCallback(func(_ string) { invoked = true })
+	require.NoError(t, target.RenewToken())
+	assert.False(t, invoked, "callback must never be invoked when security is disabled")
+}
+
+func TestInsecureProvider_SecretStoreHealth(t *testing.T) {
+	target := NewInsecureProvider(nil, logger.MockLogger{})
+	sealed, reachable, err := target.SecretStoreHealth()
+	require.NoError(t, err)
+	assert.False(t, sealed)
+	assert.True(t, reachable)
+}
+
 func TestInsecureProvider_IsJWTValid(t *testing.T) {
 	nullJWT := "eyJhbGciOiJOb25lIiwidHlwIjoiSldUIn0.e30."
 	target := NewInsecureProvider(nil, logger.MockLogger{})
@@ -165,6 +205,51 @@ func TestInsecureProvider_ListPaths(t *testing.T) {
 	}
 }
 
+func TestInsecureProvider_ListSecretsMetadata(t *testing.T) {
+	configAllSecrets := TestConfig{
+		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
+			"REDIS": {
+				SecretName: "redisdb",
+				SecretData: map[string]string{"username": "admin", "password": "sam123!"},
+			},
+			"KONG": {
+				SecretName: "kongdb",
+				SecretData: map[string]string{"token": "abc123"},
+			},
+		},
+	}
+
+	t.Run("Valid", func(t *testing.T) {
+		target := NewInsecureProvider(configAllSecrets, logger.NewMockClient())
+		created := target.SecretsLastUpdated()
+		time.Sleep(10 * time.Millisecond)
+		target.SecretUpdatedAtSecretName("redisdb")
+
+		actual, err := target.ListSecretsMetadata()
+		require.NoError(t, err)
+		require.Len(t, actual, 2)
+
+		assert.Equal(t, "kongdb", actual[0].SecretName)
+		assert.Equal(t, []string{"token"}, actual[0].Keys)
+		// Secrets without a tracked update have an unknown last updated time
+		assert.True(t, actual[0].LastUpdated.IsZero())
+
+		assert.Equal(t, "redisdb", actual[1].SecretName)
+		assert.Equal(t, []string{"password", "username"}, actual[1].Keys)
+		assert.True(t, actual[1].LastUpdated.After(created))
+
+		// Secret values must never be returned
+		assert.NotContains(t, fmt.Sprintf("%v", actual), "sam123!")
+		assert.NotContains(t, fmt.Sprintf("%v", actual), "abc123")
+	})
+
+	t.Run("Invalid - No InsecureSecrets", func(t *testing.T) {
+		target := NewInsecureProvider(TestConfig{}, logger.MockLogger{})
+		_, err := target.ListSecretsMetadata()
+		require.Error(t, err)
+	})
+}
+
 func TestInsecureProvider_HasSecrets(t *testing.T) {
 	configAllSecrets := TestConfig{
 		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
@@ -290,6 +375,46 @@ func TestInsecureProvider_RegisteredSecretUpdatedCallback(t *testing.T) {
 	}
 }
 
+func TestInsecureProvider_SecretUpdatedAtSecretName_Patterns(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Registered       []string
+		SecretName       string
+		ExpectedInvoked  []string
+		ExpectedRegError bool
+	}{
+		{"Valid - glob match", []string{"mqtt-*"}, "mqtt-bus", []string{"mqtt-*"}, false},
+		{"Valid - glob no match", []string{"mqtt-*"}, "redisdb", nil, false},
+		{"Valid - single char glob", []string{"mqtt-?"}, "mqtt-1", []string{"mqtt-?"}, false},
+		{"Valid - exact and glob both fire", []string{"mqtt-bus", "mqtt-*"}, "mqtt-bus", []string{"mqtt-bus", "mqtt-*"}, false},
+		{"Valid - overlapping globs both fire", []string{"mqtt-*", "*-bus"}, "mqtt-bus", []string{"mqtt-*", "*-bus"}, false},
+		{"Invalid - bad pattern", []string{"mqtt-["}, "mqtt-bus", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+
+			var invoked []string
+			for _, registered := range tc.Registered {
+				registered := registered
+				err := target.RegisteredSecretUpdatedCallback(registered, func(secretName string) {
+					assert.Equal(t, tc.SecretName, secretName)
+					invoked = append(invoked, registered)
+				})
+				if tc.ExpectedRegError {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			}
+
+			target.SecretUpdatedAtSecretName(tc.SecretName)
+			assert.ElementsMatch(t, tc.ExpectedInvoked, invoked)
+		})
+	}
+}
+
 func TestInsecureProvider_DeregisterSecretUpdatedCallback(t *testing.T) {
 	configAllSecrets := TestConfig{
 		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
@@ -361,3 +486,407 @@ func (t TestConfig) GetTelemetryInfo() *bootstrapConfig.TelemetryInfo {
 func (t TestConfig) GetWritablePtr() any {
 	panic("implement me")
 }
+
+func TestInsecureProvider_WatchSecretsFile(t *testing.T) {
+	initialFile := `{"secrets": [
+		{"secretName": "mqtt", "secretData": [{"key": "username", "value": "mqtt-user"}, {"key": "password", "value": "initial"}]},
+		{"secretName": "redisdb", "secretData": [{"key": "username", "value": "file-admin"}]}
+	]}`
+	updatedFile := `{"secrets": [
+		{"secretName": "mqtt", "secretData": [{"key": "username", "value": "mqtt-user"}, {"key": "password", "value": "updated"}]},
+		{"secretName": "redisdb", "secretData": [{"key": "username", "value": "file-admin"}]}
+	]}`
+
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(secretsFile, []byte(initialFile), 0600))
+
+	configuration := TestConfig{
+		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
+			"DB": {
+				SecretName: expectedSecretName,
+				SecretData: expectedSecrets,
+			},
+			"Other": {
+				SecretName: "other",
+				SecretData: map[string]string{"key": "value"},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	target := NewInsecureProvider(configuration, logger.NewMockClient())
+
+	updated := make(chan string, 10)
+	require.NoError(t, target.RegisteredSecretUpdatedCallback("*", func(secretName string) {
+		updated <- secretName
+	}))
+
+	require.NoError(t, target.WatchSecretsFile(ctx, secretsFile, 10*time.Millisecond))
+
+	// Secrets from the file take precedence over the configuration's secrets with the same secretName
+	actual, err := target.GetSecret(expectedSecretName)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "file-admin"}, actual)
+
+	actual, err = target.GetSecret("mqtt", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "initial", actual["password"])
+
+	actual, err = target.GetSecret("other")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, actual)
+
+	names, err := target.ListSecretNames()
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []string{"mqtt", expectedSecretName, "other"}, names)
+
+	require.NoError(t, os.WriteFile(secretsFile, []byte(updatedFile), 0600))
+
+	select {
+	case secretName := <-updated:
+		assert.Equal(t, "mqtt", secretName)
+	case <-time.After(time.Second):
+		require.Fail(t, "timed out waiting for secret updated callback")
+	}
+
+	actual, err = target.GetSecret("mqtt", "password")
+	require.NoError(t, err)
+	assert.Equal(t, "updated", actual["password"])
+
+	// Only the changed secret triggers a callback
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	assert.Empty(t, updated)
+}
+
+func TestInsecureProvider_WatchSecretsFile_Invalid(t *testing.T) {
+	target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+
+	err := target.WatchSecretsFile(context.Background(), filepath.Join(t.TempDir(), "missing.json"), time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to read secrets file")
+
+	badFile := filepath.Join(t.TempDir(), "bad.json")
+	require.NoError(t, os.WriteFile(badFile, []byte(`{"secrets": []}`), 0600))
+	err = target.WatchSecretsFile(context.Background(), badFile, time.Second)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load secrets file")
+}
+
+func TestInsecureProvider_StoreSecret_SecretsFile(t *testing.T) {
+	initialFile := `{"secrets": [
+		{"secretName": "mqtt", "secretData": [{"key": "username", "value": "mqtt-user"}, {"key": "password", "value": "initial"}]}
+	]}`
+
+	tests := []struct {
+		Name       string
+		Passphrase string
+	}{
+		{"Plaintext", ""},
+		{"Encrypted", "correct horse battery staple"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+			require.NoError(t, os.WriteFile(secretsFile, []byte(initialFile), 0600))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+			target.SetSecretsFilePassphrase(tc.Passphrase)
+			require.NoError(t, target.WatchSecretsFile(ctx, secretsFile, time.Hour))
+
+			require.NoError(t, target.StoreSecret("redisdb", map[string]string{"username": "admin", "password": "MySecret"}))
+
+			actual, err := target.GetSecret("redisdb")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"username": "admin", "password": "MySecret"}, actual)
+
+			contents, err := os.ReadFile(secretsFile)
+			require.NoError(t, err)
+			if len(tc.Passphrase) > 0 {
+				// An existing plaintext file is encrypted when secrets are stored
+				assert.True(t, isEncryptedSecretsFile(contents))
+				assert.NotContains(t, string(contents), "MySecret")
+				assert.NotContains(t, string(contents), "mqtt-user")
+			} else {
+				assert.False(t, isEncryptedSecretsFile(contents))
+				assert.Contains(t, string(contents), "MySecret")
+			}
+
+			// The stored file is loaded by a new provider using the same passphrase
+			reloaded := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+			reloaded.SetSecretsFilePassphrase(tc.Passphrase)
+			require.NoError(t, reloaded.WatchSecretsFile(ctx, secretsFile, time.Hour))
+
+			actual, err = reloaded.GetSecret("redisdb")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"username": "admin", "password": "MySecret"}, actual)
+
+			actual, err = reloaded.GetSecret("mqtt")
+			require.NoError(t, err)
+			assert.Equal(t, map[string]string{"username": "mqtt-user", "password": "initial"}, actual)
+		})
+	}
+}
+
+func TestInsecureProvider_PatchSecret_SecretsFile(t *testing.T) {
+	initialFile := `{"secrets": [
+		{"secretName": "mqtt", "secretData": [{"key": "username", "value": "mqtt-user"}, {"key": "password", "value": "initial"}]}
+	]}`
+
+	tests := []struct {
+		Name          string
+		SecretName    string
+		Updates       map[string]string
+		Expected      map[string]string
+		ExpectedError string
+	}{
+		{"Merge", "mqtt", map[string]string{"password": "updated"},
+			map[string]string{"username": "mqtt-user", "password": "updated"}, ""},
+		{"Add key", "mqtt", map[string]string{"clientId": "edgex"},
+			map[string]string{"username": "mqtt-user", "password": "initial", "clientId": "edgex"}, ""},
+		{"Delete key", "mqtt", map[string]string{"password": interfaces.DeleteSecretKeyValue},
+			map[string]string{"username": "mqtt-user"}, ""},
+		{"New secret", "redisdb", map[string]string{"username": "admin"},
+			map[string]string{"username": "admin"}, ""},
+		{"Delete all keys", "mqtt", map[string]string{"username": interfaces.DeleteSecretKeyValue, "password": interfaces.DeleteSecretKeyValue},
+			nil, "must contain at least one key"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+			require.NoError(t, os.WriteFile(secretsFile, []byte(initialFile), 0600))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+			require.NoError(t, target.WatchSecretsFile(ctx, secretsFile, time.Hour))
+
+			err := target.PatchSecret(tc.SecretName, tc.Updates)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+			require.NoError(t, err)
+
+			actual, err := target.GetSecret(tc.SecretName)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+
+			// The patched secret is written to the secrets file
+			reloaded := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+			require.NoError(t, reloaded.WatchSecretsFile(ctx, secretsFile, time.Hour))
+			actual, err = reloaded.GetSecret(tc.SecretName)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestInsecureProvider_WatchSecretsFile_Encrypted_Invalid(t *testing.T) {
+	plaintext := []byte(`{"secrets": [{"secretName": "mqtt", "secretData": [{"key": "password", "value": "initial"}]}]}`)
+	encrypted, err := encryptSecretsFile(plaintext, "correct horse battery staple")
+	require.NoError(t, err)
+
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(secretsFile, encrypted, 0600))
+
+	tests := []struct {
+		Name          string
+		Passphrase    string
+		ExpectedError string
+	}{
+		{"Wrong passphrase", "wrong passphrase", "wrong passphrase"},
+		{"No passphrase", "", "no passphrase has been set"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+			target.SetSecretsFilePassphrase(tc.Passphrase)
+
+			err := target.WatchSecretsFile(context.Background(), secretsFile, time.Hour)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ExpectedError)
+
+			_, err = target.GetSecret("mqtt")
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestInsecureProvider_LoadSecretsDirectory(t *testing.T) {
+	secretsDir := t.TempDir()
+	writeFile := func(path string, contents string) {
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(contents), 0600))
+	}
+
+	// Plain directory of files
+	writeFile(filepath.Join(secretsDir, "redisdb", "username"), "redis")
+	writeFile(filepath.Join(secretsDir, "redisdb", "password"), "MyRedisPassword")
+
+	// Kubernetes secret volume layout, where the keys are links to the files in the timestamped directory
+	mqttDir := filepath.Join(secretsDir, "mqtt")
+	writeFile(filepath.Join(mqttDir, "..2023_10_01_00_00_00.123", "username"), "mqtt-user")
+	writeFile(filepath.Join(mqttDir, "..2023_10_01_00_00_00.123", "password"), "MyMqttPassword")
+	require.NoError(t, os.Symlink("..2023_10_01_00_00_00.123", filepath.Join(mqttDir, "..data")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "username"), filepath.Join(mqttDir, "username")))
+	require.NoError(t, os.Symlink(filepath.Join("..data", "password"), filepath.Join(mqttDir, "password")))
+
+	// Entries which are not secrets
+	writeFile(filepath.Join(secretsDir, "README"), "not a secret")
+	writeFile(filepath.Join(secretsDir, ".hidden", "key"), "not a secret")
+	require.NoError(t, os.MkdirAll(filepath.Join(secretsDir, "empty"), 0755))
+
+	configuration := TestConfig{
+		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
+			"DB":    {SecretName: "redisdb", SecretData: map[string]string{"username": "config-user"}},
+			"Other": {SecretName: "other", SecretData: map[string]string{"key": "value"}},
+		},
+	}
+
+	target := NewInsecureProvider(configuration, logger.NewMockClient())
+	require.NoError(t, target.LoadSecretsDirectory(secretsDir))
+
+	actual, err := target.GetSecret("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "redis", "password": "MyRedisPassword"}, actual)
+
+	actual, err = target.GetSecret("mqtt")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "mqtt-user", "password": "MyMqttPassword"}, actual)
+
+	actual, err = target.GetSecret("other")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"key": "value"}, actual)
+
+	secretNames, err := target.ListSecretNames()
+	require.NoError(t, err)
+	sort.Strings(secretNames)
+	assert.Equal(t, []string{"mqtt", "other", "redisdb"}, secretNames)
+}
+
+func TestInsecureProvider_LoadSecretsDirectory_Invalid(t *testing.T) {
+	target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+	err := target.LoadSecretsDirectory(filepath.Join(t.TempDir(), "missing"))
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to load secrets directory")
+}
+
+func TestParseEnvironmentSecrets(t *testing.T) {
+	environment := []string{
+		"SECRET_REDISDB_USERNAME=redis",
+		"SECRET_REDISDB_PASSWORD=My=Redis=Password",
+		"SECRET_MQTT_CLIENT_ID=edgex-client",
+		"SECRET_MQTT_PASSWORD=",
+		"SECRETSTORE_HOST=localhost",
+		"SECRET_NOKEY",
+		"SECRET__KEY=value",
+		"PATH=/usr/bin",
+	}
+
+	expected := map[string]map[string]string{
+		"redisdb": {"username": "redis", "password": "My=Redis=Password"},
+		"mqtt":    {"client_id": "edgex-client", "password": ""},
+	}
+
+	assert.Equal(t, expected, parseEnvironmentSecrets(environment))
+}
+
+func TestInsecureProvider_LoadSecretsFromEnvironment(t *testing.T) {
+	t.Setenv("SECRET_REDISDB_USERNAME", "redis")
+	t.Setenv("SECRET_REDISDB_PASSWORD", "MyRedisPassword")
+	t.Setenv("SECRET_MQTT_USERNAME", "mqtt-user")
+
+	target := NewInsecureProvider(TestConfig{}, logger.NewMockClient())
+	target.LoadSecretsFromEnvironment()
+
+	actual, err := target.GetSecret("redisdb")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "redis", "password": "MyRedisPassword"}, actual)
+
+	actual, err = target.GetSecret("mqtt", "username")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"username": "mqtt-user"}, actual)
+
+	secretNames, err := target.ListSecretNames()
+	require.NoError(t, err)
+	sort.Strings(secretNames)
+	assert.Equal(t, []string{"mqtt", "redisdb"}, secretNames)
+
+	// The secrets from the environment are read-only
+	err = target.StoreSecret("redisdb", map[string]string{"password": "NewPassword"})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "not supported")
+}
+
+func TestInsecureProvider_SnapshotNames(t *testing.T) {
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(secretsFile, []byte(`{"secrets": [
+		{"secretName": "mqtt", "secretData": [{"key": "username", "value": "mqtt-user"}, {"key": "password", "value": "initial"}]}
+	]}`), 0600))
+
+	configuration := TestConfig{
+		InsecureSecrets: map[string]bootstrapConfig.InsecureSecretsInfo{
+			"DB":    {SecretName: "redisdb", SecretData: map[string]string{"username": "admin", "password": "sam123!"}},
+			"Other": {SecretName: "other", SecretData: map[string]string{"key": "value"}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	target := NewInsecureProvider(configuration, logger.NewMockClient())
+	require.NoError(t, target.WatchSecretsFile(ctx, secretsFile, time.Hour))
+
+	expected := map[string][]string{
+		"redisdb": {"password", "username"},
+		"other":   {"key"},
+		"mqtt":    {"password", "username"},
+	}
+	assert.Equal(t, expected, target.SnapshotNames())
+}
+
+func TestInsecureProvider_SnapshotNamesConcurrentStores(t *testing.T) {
+	secretsFile := filepath.Join(t.TempDir(), "secrets.json")
+	require.NoError(t, os.WriteFile(secretsFile, []byte(`{"secrets": [
+		{"secretName": "seed", "secretData": [{"key": "username", "value": "user"}, {"key": "password", "value": "pass"}]}
+	]}`), 0600))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	target := NewInsecureProvider(nil, logger.NewMockClient())
+	require.NoError(t, target.WatchSecretsFile(ctx, secretsFile, time.Hour))
+
+	const stores = 50
+	wg := sync.WaitGroup{}
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < stores; i++ {
+			assert.NoError(t, target.StoreSecret(fmt.Sprintf("secret-%d", i), map[string]string{"username": "user", "password": "pass"}))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < stores; i++ {
+			// Iterating the snapshot must be safe while secrets are stored
+			for secretName, keys := range target.SnapshotNames() {
+				assert.Equal(t, []string{"password", "username"}, keys, secretName)
+			}
+		}
+	}()
+	wg.Wait()
+
+	assert.Len(t, target.SnapshotNames(), stores+1)
+}
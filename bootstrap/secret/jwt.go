@@ -0,0 +1,100 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+)
+
+const (
+	// EnvAuthJWTLeeway is the environment variable used to override the leeway, i.e. 1m, allowed for the clock skew
+	// between services when checking the "exp" and "nbf" claims of JWTs. Setting it to 0s allows no leeway.
+	EnvAuthJWTLeeway = "EDGEX_AUTH_JWT_LEEWAY"
+	// DefaultJWTLeeway is the leeway allowed for the clock skew between services when EnvAuthJWTLeeway isn't set
+	DefaultJWTLeeway = 30 * time.Second
+)
+
+// JWTLeeway returns the leeway allowed for the clock skew between services when checking the times of JWTs, as set via
+// EnvAuthJWTLeeway, or DefaultJWTLeeway when it isn't set or is invalid.
+func JWTLeeway(lc logger.LoggingClient) time.Duration {
+	value := os.Getenv(EnvAuthJWTLeeway)
+	if len(value) == 0 {
+		return DefaultJWTLeeway
+	}
+
+	leeway, err := time.ParseDuration(value)
+	if err != nil || leeway < 0 {
+		lc.Warnf("Invalid %s value '%s', using the default leeway of %s", EnvAuthJWTLeeway, value, DefaultJWTLeeway)
+		return DefaultJWTLeeway
+	}
+
+	return leeway
+}
+
+// ValidateJWTTimes checks the "exp" and "nbf" claims of a JWT against now, as done by common JWT libraries, allowing
+// for the leeway in either direction to tolerate the clock skew between services. So the JWT is valid from its "nbf"
+// time minus the leeway until its "exp" time plus the leeway. The claims which aren't present aren't checked. An error
+// describing the failed check is returned when the JWT isn't valid at now.
+func ValidateJWTTimes(claims map[string]any, now time.Time, leeway time.Duration) error {
+	if value, found := claims["exp"]; found {
+		expires, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("JWT exp claim is not a number")
+		}
+		if expiresAt := time.Unix(int64(expires), 0); !now.Before(expiresAt.Add(leeway)) {
+			return fmt.Errorf("JWT expired at %s, which is beyond the leeway of %s", expiresAt.UTC().Format(time.RFC3339), leeway)
+		}
+	}
+
+	if value, found := claims["nbf"]; found {
+		notBefore, ok := value.(float64)
+		if !ok {
+			return fmt.Errorf("JWT nbf claim is not a number")
+		}
+		if notBeforeAt := time.Unix(int64(notBefore), 0); now.Before(notBeforeAt.Add(-leeway)) {
+			return fmt.Errorf("JWT is not valid before %s, which is beyond the leeway of %s", notBeforeAt.UTC().Format(time.RFC3339), leeway)
+		}
+	}
+
+	return nil
+}
+
+// ParseJWTClaims decodes the claims in the payload of the JWT without verifying its signature, so must only be used
+// for a JWT which has been validated.
+func ParseJWTClaims(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("JWT has %d parts, expected 3", len(parts))
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode JWT payload: %v", err)
+	}
+
+	claims := make(map[string]any)
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JWT claims: %v", err)
+	}
+
+	return claims, nil
+}
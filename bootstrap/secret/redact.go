@@ -0,0 +1,72 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package secret
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg"
+)
+
+const redactedText = "<redacted>"
+
+var (
+	// authHeaderRegexp matches the values of the headers carrying secret store tokens, i.e. "X-Vault-Token: hvs.xxx"
+	authHeaderRegexp = regexp.MustCompile(`(?i)((?:X-Vault-Token|Authorization)["']?\s*[:=]\s*["']?)(?:Bearer\s+)?[^\s"',;]+`)
+	// bearerRegexp matches bearer tokens not preceded by a header name
+	bearerRegexp = regexp.MustCompile(`(?i)(Bearer\s+)[^\s"',;]+`)
+	// vaultTokenRegexp matches the service, batch and recovery tokens of the secret store, both the current "hvs."
+	// prefixed format and the legacy "s." prefixed format
+	vaultTokenRegexp = regexp.MustCompile(`\b(?:hv[sbr]|[sbr])\.[A-Za-z0-9_\-]{20,}`)
+	// jwtRegexp matches encoded JWTs, such as the service's self JWT
+	jwtRegexp = regexp.MustCompile(`\beyJ[A-Za-z0-9_\-]*\.[A-Za-z0-9_\-]+\.[A-Za-z0-9_\-]*`)
+	// secretPathRegexp matches the paths of the secret store API, which contain the service's full secret paths,
+	// i.e. "/v1/secret/edgex/core-data/redisdb"
+	secretPathRegexp = regexp.MustCompile(`/v1/[^\s"'?]+`)
+)
+
+// redactedError is an error whose message has had the secret store tokens and secret paths redacted
+type redactedError struct {
+	message string
+}
+
+func (e *redactedError) Error() string {
+	return e.message
+}
+
+// redactSecretStoreError returns an error describing the failed operation, i.e. "failed to get secret 'redisdb'",
+// followed by the message of err with any secret store tokens and secret paths redacted, so they don't leak into the
+// logs of callers. The errors from the secrets module describing missing secrets and secret store failures are
+// returned unchanged, since their messages never contain either and callers check for their types.
+func redactSecretStoreError(err error, operation string) error {
+	switch err.(type) {
+	case nil:
+		return nil
+	case *redactedError, pkg.ErrSecretNameNotFound, pkg.ErrSecretsNotFound, pkg.ErrSecretStore:
+		return err
+	}
+
+	return &redactedError{message: fmt.Sprintf("%s: %s", operation, redactSecretText(err.Error()))}
+}
+
+// redactSecretText replaces the secret store tokens, bearer tokens, JWTs and secret paths in the text
+func redactSecretText(text string) string {
+	text = authHeaderRegexp.ReplaceAllString(text, "${1}"+redactedText)
+	text = bearerRegexp.ReplaceAllString(text, "${1}"+redactedText)
+	text = vaultTokenRegexp.ReplaceAllString(text, redactedText)
+	text = jwtRegexp.ReplaceAllString(text, redactedText)
+	return secretPathRegexp.ReplaceAllString(text, "/v1/"+redactedText)
+}
@@ -17,9 +17,13 @@ package secret
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
 	"path"
 	"strings"
+	"text/template"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
@@ -30,6 +34,7 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/container"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/utils"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 
 	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/token/authtokenloader"
@@ -45,7 +50,9 @@ const (
 	securityConsulTokenDurationName   = "SecurityConsulTokenDuration"
 )
 
-// NewSecretProvider creates a new fully initialized the Secret Provider.
+// NewSecretProvider creates a new fully initialized the Secret Provider. The paths of the secrets in the secret store
+// are built by the interfaces.SecretNameResolver in the DIC, if present, otherwise by DefaultSecretNameResolver. The
+// Secret Provider logs using the LoggingClient in the DIC.
 func NewSecretProvider(
 	configuration interfaces.Configuration,
 	envVars *environment.Variables,
@@ -64,11 +71,23 @@ func NewSecretProvider(
 
 		lc.Info("Creating SecretClient")
 
-		secretStoreConfig, err := BuildSecretStoreConfig(serviceKey, envVars, lc)
+		secretStoreConfig, err := BuildSecretStoreConfigFrom(serviceKey, configuration, envVars, lc)
 		if err != nil {
 			return nil, err
 		}
 
+		if factory, found := getBackend(secretStoreConfig.Type); found {
+			lc.Infof("Creating SecretProvider for the '%s' secret store backend", secretStoreConfig.Type)
+			provider, err = factory(ctx, secretStoreConfig, dic, serviceKey)
+			if err != nil {
+				return nil, fmt.Errorf("unable to create SecretProvider for the '%s' secret store backend: %v", secretStoreConfig.Type, err)
+			}
+			if provider == nil {
+				return nil, fmt.Errorf("the '%s' secret store backend did not create a SecretProvider", secretStoreConfig.Type)
+			}
+			break
+		}
+
 		for startupTimer.HasNotElapsed() {
 			var secretConfig types.SecretConfig
 
@@ -85,9 +104,15 @@ func NewSecretProvider(
 					secretStoreConfig.RuntimeTokenProvider)
 			}
 
-			secretConfig, err = getSecretConfig(secretStoreConfig, tokenLoader, runtimeTokenLoader, serviceKey, lc)
+			secretNameResolver := container.SecretNameResolverFrom(dic.Get)
+			if secretNameResolver == nil {
+				secretNameResolver = DefaultSecretNameResolver
+			}
+
+			secretConfig, err = getSecretConfig(secretStoreConfig, tokenLoader, runtimeTokenLoader, secretNameResolver, startupTimer, serviceKey, lc)
 			if err == nil {
 				secureProvider := NewSecureProvider(ctx, secretStoreConfig, lc, tokenLoader, runtimeTokenLoader, serviceKey)
+				secureProvider.SetSecretNameResolver(secretNameResolver)
 				var secretClient secrets.SecretClient
 
 				lc.Info("Attempting to create secret client")
@@ -98,6 +123,10 @@ func NewSecretProvider(
 				}
 
 				secretClient, err = secrets.NewSecretsClient(ctx, secretConfig, lc, tokenCallbackFunc)
+				if err == nil {
+					err = secureProvider.createFallbackClients(ctx, secretStoreConfig.FallbackNamespaces, secretConfig, tokenCallbackFunc)
+				}
+
 				if err == nil {
 					secureProvider.SetClient(secretClient)
 					provider = secureProvider
@@ -122,6 +151,11 @@ func NewSecretProvider(
 			}
 
 			lc.Warn(fmt.Sprintf("Retryable failure while creating SecretClient: %s", err.Error()))
+			if sealed, reachable, healthErr := probeSecretStoreHealth(secretStoreConfig); !reachable {
+				lc.Warnf("SecretStore is not reachable: %v", healthErr)
+			} else if sealed {
+				lc.Warn("SecretStore is reachable but sealed")
+			}
 			startupTimer.SleepForInterval()
 		}
 
@@ -130,7 +164,39 @@ func NewSecretProvider(
 		}
 
 	case false:
-		provider = NewInsecureProvider(configuration, lc)
+		insecureProvider := NewInsecureProvider(configuration, lc)
+
+		secretStoreConfig, err := BuildSecretStoreConfigFrom(serviceKey, configuration, envVars, lc)
+		if err != nil {
+			return nil, err
+		}
+
+		if secretStoreConfig.SecretsFromEnvironment {
+			insecureProvider.LoadSecretsFromEnvironment()
+		}
+
+		if len(strings.TrimSpace(secretStoreConfig.SecretsDirectory)) > 0 {
+			if err = insecureProvider.LoadSecretsDirectory(secretStoreConfig.SecretsDirectory); err != nil {
+				return nil, err
+			}
+		}
+
+		if secretStoreConfig.WatchSecretsFile && len(strings.TrimSpace(secretStoreConfig.SecretsFile)) > 0 {
+			if len(secretStoreConfig.SecretsFilePassphraseEnvVar) > 0 {
+				if passphrase := os.Getenv(secretStoreConfig.SecretsFilePassphraseEnvVar); len(passphrase) > 0 {
+					lc.Infof("Secrets file encryption enabled using passphrase from environment variable %s",
+						secretStoreConfig.SecretsFilePassphraseEnvVar)
+					insecureProvider.SetSecretsFilePassphrase(passphrase)
+				}
+			}
+
+			err = insecureProvider.WatchSecretsFile(ctx, secretStoreConfig.SecretsFile, defaultSecretsFileWatchInterval)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		provider = insecureProvider
 	}
 
 	dic.Update(di.ServiceConstructorMap{
@@ -150,33 +216,102 @@ func NewSecretProvider(
 // BuildSecretStoreConfig is public helper function that builds the SecretStore configuration
 // from default values and  environment override.
 func BuildSecretStoreConfig(serviceKey string, envVars *environment.Variables, lc logger.LoggingClient) (*config.SecretStoreInfo, error) {
+	return BuildSecretStoreConfigFrom(serviceKey, nil, envVars, lc)
+}
+
+// BuildSecretStoreConfigFrom is the same as BuildSecretStoreConfig, except that when the service's configuration
+// implements interfaces.SecretStoreConfiguration the settings set in its SecretStore section replace the default
+// values before the environment overrides are applied. Settings with empty, zero or false values in the section are
+// left as their default values. The section is only used as it is when this is called, so it must be loaded first,
+// i.e. by the configuration Processor, for settings from the Configuration Provider or files to be used.
+func BuildSecretStoreConfigFrom(
+	serviceKey string,
+	configuration interfaces.Configuration,
+	envVars *environment.Variables,
+	lc logger.LoggingClient) (*config.SecretStoreInfo, error) {
 	configWrapper := struct {
 		SecretStore config.SecretStoreInfo
 	}{
 		SecretStore: config.NewSecretStoreInfo(serviceKey),
 	}
 
+	if secretStoreConfig, ok := configuration.(interfaces.SecretStoreConfiguration); ok {
+		if section := secretStoreConfig.GetSecretStoreInfo(); section != nil {
+			var sectionMap map[string]any
+			if err := utils.ConvertToMapOmitEmpty(section, &sectionMap); err != nil {
+				return nil, fmt.Errorf("failed to convert SecretStore configuration: %v", err)
+			}
+			if err := utils.MergeValues(&configWrapper.SecretStore, sectionMap); err != nil {
+				return nil, fmt.Errorf("failed to merge SecretStore configuration: %v", err)
+			}
+			lc.Info("SecretStore information merged with the SecretStore section of the service's configuration")
+		}
+	}
+
 	count, err := envVars.OverrideConfiguration(&configWrapper)
 	if err != nil {
 		return nil, fmt.Errorf("failed to override SecretStore information: %v", err)
 	}
 
+	storeName, err := renderStoreName(configWrapper.SecretStore.StoreName, serviceKey, configWrapper.SecretStore.Instance)
+	if err != nil {
+		return nil, err
+	}
+	configWrapper.SecretStore.StoreName = storeName
+
 	lc.Infof("SecretStore information created with %d overrides applied", count)
 	return &configWrapper.SecretStore, nil
 }
 
+// renderStoreName renders the StoreName when it is a template, i.e. "{{.ServiceKey}}-{{.Instance}}", with the
+// ServiceKey and Instance variables. An error is returned when the template uses a variable which is unknown or empty.
+func renderStoreName(storeName string, serviceKey string, instance string) (string, error) {
+	if !strings.Contains(storeName, "{{") {
+		return storeName, nil
+	}
+
+	tmpl, err := template.New("StoreName").Option("missingkey=error").Parse(storeName)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse SecretStore StoreName template '%s': %v", storeName, err)
+	}
+
+	// Only the variables with values are set, so the template fails rather than rendering an empty value
+	variables := make(map[string]string)
+	if len(serviceKey) > 0 {
+		variables["ServiceKey"] = serviceKey
+	}
+	if len(instance) > 0 {
+		variables["Instance"] = instance
+	}
+
+	var rendered strings.Builder
+	if err := tmpl.Execute(&rendered, variables); err != nil {
+		return "", fmt.Errorf("failed to render SecretStore StoreName template '%s', since a variable is unresolved: %v", storeName, err)
+	}
+
+	return rendered.String(), nil
+}
+
 // getSecretConfig creates a SecretConfig based on the SecretStoreInfo configuration properties.
-// If a token file is present it will override the Authentication.AuthToken value.
+// The token is obtained using the first of the following that applies:
+//  1. the RuntimeTokenProvider, if enabled
+//  2. the TokenFile, if not empty
+//  3. the environment variable named by TokenEnvVar, if both it and the variable's value are not empty
+//
+// If none apply the SecretStore is treated as being in insecure mode. Loading the token from the RuntimeTokenProvider
+// or TokenFile is retried until the startupTimer has elapsed, since the token may not have been created yet.
 func getSecretConfig(secretStoreInfo *config.SecretStoreInfo,
 	tokenLoader authtokenloader.AuthTokenLoader,
 	runtimeTokenLoader runtimetokenprovider.RuntimeTokenProvider,
+	secretNameResolver interfaces.SecretNameResolver,
+	startupTimer startup.Timer,
 	serviceKey string,
 	lc logger.LoggingClient) (types.SecretConfig, error) {
 	secretConfig := types.SecretConfig{
 		Type:                 secretStoreInfo.Type, // Type of SecretStore implementation, i.e. Vault
 		Host:                 secretStoreInfo.Host,
 		Port:                 secretStoreInfo.Port,
-		BasePath:             addEdgeXSecretNamePrefix(secretStoreInfo.StoreName),
+		BasePath:             secretNameResolver(serviceKey, secretStoreInfo.StoreName),
 		SecretsFile:          secretStoreInfo.SecretsFile,
 		Protocol:             secretStoreInfo.Protocol,
 		Namespace:            secretStoreInfo.Namespace,
@@ -186,10 +321,13 @@ func getSecretConfig(secretStoreInfo *config.SecretStoreInfo,
 		RuntimeTokenProvider: secretStoreInfo.RuntimeTokenProvider,
 	}
 
+	envToken, envTokenFound := getAuthTokenFromEnv(secretStoreInfo)
+
 	// maybe insecure mode
-	// if both configs of token file and runtime token provider are empty or disabled
+	// if all of the token file, token env var and runtime token provider configs are empty or disabled
 	// then we treat that as insecure mode
-	if !IsSecurityEnabled() || (secretStoreInfo.TokenFile == "" && !secretConfig.RuntimeTokenProvider.Enabled) {
+	if !IsSecurityEnabled() ||
+		(secretStoreInfo.TokenFile == "" && !secretConfig.RuntimeTokenProvider.Enabled && !envTokenFound) {
 		lc.Info("insecure mode")
 		return secretConfig, nil
 	}
@@ -197,14 +335,23 @@ func getSecretConfig(secretStoreInfo *config.SecretStoreInfo,
 	// based on whether token provider config is configured or not, we will obtain token in different way
 	var token string
 	var err error
-	if secretConfig.RuntimeTokenProvider.Enabled {
+	switch {
+	case secretConfig.RuntimeTokenProvider.Enabled:
 		lc.Info("runtime token provider enabled")
 		// call spiffe token provider to get token on the fly
-		token, err = runtimeTokenLoader.GetRawToken(serviceKey)
-	} else {
+		token, err = loadTokenWithRetry(startupTimer, lc, "RuntimeTokenProvider", func() (string, error) {
+			return runtimeTokenLoader.GetRawToken(serviceKey)
+		})
+	case secretStoreInfo.TokenFile != "":
 		lc.Info("load token from file")
 		// else obtain the token from TokenFile
-		token, err = tokenLoader.Load(secretStoreInfo.TokenFile)
+		token, err = loadTokenWithRetry(startupTimer, lc, "TokenFile "+secretStoreInfo.TokenFile, func() (string, error) {
+			return tokenLoader.Load(secretStoreInfo.TokenFile)
+		})
+	default:
+		// Note: The token value must never be logged
+		lc.Infof("load token from environment variable %s", secretStoreInfo.TokenEnvVar)
+		token = envToken
 	}
 
 	if err != nil {
@@ -215,6 +362,60 @@ func getSecretConfig(secretStoreInfo *config.SecretStoreInfo,
 	return secretConfig, nil
 }
 
+// loadTokenWithRetry loads the token from the source, retrying at the startupTimer's interval until it has elapsed
+func loadTokenWithRetry(startupTimer startup.Timer, lc logger.LoggingClient, source string, load func() (string, error)) (string, error) {
+	for attempt := 1; ; attempt++ {
+		token, err := load()
+		if err == nil || !startupTimer.HasNotElapsed() {
+			return token, err
+		}
+
+		lc.Warnf("Attempt %d to load the SecretStore token from the %s failed: %v. Retrying in %s",
+			attempt, source, err, startupTimer.Interval())
+		startupTimer.SleepForInterval()
+	}
+}
+
+// newSecretStoreTLSConfig creates the TLS configuration used to probe the SecretStore's health from the
+// RootCaCertPath and ServerName settings. nil is returned if no root CA is configured.
+func newSecretStoreTLSConfig(secretStoreInfo *config.SecretStoreInfo) (*tls.Config, error) {
+	if len(secretStoreInfo.RootCaCertPath) == 0 {
+		return nil, nil
+	}
+
+	caCert, err := os.ReadFile(secretStoreInfo.RootCaCertPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read SecretStore root CA certificate %s: %v", secretStoreInfo.RootCaCertPath, err)
+	}
+
+	caCertPool := x509.NewCertPool()
+	if !caCertPool.AppendCertsFromPEM(caCert) {
+		return nil, fmt.Errorf("failed to parse SecretStore root CA certificate %s", secretStoreInfo.RootCaCertPath)
+	}
+
+	return &tls.Config{
+		RootCAs:    caCertPool,
+		ServerName: secretStoreInfo.ServerName,
+		MinVersion: tls.VersionTLS12,
+	}, nil
+}
+
+// getAuthTokenFromEnv returns the token from the environment variable named by TokenEnvVar and whether it was found.
+func getAuthTokenFromEnv(secretStoreInfo *config.SecretStoreInfo) (string, bool) {
+	if len(secretStoreInfo.TokenEnvVar) == 0 {
+		return "", false
+	}
+
+	token := strings.TrimSpace(os.Getenv(secretStoreInfo.TokenEnvVar))
+	return token, len(token) > 0
+}
+
+// DefaultSecretNameResolver is the default interfaces.SecretNameResolver, which places the secrets of every service
+// under "/v1/secret/edgex", regardless of the service key.
+func DefaultSecretNameResolver(_ string, secretName string) string {
+	return addEdgeXSecretNamePrefix(secretName)
+}
+
 func addEdgeXSecretNamePrefix(secretName string) string {
 	trimmedSecretName := strings.TrimSpace(secretName)
 
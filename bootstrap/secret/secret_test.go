@@ -16,13 +16,25 @@ package secret
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
 	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"math/big"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
 	"os"
+	"path/filepath"
 	"strconv"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
 	bootstrapConfig "github.com/edgexfoundry/go-mod-bootstrap/v3/config"
@@ -33,7 +45,11 @@ import (
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/startup"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/di"
 
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/token/authtokenloader"
 	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/token/authtokenloader/mocks"
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/token/fileioperformer"
+	runtimeTokenMock "github.com/edgexfoundry/go-mod-secrets/v3/pkg/token/runtimetokenprovider/mocks"
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/types"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -147,6 +163,77 @@ func TestNewSecretProvider(t *testing.T) {
 	}
 }
 
+func TestNewSecretProvider_SecretNameResolver(t *testing.T) {
+	t.Setenv(EnvSecretStore, "true")
+
+	var requestedMutex sync.Mutex
+	var requested []string
+	testServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedMutex.Lock()
+		requested = append(requested, r.URL.Path)
+		requestedMutex.Unlock()
+
+		switch r.URL.Path {
+		case "/v1/auth/token/lookup-self":
+			w.WriteHeader(http.StatusOK)
+			_, _ = w.Write([]byte(testTokenResponse))
+		case "/v1/secret/tenants/testServiceKey/testServiceKey/redisdb",
+			"/v1/secret/tenants/testServiceKey/shared/mqtt":
+			w.WriteHeader(http.StatusOK)
+			response, _ := json.Marshal(map[string]any{"data": expectedSecrets})
+			_, _ = w.Write(response)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer testServer.Close()
+
+	serverUrl, _ := url.Parse(testServer.URL)
+	t.Setenv("SECRETSTORE_PORT", serverUrl.Port())
+	t.Setenv("SECRETSTORE_FALLBACKNAMESPACES", "shared")
+
+	mockTokenLoader := &mocks.AuthTokenLoader{}
+	mockTokenLoader.On("Load", "/tmp/edgex/secrets/testServiceKey/secrets-token.json").Return("Test Token", nil)
+
+	var resolved []string
+	resolver := interfaces.SecretNameResolver(func(serviceKey string, secretName string) string {
+		resolved = append(resolved, secretName)
+		return "/v1/secret/tenants/" + serviceKey + "/" + secretName
+	})
+
+	dic := di.NewContainer(di.ServiceConstructorMap{
+		container.LoggingClientInterfaceName: func(get di.Get) interface{} {
+			return logger.NewMockClient()
+		},
+		container.AuthTokenLoaderInterfaceName: func(get di.Get) interface{} {
+			return mockTokenLoader
+		},
+		container.SecretNameResolverName: func(get di.Get) interface{} {
+			return resolver
+		},
+	})
+
+	lc := logger.NewMockClient()
+	provider, err := NewSecretProvider(nil, environment.NewVariables(lc), context.Background(), startup.NewStartUpTimer("UnitTest"), dic, "testServiceKey")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"testServiceKey", "shared"}, resolved)
+
+	actualSecrets, err := provider.GetSecret(expectedSecretName)
+	require.NoError(t, err)
+	assert.Equal(t, expectedSecrets, actualSecrets)
+
+	// Secrets not in the service's own namespace are found in the fallback namespace at its resolved path
+	actualSecrets, err = provider.GetSecret("mqtt")
+	require.NoError(t, err)
+	assert.Equal(t, expectedSecrets, actualSecrets)
+
+	requestedMutex.Lock()
+	defer requestedMutex.Unlock()
+	assert.Contains(t, requested, "/v1/secret/tenants/testServiceKey/testServiceKey/redisdb")
+	assert.Contains(t, requested, "/v1/secret/tenants/testServiceKey/shared/mqtt")
+	assert.NotContains(t, requested, "/v1/secret/edgex/testServiceKey/redisdb")
+}
+
 func TestAddPrefix(t *testing.T) {
 	expectedPrefixPath := "/v1/secret/edgex/"
 
@@ -196,3 +283,312 @@ func TestBuildSecretStoreConfig(t *testing.T) {
 	assert.Equal(t, expectedRuntimeTokenProviderHost, target.RuntimeTokenProvider.Host)
 	assert.Equal(t, expectedRuntimeTokenProviderRequiredSecrets, target.RuntimeTokenProvider.RequiredSecrets)
 }
+
+// secretStoreTestConfig is a service configuration with a SecretStore section
+type secretStoreTestConfig struct {
+	TestConfig
+	SecretStore *bootstrapConfig.SecretStoreInfo
+}
+
+func (c secretStoreTestConfig) GetSecretStoreInfo() *bootstrapConfig.SecretStoreInfo {
+	return c.SecretStore
+}
+
+func TestBuildSecretStoreConfigFrom(t *testing.T) {
+	section := &bootstrapConfig.SecretStoreInfo{
+		Host:      "config-vault",
+		Port:      8300,
+		Namespace: "config-namespace",
+		RuntimeTokenProvider: types.RuntimeTokenProviderInfo{
+			Host: "config-token-provider",
+		},
+	}
+
+	defaults := bootstrapConfig.NewSecretStoreInfo("unit-test")
+
+	tests := []struct {
+		Name              string
+		Configuration     interfaces.Configuration
+		EnvVars           map[string]string
+		ExpectedHost      string
+		ExpectedPort      int
+		ExpectedNamespace string
+		ExpectedRTPHost   string
+	}{
+		{"Defaults - no section", TestConfig{}, nil,
+			defaults.Host, defaults.Port, defaults.Namespace, defaults.RuntimeTokenProvider.Host},
+		{"Defaults - nil section", secretStoreTestConfig{}, nil,
+			defaults.Host, defaults.Port, defaults.Namespace, defaults.RuntimeTokenProvider.Host},
+		{"Configuration section", secretStoreTestConfig{SecretStore: section}, nil,
+			"config-vault", 8300, "config-namespace", "config-token-provider"},
+		{"Env overrides take precedence", secretStoreTestConfig{SecretStore: section},
+			map[string]string{"SECRETSTORE_HOST": "env-vault", "SECRETSTORE_RUNTIMETOKENPROVIDER_HOST": "env-token-provider"},
+			"env-vault", 8300, "config-namespace", "env-token-provider"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests leave SecretStore overrides set, which must not hide the values from the section
+			for _, envVar := range os.Environ() {
+				if name, _, _ := strings.Cut(envVar, "="); strings.HasPrefix(name, "SECRETSTORE_") {
+					t.Setenv(name, "")
+					_ = os.Unsetenv(name)
+				}
+			}
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			lc := logger.NewMockClient()
+			target, err := BuildSecretStoreConfigFrom("unit-test", tc.Configuration, environment.NewVariables(lc), lc)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedHost, target.Host)
+			assert.Equal(t, tc.ExpectedPort, target.Port)
+			assert.Equal(t, tc.ExpectedNamespace, target.Namespace)
+			assert.Equal(t, tc.ExpectedRTPHost, target.RuntimeTokenProvider.Host)
+			// The settings not set in the section keep their default values
+			assert.Equal(t, defaults.TokenFile, target.TokenFile)
+			assert.Equal(t, defaults.Type, target.Type)
+			assert.Equal(t, defaults.RuntimeTokenProvider.Port, target.RuntimeTokenProvider.Port)
+		})
+	}
+}
+
+func TestBuildSecretStoreConfigStoreNameTemplate(t *testing.T) {
+	tests := []struct {
+		Name              string
+		EnvVars           map[string]string
+		ExpectedStoreName string
+		ExpectedError     string
+	}{
+		{"Valid - default", nil, "unit-test", ""},
+		{"Valid - not a template", map[string]string{"SECRETSTORE_STORENAME": "shared"}, "shared", ""},
+		{"Valid - service key and instance",
+			map[string]string{"SECRETSTORE_STORENAME": "{{.ServiceKey}}-{{.Instance}}", "SECRETSTORE_INSTANCE": "tenant-a"},
+			"unit-test-tenant-a", ""},
+		{"Invalid - instance not set",
+			map[string]string{"SECRETSTORE_STORENAME": "{{.ServiceKey}}-{{.Instance}}"}, "", "unresolved"},
+		{"Invalid - unknown variable",
+			map[string]string{"SECRETSTORE_STORENAME": "{{.ServiceKey}}-{{.Tenant}}", "SECRETSTORE_INSTANCE": "tenant-a"}, "", "unresolved"},
+		{"Invalid - template", map[string]string{"SECRETSTORE_STORENAME": "{{.ServiceKey"}, "", "failed to parse"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			// Other tests leave SecretStore overrides set
+			for _, envVar := range os.Environ() {
+				if name, _, _ := strings.Cut(envVar, "="); strings.HasPrefix(name, "SECRETSTORE_") {
+					t.Setenv(name, "")
+					_ = os.Unsetenv(name)
+				}
+			}
+			for name, value := range tc.EnvVars {
+				t.Setenv(name, value)
+			}
+
+			lc := logger.NewMockClient()
+			target, err := BuildSecretStoreConfig("unit-test", environment.NewVariables(lc), lc)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.ExpectedStoreName, target.StoreName)
+		})
+	}
+}
+
+func TestGetSecretConfigTokenSelection(t *testing.T) {
+	fileToken := "file-token"
+	envToken := "env-token"
+	runtimeToken := "runtime-token"
+	tokenFile := "/tmp/edgex/secrets/unit-test/secrets-token.json"
+	tokenEnvVar := "UNIT_TEST_SECRETSTORE_AUTHTOKEN"
+
+	tests := []struct {
+		Name            string
+		TokenFile       string
+		TokenEnvVar     string
+		EnvValue        string
+		RuntimeEnabled  bool
+		ExpectedToken   string
+		ExpectedFileUse bool
+	}{
+		{"Runtime token", tokenFile, tokenEnvVar, envToken, true, runtimeToken, false},
+		{"File token", tokenFile, tokenEnvVar, envToken, false, fileToken, true},
+		{"Env token", "", tokenEnvVar, envToken, false, envToken, false},
+		{"Env token - default env var name", "", bootstrapConfig.DefaultSecretStoreTokenEnvVar, envToken, false, envToken, false},
+		{"Insecure - env var empty", "", tokenEnvVar, "", false, "", false},
+		{"Insecure - env var name not set", "", "", envToken, false, "", false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			t.Setenv(EnvSecretStore, "true")
+			if len(tc.TokenEnvVar) > 0 {
+				t.Setenv(tc.TokenEnvVar, tc.EnvValue)
+			}
+			// Ensure the default env var doesn't leak into the cases using another name
+			if tc.TokenEnvVar != bootstrapConfig.DefaultSecretStoreTokenEnvVar {
+				t.Setenv(bootstrapConfig.DefaultSecretStoreTokenEnvVar, "")
+			}
+
+			secretStoreInfo := bootstrapConfig.NewSecretStoreInfo("unit-test")
+			secretStoreInfo.TokenFile = tc.TokenFile
+			secretStoreInfo.TokenEnvVar = tc.TokenEnvVar
+			secretStoreInfo.RuntimeTokenProvider.Enabled = tc.RuntimeEnabled
+
+			mockTokenLoader := &mocks.AuthTokenLoader{}
+			mockTokenLoader.On("Load", tokenFile).Return(fileToken, nil)
+			mockRuntimeProvider := &runtimeTokenMock.RuntimeTokenProvider{}
+			mockRuntimeProvider.On("GetRawToken", "unit-test").Return(runtimeToken, nil)
+
+			secretConfig, err := getSecretConfig(&secretStoreInfo, mockTokenLoader, mockRuntimeProvider, DefaultSecretNameResolver, startup.NewTimer(1, 1), "unit-test", logger.NewMockClient())
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.ExpectedToken, secretConfig.Authentication.AuthToken)
+			if tc.ExpectedFileUse {
+				mockTokenLoader.AssertCalled(t, "Load", tokenFile)
+			} else {
+				mockTokenLoader.AssertNotCalled(t, "Load", tokenFile)
+			}
+			if !tc.RuntimeEnabled {
+				mockRuntimeProvider.AssertNotCalled(t, "GetRawToken", "unit-test")
+			}
+		})
+	}
+}
+
+// lateTokenFileLoader loads the token file, which it writes after the first attempt to load it
+type lateTokenFileLoader struct {
+	loader   authtokenloader.AuthTokenLoader
+	contents string
+	attempts int
+}
+
+func (l *lateTokenFileLoader) Load(path string) (string, error) {
+	l.attempts++
+	token, err := l.loader.Load(path)
+	if l.attempts == 1 && err != nil {
+		if writeErr := os.WriteFile(path, []byte(l.contents), 0600); writeErr != nil {
+			return "", writeErr
+		}
+	}
+	return token, err
+}
+
+func TestGetSecretConfigTokenRetry(t *testing.T) {
+	t.Setenv(EnvSecretStore, "true")
+	tokenFile := filepath.Join(t.TempDir(), "secrets-token.json")
+
+	secretStoreInfo := bootstrapConfig.NewSecretStoreInfo("unit-test")
+	secretStoreInfo.TokenFile = tokenFile
+	secretStoreInfo.RuntimeTokenProvider.Enabled = false
+
+	// The token file is written by the security bootstrapper, so may not exist until after the first attempt
+	tokenLoader := &lateTokenFileLoader{
+		loader:   authtokenloader.NewAuthTokenLoader(fileioperformer.NewDefaultFileIoPerformer()),
+		contents: `{"auth":{"client_token":"late-token"}}`,
+	}
+
+	secretConfig, err := getSecretConfig(&secretStoreInfo, tokenLoader, nil, DefaultSecretNameResolver,
+		startup.NewTimer(5, 1), "unit-test", logger.NewMockClient())
+	require.NoError(t, err)
+	assert.Equal(t, "late-token", secretConfig.Authentication.AuthToken)
+	assert.Equal(t, 2, tokenLoader.attempts)
+
+	// The runtime token is retried the same way
+	secretStoreInfo.RuntimeTokenProvider.Enabled = true
+	mockRuntimeProvider := &runtimeTokenMock.RuntimeTokenProvider{}
+	mockRuntimeProvider.On("GetRawToken", "unit-test").Return("", errors.New("token provider not ready")).Once()
+	mockRuntimeProvider.On("GetRawToken", "unit-test").Return("runtime-token", nil).Once()
+
+	secretConfig, err = getSecretConfig(&secretStoreInfo, tokenLoader, mockRuntimeProvider, DefaultSecretNameResolver,
+		startup.NewTimer(5, 1), "unit-test", logger.NewMockClient())
+	require.NoError(t, err)
+	assert.Equal(t, "runtime-token", secretConfig.Authentication.AuthToken)
+	mockRuntimeProvider.AssertNumberOfCalls(t, "GetRawToken", 2)
+
+	// Gives up once the startup timer has elapsed
+	mockRuntimeProvider = &runtimeTokenMock.RuntimeTokenProvider{}
+	mockRuntimeProvider.On("GetRawToken", "unit-test").Return("", errors.New("token provider not ready"))
+	_, err = getSecretConfig(&secretStoreInfo, tokenLoader, mockRuntimeProvider, DefaultSecretNameResolver,
+		startup.NewTimer(0, 1), "unit-test", logger.NewMockClient())
+	require.Error(t, err)
+	mockRuntimeProvider.AssertNumberOfCalls(t, "GetRawToken", 1)
+}
+
+func TestNewSecretStoreTLSConfig(t *testing.T) {
+	certFile, keyFile := writeTestCertificate(t)
+
+	tests := []struct {
+		Name           string
+		RootCaCertPath string
+		ExpectNil      bool
+		ExpectedError  string
+	}{
+		{"Valid - root CA", certFile, false, ""},
+		{"Valid - no TLS", "", true, ""},
+		{"Invalid - missing root CA file", certFile + ".missing", true, "failed to read SecretStore root CA certificate"},
+		{"Invalid - root CA not a certificate", keyFile, true, "failed to parse SecretStore root CA certificate"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			secretStoreInfo := bootstrapConfig.NewSecretStoreInfo("unit-test")
+			secretStoreInfo.ServerName = "edgex-vault"
+			secretStoreInfo.RootCaCertPath = tc.RootCaCertPath
+
+			tlsConfig, err := newSecretStoreTLSConfig(&secretStoreInfo)
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.ExpectNil {
+				assert.Nil(t, tlsConfig)
+				return
+			}
+
+			require.NotNil(t, tlsConfig)
+			assert.Equal(t, "edgex-vault", tlsConfig.ServerName)
+			assert.NotNil(t, tlsConfig.RootCAs)
+		})
+	}
+}
+
+// writeTestCertificate writes a self-signed certificate and its private key to PEM files in a temp directory
+func writeTestCertificate(t *testing.T) (string, string) {
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "unit-test"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		ExtKeyUsage:           []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+
+	certBytes, err := x509.CreateCertificate(rand.Reader, template, template, &privateKey.PublicKey, privateKey)
+	require.NoError(t, err)
+	keyBytes, err := x509.MarshalECPrivateKey(privateKey)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	certFile := filepath.Join(dir, "client.crt")
+	keyFile := filepath.Join(dir, "client.key")
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certBytes}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyBytes}), 0600))
+
+	return certFile, keyFile
+}
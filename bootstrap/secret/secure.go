@@ -17,15 +17,19 @@ package secret
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	"github.com/edgexfoundry/go-mod-secrets/v3/pkg"
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/types"
 	gometrics "github.com/rcrowley/go-metrics"
 
 	"github.com/edgexfoundry/go-mod-core-contracts/v3/dtos/common"
@@ -43,6 +47,14 @@ const (
 	AccessTokenAuthError = "HTTP response with status code 403"
 	//nolint: gosec
 	SecretsAuthError = "Received a '403' response"
+
+	// secretStoreHealthPath is the secret store's health endpoint, which doesn't require a token
+	secretStoreHealthPath    = "/v1/sys/health"
+	secretStoreHealthTimeout = 5 * time.Second
+	// vaultStatusDRSecondary and vaultStatusPerformanceStandby are the health endpoint's non-standard status codes
+	// for nodes which are unsealed but not the active node
+	vaultStatusDRSecondary        = 472
+	vaultStatusPerformanceStandby = 473
 )
 
 // SecureProvider implements the SecretProvider interface
@@ -57,12 +69,31 @@ type SecureProvider struct {
 	secretsCache                  map[string]map[string]string // secret's secretName, key, value
 	cacheMutex                    *sync.RWMutex
 	lastUpdated                   time.Time
+	secretsLastUpdated            map[string]time.Time
+	secretsLastUpdatedMutex       sync.RWMutex
 	ctx                           context.Context
 	registeredSecretCallbacks     map[string]func(secretName string)
 	securitySecretsRequested      gometrics.Counter
 	securitySecretsStored         gometrics.Counter
 	securityConsulTokensRequested gometrics.Counter
 	securityConsulTokenDuration   gometrics.Timer
+	// fallbackClients are the clients for the fallback namespaces, in the order they are searched
+	fallbackClients []namespacedSecretClient
+	// patchMutex serializes PatchSecret calls so concurrent patches don't overwrite each other's updates
+	patchMutex sync.Mutex
+	// secretNameResolver builds the paths of the fallback namespaces in the secret store
+	secretNameResolver interfaces.SecretNameResolver
+	// tokenRenewedCallbacks are invoked with the new self JWT after each successful token renewal
+	tokenRenewedCallbacks []func(newSelfJWT string)
+	tokenRenewedMutex     sync.Mutex
+	// jwtLeeway is the leeway allowed for the clock skew between services when checking the times of JWTs
+	jwtLeeway time.Duration
+}
+
+// namespacedSecretClient is a secret client for accessing the secrets in a fallback namespace
+type namespacedSecretClient struct {
+	namespace string
+	client    secrets.SecretClient
 }
 
 // NewSecureProvider creates & initializes Provider instance for secure secrets.
@@ -78,12 +109,15 @@ func NewSecureProvider(ctx context.Context, secretStoreInfo *config.SecretStoreI
 		secretsCache:                  make(map[string]map[string]string),
 		cacheMutex:                    &sync.RWMutex{},
 		lastUpdated:                   time.Now(),
+		secretsLastUpdated:            make(map[string]time.Time),
 		ctx:                           ctx,
 		registeredSecretCallbacks:     make(map[string]func(secretName string)),
 		securitySecretsRequested:      gometrics.NewCounter(),
 		securitySecretsStored:         gometrics.NewCounter(),
 		securityConsulTokensRequested: gometrics.NewCounter(),
 		securityConsulTokenDuration:   gometrics.NewTimer(),
+		secretNameResolver:            DefaultSecretNameResolver,
+		jwtLeeway:                     JWTLeeway(lc),
 	}
 	return provider
 }
@@ -93,6 +127,43 @@ func (p *SecureProvider) SetClient(client secrets.SecretClient) {
 	p.secretClient = client
 }
 
+// SetSecretNameResolver sets the resolver used to build the paths of the fallback namespaces in the secret store, which
+// must be the same as the one used for the service's own secret client. Defaults to DefaultSecretNameResolver.
+func (p *SecureProvider) SetSecretNameResolver(resolver interfaces.SecretNameResolver) {
+	p.secretNameResolver = resolver
+}
+
+// AddFallbackClient adds a secret client for a fallback namespace. Fallback namespaces are searched, in the order
+// they are added, for secrets not found in the service's own namespace.
+func (p *SecureProvider) AddFallbackClient(namespace string, client secrets.SecretClient) {
+	p.fallbackClients = append(p.fallbackClients, namespacedSecretClient{namespace: namespace, client: client})
+}
+
+// createFallbackClients creates the secret clients for the comma separated list of fallback namespaces
+func (p *SecureProvider) createFallbackClients(ctx context.Context, fallbackNamespaces string, secretConfig types.SecretConfig,
+	tokenCallback pkg.TokenExpiredCallback) error {
+	p.fallbackClients = nil
+
+	for _, namespace := range strings.Split(fallbackNamespaces, ",") {
+		namespace = strings.TrimSpace(namespace)
+		if len(namespace) == 0 {
+			continue
+		}
+
+		fallbackConfig := secretConfig
+		fallbackConfig.BasePath = p.secretNameResolver(p.serviceKey, namespace)
+		client, err := secrets.NewSecretsClient(ctx, fallbackConfig, p.lc, tokenCallback)
+		if err != nil {
+			return fmt.Errorf("unable to create SecretClient for fallback namespace '%s': %s", namespace, err.Error())
+		}
+
+		p.AddFallbackClient(namespace, client)
+		p.lc.Infof("Created SecretClient for fallback namespace '%s'", namespace)
+	}
+
+	return nil
+}
+
 // GetSecret retrieves secrets from a secret store.
 // secretName specifies the type or location of the secrets to retrieve.
 // keys specifies the secrets which to retrieve. If no keys are provided then all the keys associated with the
@@ -116,14 +187,46 @@ func (p *SecureProvider) GetSecret(secretName string, keys ...string) (map[strin
 		secureSecrets, err = p.secretClient.GetSecret(secretName, keys...)
 	}
 
+	if _, notFound := err.(pkg.ErrSecretNameNotFound); notFound && len(p.fallbackClients) > 0 {
+		secureSecrets, err = p.getFallbackSecret(secretName, err, keys...)
+	}
+
 	if err != nil {
-		return nil, err
+		return nil, redactSecretStoreError(err, fmt.Sprintf("failed to get secret '%s'", secretName))
 	}
 
 	p.updateSecretsCache(secretName, secureSecrets)
 	return secureSecrets, nil
 }
 
+// getFallbackSecret searches the fallback namespaces, in order, for a secret not found in the service's namespace.
+// The first secret found is returned, otherwise the passed in not found error is returned.
+func (p *SecureProvider) getFallbackSecret(secretName string, notFoundErr error, keys ...string) (map[string]string, error) {
+	namespaces := make([]string, 0, len(p.fallbackClients)+1)
+	namespaces = append(namespaces, p.secretStoreInfo.StoreName)
+	for _, fallback := range p.fallbackClients {
+		namespaces = append(namespaces, fallback.namespace)
+	}
+
+	p.lc.Debugf("Secret '%s' not found in namespace '%s', resolving using namespaces in order: %s",
+		secretName, p.secretStoreInfo.StoreName, strings.Join(namespaces, ", "))
+
+	for _, fallback := range p.fallbackClients {
+		secureSecrets, err := fallback.client.GetSecret(secretName, keys...)
+		if err == nil {
+			p.lc.Debugf("Secret '%s' found in fallback namespace '%s'", secretName, fallback.namespace)
+			return secureSecrets, nil
+		}
+
+		if _, notFound := err.(pkg.ErrSecretNameNotFound); !notFound {
+			return nil, redactSecretStoreError(err, fmt.Sprintf("failed to get secret '%s' from fallback namespace '%s'", secretName, fallback.namespace))
+		}
+	}
+
+	p.lc.Debugf("Secret '%s' not found in any namespace", secretName)
+	return nil, notFoundErr
+}
+
 func (p *SecureProvider) getSecretsCache(secretName string, keys ...string) map[string]string {
 	secureSecrets := make(map[string]string)
 
@@ -188,7 +291,7 @@ func (p *SecureProvider) StoreSecret(secretName string, secrets map[string]strin
 	}
 
 	if err != nil {
-		return err
+		return redactSecretStoreError(err, fmt.Sprintf("failed to store secret '%s'", secretName))
 	}
 
 	// Execute Callbacks on registered secret secretNames.
@@ -204,6 +307,41 @@ func (p *SecureProvider) StoreSecret(secretName string, secrets map[string]strin
 	return nil
 }
 
+// PatchSecret merges the updates into the secret at the specified secretName and stores the result to the secret
+// store. Existing keys not in the updates are preserved and keys whose value is interfaces.DeleteSecretKeyValue are
+// removed. The secret is created if it doesn't already exist. The secret store doesn't support conditional writes, so
+// patches are serialized within the service to avoid concurrent patches overwriting each other.
+func (p *SecureProvider) PatchSecret(secretName string, updates map[string]string) error {
+	if p.secretClient == nil {
+		return errors.New("can't patch secrets. Secure secret provider is not properly initialized")
+	}
+
+	p.patchMutex.Lock()
+	defer p.patchMutex.Unlock()
+
+	p.securitySecretsRequested.Inc(1)
+
+	// The existing secret is read directly from the secret store rather than the cache so that the merge is done
+	// against the latest values.
+	existing, err := p.secretClient.GetSecret(secretName)
+
+	retry, err := p.reloadTokenOnAuthError(err)
+	if retry {
+		// Retry with potential new token
+		existing, err = p.secretClient.GetSecret(secretName)
+	}
+
+	if _, notFound := err.(pkg.ErrSecretNameNotFound); notFound {
+		existing, err = nil, nil
+	}
+
+	if err != nil {
+		return redactSecretStoreError(err, fmt.Sprintf("unable to get existing secret '%s' to patch", secretName))
+	}
+
+	return p.StoreSecret(secretName, patchSecretData(existing, updates))
+}
+
 func (p *SecureProvider) reloadTokenOnAuthError(err error) (bool, error) {
 	if err == nil {
 		return false, nil
@@ -255,7 +393,7 @@ func (p *SecureProvider) GetAccessToken(tokenType string, serviceKey string) (st
 		}
 
 		if err != nil {
-			return "", err
+			return "", redactSecretStoreError(err, "failed to generate Consul token")
 		}
 
 		return token, nil
@@ -265,13 +403,61 @@ func (p *SecureProvider) GetAccessToken(tokenType string, serviceKey string) (st
 	}
 }
 
+// GetAccessTokens returns the access tokens for the requested token type and service keys, keyed by service key. The
+// tokens are requested concurrently and the errors for the service keys whose token couldn't be obtained are joined.
+func (p *SecureProvider) GetAccessTokens(tokenType string, serviceKeys ...string) (map[string]string, error) {
+	tokens := make(map[string]string, len(serviceKeys))
+	var errs error
+	var mutex sync.Mutex
+	var wg sync.WaitGroup
+
+	requested := make(map[string]bool, len(serviceKeys))
+	for _, serviceKey := range serviceKeys {
+		if requested[serviceKey] {
+			continue
+		}
+		requested[serviceKey] = true
+
+		wg.Add(1)
+		go func(serviceKey string) {
+			defer wg.Done()
+
+			token, err := p.GetAccessToken(tokenType, serviceKey)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			if err != nil {
+				errs = multierror.Append(errs, fmt.Errorf("failed to get access token for service key '%s': %w", serviceKey, err))
+				return
+			}
+			tokens[serviceKey] = token
+		}(serviceKey)
+	}
+
+	wg.Wait()
+
+	return tokens, errs
+}
+
 // DefaultTokenExpiredCallback is the default implementation of tokenExpiredCallback function
-// It utilizes the tokenFile to re-read the token and enable retry if any update from the expired token
+// It utilizes the tokenFile, or the token env var if the tokenFile is not set, to re-read the token and enable retry if
+// any update from the expired token
 func (p *SecureProvider) DefaultTokenExpiredCallback(expiredToken string) (replacementToken string, retry bool) {
 	tokenFile := p.secretStoreInfo.TokenFile
 
+	// when the token was provided via the environment, re-read it from there instead
+	if tokenFile == "" {
+		reReadToken, found := getAuthTokenFromEnv(&p.secretStoreInfo)
+		if !found || reReadToken == expiredToken {
+			p.lc.Error("No new replacement token found for the expired token")
+			return reReadToken, false
+		}
+
+		return p.adoptReplacementToken(reReadToken)
+	}
+
 	// during the callback, we want to re-read the token from the disk
-	// specified by tokenFile and set the retry to true if a new token
+	// specified by tokenFile and adopt it if the new token
 	// is different from the expiredToken
 	reReadToken, err := p.loader.Load(tokenFile)
 	if err != nil {
@@ -284,7 +470,7 @@ func (p *SecureProvider) DefaultTokenExpiredCallback(expiredToken string) (repla
 		return reReadToken, false
 	}
 
-	return reReadToken, true
+	return p.adoptReplacementToken(reReadToken)
 }
 
 func (p *SecureProvider) RuntimeTokenExpiredCallback(expiredToken string) (replacementToken string, retry bool) {
@@ -294,7 +480,29 @@ func (p *SecureProvider) RuntimeTokenExpiredCallback(expiredToken string) (repla
 		return "", false
 	}
 
-	return newToken, true
+	return p.adoptReplacementToken(newToken)
+}
+
+// adoptReplacementToken sets the replacement for an expired token on the secret clients, the same as RenewToken does,
+// so the token renewed callbacks are invoked when the token expires. Setting the token starts a new renewal routine
+// in the secret client, so retry is only true, for the expired token's renewal routine to continue with the
+// replacement token, when the token couldn't be set.
+func (p *SecureProvider) adoptReplacementToken(token string) (replacementToken string, retry bool) {
+	// The secret client replaces the token itself when it expires before the client is set
+	if p.secretClient == nil {
+		return token, true
+	}
+
+	if err := p.secretClient.SetAuthToken(p.ctx, token); err != nil {
+		p.lc.Error(redactSecretStoreError(err, "failed to set replacement secret store token").Error())
+		return token, true
+	}
+
+	if err := p.tokenReplaced(token); err != nil {
+		p.lc.Error(err.Error())
+	}
+
+	return token, false
 }
 
 // LoadServiceSecrets loads the service secrets from the specified file and stores them in the service's SecretStore
@@ -340,9 +548,9 @@ func (p *SecureProvider) seedSecrets(contents []byte) ([]byte, error) {
 		secretName, data := prepareSecret(secret)
 		err := p.StoreSecret(secretName, data)
 		if err != nil {
-			message := fmt.Sprintf("failed to store secret for '%s': %s", secret.SecretName, err.Error())
-			p.lc.Errorf(message)
-			seedingErrs = multierror.Append(seedingErrs, errors.New(message))
+			// The error already names the secret and has any secret store tokens and paths redacted
+			p.lc.Errorf(err.Error())
+			seedingErrs = multierror.Append(seedingErrs, err)
 			continue
 		}
 
@@ -404,14 +612,46 @@ func (p *SecureProvider) ListSecretNames() ([]string, error) {
 	}
 
 	if err != nil {
-		return nil, fmt.Errorf("unable to get secret secretNames: %v", err)
+		return nil, redactSecretStoreError(err, "unable to get secret secretNames")
 	}
 
 	return secureSecrets, nil
 }
 
-// RegisteredSecretUpdatedCallback registers a callback for a secret.
+// ListSecretsMetadata returns the name, key names and last updated time for all the secrets for the current service
+// from the secret store. Secret values are never returned. The secret store client has no API for a secret's metadata,
+// so each secret is read to determine its key names, bypassing the secrets cache so the values aren't retained.
+func (p *SecureProvider) ListSecretsMetadata() ([]interfaces.SecretMetadata, error) {
+	secretNames, err := p.ListSecretNames()
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]interfaces.SecretMetadata, 0, len(secretNames))
+	for _, secretName := range secretNames {
+		secrets, err := p.secretClient.GetSecret(secretName)
+		if err != nil {
+			return nil, redactSecretStoreError(err, fmt.Sprintf("unable to get key names for secret '%s'", secretName))
+		}
+
+		results = append(results, interfaces.SecretMetadata{
+			SecretName:  secretName,
+			Keys:        secretDataKeys(secrets),
+			LastUpdated: p.secretLastUpdated(secretName),
+		})
+	}
+
+	sortSecretsMetadata(results)
+	return results, nil
+}
+
+// RegisteredSecretUpdatedCallback registers a callback for a secret. The secretName may be a glob pattern
+// (see path.Match), i.e. "mqtt-*", in which case the callback is invoked for every updated secretName that matches.
 func (p *SecureProvider) RegisteredSecretUpdatedCallback(secretName string, callback func(secretName string)) error {
+	if err := validateSecretNamePattern(secretName); err != nil {
+		return err
+	}
+
 	if _, ok := p.registeredSecretCallbacks[secretName]; ok {
 		return fmt.Errorf("there is a callback already registered for secretName '%v'", secretName)
 	}
@@ -425,18 +665,22 @@ func (p *SecureProvider) RegisteredSecretUpdatedCallback(secretName string, call
 // SecretUpdatedAtSecretName performs updates and callbacks for an updated secret or secretName.
 func (p *SecureProvider) SecretUpdatedAtSecretName(secretName string) {
 	p.lastUpdated = time.Now()
+	p.secretsLastUpdatedMutex.Lock()
+	p.secretsLastUpdated[secretName] = p.lastUpdated
+	p.secretsLastUpdatedMutex.Unlock()
 	if p.registeredSecretCallbacks != nil {
-		// Execute Callback for provided secretName.
-		for k, v := range p.registeredSecretCallbacks {
-			if k == secretName {
-				p.lc.Debugf("invoking callback registered for secretName: '%s'", secretName)
-				v(secretName)
-				return
-			}
-		}
+		// Execute Callbacks registered for provided secretName or a pattern matching it.
+		invokeSecretUpdatedCallbacks(p.lc, p.registeredSecretCallbacks, secretName)
 	}
 }
 
+// secretLastUpdated returns the last time the secret was updated by this provider, which is zero when unknown
+func (p *SecureProvider) secretLastUpdated(secretName string) time.Time {
+	p.secretsLastUpdatedMutex.RLock()
+	defer p.secretsLastUpdatedMutex.RUnlock()
+	return p.secretsLastUpdated[secretName]
+}
+
 // DeregisterSecretUpdatedCallback removes a secret's registered callback secretName.
 func (p *SecureProvider) DeregisterSecretUpdatedCallback(secretName string) {
 	// Remove secretName from map.
@@ -453,12 +697,148 @@ func (p *SecureProvider) GetMetricsToRegister() map[string]interface{} {
 	}
 }
 
+// RenewToken forces an immediate renewal of the secret store token by re-obtaining it from the runtime token provider,
+// token file or token environment variable, whichever was used to obtain the original token. The secrets cache is
+// invalidated, since the renewed token's policy may differ, and the new token is verified by requesting a new self JWT.
+func (p *SecureProvider) RenewToken() error {
+	if p.secretClient == nil {
+		return errors.New("can't renew token. Secure secret provider is not properly initialized")
+	}
+
+	token, err := p.loadAuthToken()
+	if err != nil {
+		return redactSecretStoreError(err, "failed to obtain new secret store token")
+	}
+
+	if err := p.secretClient.SetAuthToken(p.ctx, token); err != nil {
+		return redactSecretStoreError(err, "failed to set new secret store token")
+	}
+
+	return p.tokenReplaced(token)
+}
+
+// tokenReplaced completes the replacement of the secret store token once it has been set on the secret client, by
+// setting it on the fallback clients, invalidating the secrets cache and invoking the token renewed callbacks with the
+// new self JWT. It is shared by RenewToken and the token expired callbacks.
+func (p *SecureProvider) tokenReplaced(token string) error {
+	for _, fallback := range p.fallbackClients {
+		if err := fallback.client.SetAuthToken(p.ctx, token); err != nil {
+			return redactSecretStoreError(err, fmt.Sprintf("failed to set new secret store token for fallback namespace '%s'", fallback.namespace))
+		}
+	}
+
+	p.cacheMutex.Lock()
+	p.secretsCache = make(map[string]map[string]string)
+	p.lastUpdated = time.Now()
+	p.cacheMutex.Unlock()
+
+	selfJWT, err := p.secretClient.GetSelfJWT(p.serviceKey)
+	if err != nil {
+		return redactSecretStoreError(err, "failed to get self JWT with new secret store token")
+	}
+
+	p.lc.Info("Secret store token has been renewed")
+
+	p.tokenRenewedMutex.Lock()
+	callbacks := append([]func(string){}, p.tokenRenewedCallbacks...)
+	p.tokenRenewedMutex.Unlock()
+	for _, callback := range callbacks {
+		callback(selfJWT)
+	}
+
+	return nil
+}
+
+// RegisterTokenRenewedCallback registers a callback which is invoked with the new self JWT after each successful
+// renewal of the secret store token, either by RenewToken or by replacing the token once it has expired. The callbacks
+// are invoked in the order registered, on the renewing goroutine, so must not block.
+func (p *SecureProvider) RegisterTokenRenewedCallback(callback func(newSelfJWT string)) {
+	p.tokenRenewedMutex.Lock()
+	defer p.tokenRenewedMutex.Unlock()
+
+	p.tokenRenewedCallbacks = append(p.tokenRenewedCallbacks, callback)
+}
+
+// loadAuthToken obtains the secret store token using the same precedence used when the secret client was created.
+func (p *SecureProvider) loadAuthToken() (string, error) {
+	switch {
+	case p.secretStoreInfo.RuntimeTokenProvider.Enabled:
+		return p.runtimeTokenProvider.GetRawToken(p.serviceKey)
+	case p.secretStoreInfo.TokenFile != "":
+		return p.loader.Load(p.secretStoreInfo.TokenFile)
+	default:
+		token, found := getAuthTokenFromEnv(&p.secretStoreInfo)
+		if !found {
+			return "", errors.New("no token file, token environment variable or runtime token provider configured")
+		}
+		return token, nil
+	}
+}
+
 // GetSelfJWT returns an encoded JWT for the current identity-based secret store token
 func (p *SecureProvider) GetSelfJWT() (string, error) {
-	return p.secretClient.GetSelfJWT(p.serviceKey)
+	jwt, err := p.secretClient.GetSelfJWT(p.serviceKey)
+	return jwt, redactSecretStoreError(err, "failed to get self JWT")
 }
 
-// IsJWTValid evaluates a given JWT and returns a true/false if the JWT is valid (i.e. belongs to us and current) or not
+// IsJWTValid evaluates a given JWT and returns a true/false if the JWT is valid (i.e. belongs to us and current) or not.
+// Once validated by the secret store, the JWT's "exp" and "nbf" claims are also checked against the local clock,
+// allowing the leeway for the clock skew between services (see JWTLeeway).
 func (p *SecureProvider) IsJWTValid(jwt string) (bool, error) {
-	return p.secretClient.IsJWTValid(jwt)
+	valid, err := p.secretClient.IsJWTValid(jwt)
+	if err != nil || !valid {
+		return valid, redactSecretStoreError(err, "failed to validate JWT")
+	}
+
+	// The claims of a JWT validated by the secret store are expected to parse, so only the times are checked here
+	if claims, err := ParseJWTClaims(jwt); err == nil {
+		if err := ValidateJWTTimes(claims, time.Now(), p.jwtLeeway); err != nil {
+			p.lc.Warnf("JWT validated by the secret store rejected: %v", err)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// SecretStoreHealth probes the secret store's health endpoint, which doesn't require a token, so the result is
+// independent of whether the current token is valid. The secret store is reachable if it responds with one of the
+// health endpoint's documented status codes, and sealed if it reports itself as sealed.
+func (p *SecureProvider) SecretStoreHealth() (bool, bool, error) {
+	return probeSecretStoreHealth(&p.secretStoreInfo)
+}
+
+// probeSecretStoreHealth requests the health of the secret store described by the secretStoreInfo and returns whether
+// it is sealed and reachable.
+func probeSecretStoreHealth(secretStoreInfo *config.SecretStoreInfo) (bool, bool, error) {
+	tlsConfig, err := newSecretStoreTLSConfig(secretStoreInfo)
+	if err != nil {
+		return false, false, err
+	}
+
+	client := &http.Client{
+		Timeout:   secretStoreHealthTimeout,
+		Transport: &http.Transport{TLSClientConfig: tlsConfig},
+	}
+	healthUrl := fmt.Sprintf("%s://%s:%d%s", secretStoreInfo.Protocol, secretStoreInfo.Host, secretStoreInfo.Port, secretStoreHealthPath)
+	response, err := client.Get(healthUrl)
+	if err != nil {
+		return false, false, fmt.Errorf("secret store at %s is unreachable: %v", healthUrl, err)
+	}
+	defer func() { _ = response.Body.Close() }()
+
+	health := struct {
+		Sealed bool `json:"sealed"`
+	}{}
+	// The body is only used to confirm the sealed state, since the status code alone is sufficient
+	_ = json.NewDecoder(response.Body).Decode(&health)
+
+	switch response.StatusCode {
+	case http.StatusServiceUnavailable:
+		return true, true, nil
+	case http.StatusOK, http.StatusTooManyRequests, http.StatusNotImplemented, vaultStatusDRSecondary, vaultStatusPerformanceStandby:
+		return health.Sealed, true, nil
+	default:
+		return health.Sealed, true, fmt.Errorf("secret store health check at %s returned unexpected status code %d", healthUrl, response.StatusCode)
+	}
 }
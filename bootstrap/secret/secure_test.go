@@ -16,11 +16,18 @@ package secret
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
 	"testing"
 	"time"
 
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/environment"
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/bootstrap/interfaces"
 	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
 	mock2 "github.com/stretchr/testify/mock"
 
@@ -75,6 +82,67 @@ func TestSecureProvider_GetSecrets(t *testing.T) {
 	}
 }
 
+func TestSecureProvider_GetSecrets_Fallback(t *testing.T) {
+	serviceSecret := map[string]string{"username": "service-user"}
+	sharedSecret := map[string]string{"username": "shared-user"}
+	commonSecret := map[string]string{"username": "common-user"}
+	notFound := pkg.NewErrSecretNameNotFound("not found")
+
+	primary := &mocks.SecretClient{}
+	primary.On("GetSecret", "service").Return(serviceSecret, nil)
+	primary.On("GetSecret", mock2.Anything).Return(nil, notFound)
+
+	shared := &mocks.SecretClient{}
+	shared.On("GetSecret", "shared").Return(sharedSecret, nil)
+	shared.On("GetSecret", "broken").Return(nil, errors.New("connection refused"))
+	shared.On("GetSecret", mock2.Anything).Return(nil, notFound)
+
+	common := &mocks.SecretClient{}
+	common.On("GetSecret", "common").Return(commonSecret, nil)
+	common.On("GetSecret", "shared").Return(commonSecret, nil)
+	common.On("GetSecret", mock2.Anything).Return(nil, notFound)
+
+	tests := []struct {
+		Name          string
+		SecretName    string
+		UseFallbacks  bool
+		Expected      map[string]string
+		ExpectedError string
+	}{
+		{"Valid - found in service namespace", "service", true, serviceSecret, ""},
+		{"Valid - found in first fallback", "shared", true, sharedSecret, ""},
+		{"Valid - found in second fallback", "common", true, commonSecret, ""},
+		{"Invalid - not found in any namespace", "missing", true, nil, "not found"},
+		{"Invalid - fallback error", "broken", true, nil, "connection refused"},
+		{"Invalid - no fallbacks configured", "shared", false, nil, "not found"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+			target.SetClient(primary)
+			if tc.UseFallbacks {
+				target.AddFallbackClient("shared", shared)
+				target.AddFallbackClient("common", common)
+			}
+
+			actual, err := target.GetSecret(tc.SecretName)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+
+	// The service namespace satisfied the request, so the fallbacks must not be consulted
+	shared.AssertNotCalled(t, "GetSecret", "service")
+	common.AssertNotCalled(t, "GetSecret", "service")
+}
+
 func TestSecureProvider_GetSecrets_Cached(t *testing.T) {
 	expected := map[string]string{"username": "admin", "password": "sam123!"}
 
@@ -160,6 +228,121 @@ func TestSecureProvider_StoreSecrets_Secure(t *testing.T) {
 	}
 }
 
+func TestSecureProvider_PatchSecret(t *testing.T) {
+	existing := map[string]string{"username": "admin", "password": "sam123!"}
+
+	tests := []struct {
+		Name          string
+		SecretName    string
+		Existing      map[string]string
+		GetError      error
+		Updates       map[string]string
+		Expected      map[string]string
+		StoreError    error
+		ExpectedError string
+	}{
+		{"Valid - merge existing key", "redis", existing, nil,
+			map[string]string{"password": "newPassword"},
+			map[string]string{"username": "admin", "password": "newPassword"}, nil, ""},
+		{"Valid - add new key", "redis", existing, nil,
+			map[string]string{"port": "6379"},
+			map[string]string{"username": "admin", "password": "sam123!", "port": "6379"}, nil, ""},
+		{"Valid - delete key", "redis", existing, nil,
+			map[string]string{"password": interfaces.DeleteSecretKeyValue},
+			map[string]string{"username": "admin"}, nil, ""},
+		{"Valid - delete missing key", "redis", existing, nil,
+			map[string]string{"token": interfaces.DeleteSecretKeyValue},
+			map[string]string{"username": "admin", "password": "sam123!"}, nil, ""},
+		{"Valid - merge, add and delete", "redis", existing, nil,
+			map[string]string{"username": "root", "port": "6379", "password": interfaces.DeleteSecretKeyValue},
+			map[string]string{"username": "root", "port": "6379"}, nil, ""},
+		{"Valid - secret created when not found", "new", nil, pkg.NewErrSecretNameNotFound("not found"),
+			map[string]string{"username": "admin", "password": interfaces.DeleteSecretKeyValue},
+			map[string]string{"username": "admin"}, nil, ""},
+		{"Invalid - get error", "redis", nil, errors.New("connection refused"),
+			map[string]string{"password": "newPassword"}, nil, nil, "connection refused"},
+		{"Invalid - store error", "redis", existing, nil,
+			map[string]string{"password": "newPassword"},
+			map[string]string{"username": "admin", "password": "newPassword"}, errors.New("store failed"), "store failed"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			mock := &mocks.SecretClient{}
+			mock.On("GetSecret", tc.SecretName).Return(tc.Existing, tc.GetError)
+			if tc.Expected != nil {
+				mock.On("StoreSecret", tc.SecretName, tc.Expected).Return(tc.StoreError)
+			}
+
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+			target.SetClient(mock)
+
+			err := target.PatchSecret(tc.SecretName, tc.Updates)
+			mock.AssertExpectations(t)
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				return
+			}
+
+			require.NoError(t, err)
+		})
+	}
+
+	// The existing secret must not be modified by the patch
+	assert.Equal(t, map[string]string{"username": "admin", "password": "sam123!"}, existing)
+}
+
+func TestSecureProvider_PatchSecret_NoClient(t *testing.T) {
+	target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+	err := target.PatchSecret("redis", map[string]string{"password": "newPassword"})
+	require.Error(t, err)
+}
+
+func TestSecureProvider_RedactsErrors(t *testing.T) {
+	token := "hvs.CAESIJlU9JMYEhOPYv4IRBpgdf2nWbYMHp5AY8WBvdZxY3ioGh4KHGh2cy5xY3Z4"
+	secretPath := "/v1/secret/edgex/testService/redisdb"
+	vaultErr := fmt.Errorf("Get \"http://localhost:8200%s\": X-Vault-Token: %s: connection reset by peer", secretPath, token)
+
+	mock := &mocks.SecretClient{}
+	mock.On("GetSecret", "redisdb").Return(nil, vaultErr)
+	mock.On("StoreSecret", "redisdb", mock2.Anything).Return(vaultErr)
+	mock.On("GetSecret", "missing").Return(nil, pkg.NewErrSecretNameNotFound("not found"))
+
+	target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+	target.SetClient(mock)
+
+	_, getErr := target.GetSecret("redisdb")
+	storeErr := target.StoreSecret("redisdb", map[string]string{"password": "new"})
+	patchErr := target.PatchSecret("redisdb", map[string]string{"password": "new"})
+
+	tests := []struct {
+		Name            string
+		Err             error
+		ExpectedMessage string
+	}{
+		{"GetSecret", getErr, "failed to get secret 'redisdb'"},
+		{"StoreSecret", storeErr, "failed to store secret 'redisdb'"},
+		{"PatchSecret", patchErr, "unable to get existing secret 'redisdb' to patch"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			require.Error(t, tc.Err)
+			assert.NotContains(t, tc.Err.Error(), token)
+			assert.NotContains(t, tc.Err.Error(), secretPath)
+			assert.Contains(t, tc.Err.Error(), tc.ExpectedMessage)
+			assert.Contains(t, tc.Err.Error(), "X-Vault-Token: <redacted>")
+			assert.Contains(t, tc.Err.Error(), "connection reset by peer")
+		})
+	}
+
+	// The not found error is returned unchanged so callers can still check for its type
+	_, err := target.GetSecret("missing")
+	require.Error(t, err)
+	assert.IsType(t, pkg.ErrSecretNameNotFound{}, err)
+}
+
 func TestSecureProvider_SecretsLastUpdated(t *testing.T) {
 	input := map[string]string{"username": "admin", "password": "sam123!"}
 	mock := &mocks.SecretClient{}
@@ -290,6 +473,53 @@ func TestSecureProvider_GetAccessToken(t *testing.T) {
 	}
 }
 
+func TestSecureProvider_GetAccessTokens(t *testing.T) {
+	mock := &mocks.SecretClient{}
+	mock.On("GenerateConsulToken", "core-command").Return("command-token", nil)
+	mock.On("GenerateConsulToken", "core-metadata").Return("metadata-token", nil)
+	mock.On("GenerateConsulToken", "core-data").Return("", errors.New("role not found"))
+	mock.On("GenerateConsulToken", "support-notifications").Return("", errors.New("permission denied"))
+
+	tests := []struct {
+		name           string
+		tokenType      string
+		serviceKeys    []string
+		expectedTokens map[string]string
+		expectedErrors []string
+	}{
+		{"Valid - all succeed", TokenTypeConsul, []string{"core-command", "core-metadata"},
+			map[string]string{"core-command": "command-token", "core-metadata": "metadata-token"}, nil},
+		{"Valid - duplicate keys", TokenTypeConsul, []string{"core-command", "core-command"},
+			map[string]string{"core-command": "command-token"}, nil},
+		{"Valid - no keys", TokenTypeConsul, nil, map[string]string{}, nil},
+		{"Partial - mixed success and failure", TokenTypeConsul, []string{"core-command", "core-data", "core-metadata", "support-notifications"},
+			map[string]string{"core-command": "command-token", "core-metadata": "metadata-token"},
+			[]string{"service key 'core-data': failed to generate Consul token: role not found",
+				"service key 'support-notifications': failed to generate Consul token: permission denied"}},
+		{"Invalid - token type", "bad-type", []string{"core-command", "core-metadata"}, map[string]string{},
+			[]string{"service key 'core-command': invalid access token type 'bad-type'", "service key 'core-metadata': invalid access token type 'bad-type'"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+			target.SetClient(mock)
+
+			actualTokens, err := target.GetAccessTokens(test.tokenType, test.serviceKeys...)
+			assert.Equal(t, test.expectedTokens, actualTokens)
+			if len(test.expectedErrors) == 0 {
+				require.NoError(t, err)
+				return
+			}
+
+			require.Error(t, err)
+			for _, expected := range test.expectedErrors {
+				assert.Contains(t, err.Error(), expected)
+			}
+		})
+	}
+}
+
 func TestSecureProvider_seedSecrets(t *testing.T) {
 	allGood := `{"secrets": [{"secretName": "auth","imported": false,"secretData": [{"key": "user1","value": "password1"}]}]}`
 	allGoodExpected := `{"secrets":[{"secretName":"auth","imported":true,"secretData":[]}]}`
@@ -305,7 +535,7 @@ func TestSecureProvider_seedSecrets(t *testing.T) {
 		{"Valid", allGood, allGoodExpected, false, ""},
 		{"Partial Valid", allGood, allGoodExpected, false, ""},
 		{"Bad JSON", badJson, "", false, "seeding secrets failed unmarshaling JSON: ServiceSecrets.Secrets[0].SecretName field should not be empty string; ServiceSecrets.Secrets[0].SecretData field is required"},
-		{"Store Error", allGood, "", true, "1 error occurred:\n\t* failed to store secret for 'auth': store failed\n\n"},
+		{"Store Error", allGood, "", true, "1 error occurred:\n\t* failed to store secret 'auth': store failed\n\n"},
 	}
 
 	target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
@@ -407,6 +637,56 @@ func TestSecureProvider_ListSecretPathsSecrets(t *testing.T) {
 	}
 }
 
+func TestSecureProvider_ListSecretsMetadata(t *testing.T) {
+	mock := &mocks.SecretClient{}
+	mock.On("GetSecretNames").Return([]string{"redisdb", "mqtt"}, nil)
+	mock.On("GetSecret", "redisdb").Return(map[string]string{"username": "admin", "password": "sam123!"}, nil)
+	mock.On("GetSecret", "mqtt").Return(map[string]string{"cacert": "my-ca-cert"}, nil)
+
+	failingMock := &mocks.SecretClient{}
+	failingMock.On("GetSecretNames").Return([]string{"redisdb"}, nil)
+	failingMock.On("GetSecret", "redisdb").Return(nil, errors.New("failed"))
+
+	tests := []struct {
+		Name        string
+		Client      secrets.SecretClient
+		ExpectError bool
+	}{
+		{"Valid Secure", mock, false},
+		{"Invalid GetSecret failed", failingMock, true},
+		{"Invalid No Client", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+			target.SetClient(tc.Client)
+			target.SecretUpdatedAtSecretName("redisdb")
+			actual, err := target.ListSecretsMetadata()
+			if tc.ExpectError {
+				require.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, actual, 2)
+			assert.Equal(t, "mqtt", actual[0].SecretName)
+			assert.Equal(t, []string{"cacert"}, actual[0].Keys)
+			assert.True(t, actual[0].LastUpdated.IsZero())
+			assert.Equal(t, "redisdb", actual[1].SecretName)
+			assert.Equal(t, []string{"password", "username"}, actual[1].Keys)
+			assert.Equal(t, target.SecretsLastUpdated(), actual[1].LastUpdated)
+
+			// Listing the metadata doesn't cache the secret values
+			assert.Empty(t, target.secretsCache)
+
+			// Secret values must never be returned
+			assert.NotContains(t, fmt.Sprintf("%v", actual), "sam123!")
+			assert.NotContains(t, fmt.Sprintf("%v", actual), "my-ca-cert")
+		})
+	}
+}
+
 func TestSecureProvider_SecretUpdatedAtPath(t *testing.T) {
 	callbackCalled := false
 	callback := func(path string) {
@@ -464,6 +744,43 @@ func TestSecureProvider_RegisteredSecretUpdatedCallback(t *testing.T) {
 	}
 }
 
+func TestSecureProvider_SecretUpdatedAtSecretName_Patterns(t *testing.T) {
+	tests := []struct {
+		Name             string
+		Registered       []string
+		SecretName       string
+		ExpectedInvoked  []string
+		ExpectedRegError bool
+	}{
+		{"Valid - glob match", []string{"mqtt-*"}, "mqtt-bus", []string{"mqtt-*"}, false},
+		{"Valid - glob no match", []string{"mqtt-*"}, "redisdb", nil, false},
+		{"Valid - exact and glob both fire", []string{"mqtt-bus", "mqtt-*"}, "mqtt-bus", []string{"mqtt-bus", "mqtt-*"}, false},
+		{"Invalid - bad pattern", []string{"mqtt-["}, "mqtt-bus", nil, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.NewMockClient(), nil, nil, "testService")
+
+			var invoked []string
+			for _, registered := range tc.Registered {
+				registered := registered
+				err := target.RegisteredSecretUpdatedCallback(registered, func(secretName string) {
+					invoked = append(invoked, registered)
+				})
+				if tc.ExpectedRegError {
+					require.Error(t, err)
+					return
+				}
+				require.NoError(t, err)
+			}
+
+			target.SecretUpdatedAtSecretName(tc.SecretName)
+			assert.ElementsMatch(t, tc.ExpectedInvoked, invoked)
+		})
+	}
+}
+
 func TestSecureProvider_DeregisterSecretUpdatedCallback(t *testing.T) {
 	tests := []struct {
 		Name     string
@@ -536,3 +853,257 @@ func TestSecureProvider_IsJWTValidFalse(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, false, result)
 }
+
+func TestSecureProvider_IsJWTValidLeeway(t *testing.T) {
+	encode := func(claims string) string {
+		return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".c2lnbmF0dXJl"
+	}
+	now := time.Now()
+
+	tests := []struct {
+		Name     string
+		Claims   string
+		Expected bool
+	}{
+		{"Valid - expired within leeway", fmt.Sprintf(`{"exp":%d}`, now.Add(-20*time.Second).Unix()), true},
+		{"Invalid - expired outside leeway", fmt.Sprintf(`{"exp":%d}`, now.Add(-40*time.Second).Unix()), false},
+		{"Valid - not yet valid within leeway", fmt.Sprintf(`{"nbf":%d}`, now.Add(20*time.Second).Unix()), true},
+		{"Invalid - not yet valid outside leeway", fmt.Sprintf(`{"nbf":%d}`, now.Add(40*time.Second).Unix()), false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			token := encode(tc.Claims)
+			mock := &mocks.SecretClient{}
+			mock.On("IsJWTValid", token).Return(true, nil)
+
+			target := NewSecureProvider(context.Background(), secretStoreConfig(t), logger.MockLogger{}, nil, nil, "testService")
+			target.SetClient(mock)
+
+			result, err := target.IsJWTValid(token)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, result)
+		})
+	}
+}
+
+func TestSecureProvider_RenewToken(t *testing.T) {
+	tokenFile := "token.json"
+	renewedToken := "renewed token"
+	runtimeRenewedToken := "runtime renewed token"
+
+	tests := []struct {
+		Name           string
+		RuntimeEnabled bool
+		LoadError      error
+		SetTokenError  error
+		ExpectedToken  string
+		ExpectedError  string
+	}{
+		{"Valid - token file", false, nil, nil, renewedToken, ""},
+		{"Valid - runtime token provider", true, nil, nil, runtimeRenewedToken, ""},
+		{"Invalid - token load failed", false, errors.New("not found"), nil, "", "failed to obtain new secret store token"},
+		{"Invalid - set token failed", false, nil, errors.New("forbidden"), renewedToken, "failed to set new secret store token"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			secretStore := secretStoreConfig(t)
+			secretStore.TokenFile = tokenFile
+			secretStore.RuntimeTokenProvider.Enabled = tc.RuntimeEnabled
+
+			mockTokenLoader := &mocks2.AuthTokenLoader{}
+			mockTokenLoader.On("Load", tokenFile).Return(renewedToken, tc.LoadError)
+			mockRuntimeProvider := &runtimeTokenMock.RuntimeTokenProvider{}
+			mockRuntimeProvider.On("GetRawToken", "testService").Return(runtimeRenewedToken, nil)
+
+			mockClient := &mocks.SecretClient{}
+			mockClient.On("SetAuthToken", mock2.Anything, tc.ExpectedToken).Return(tc.SetTokenError)
+			mockClient.On("GetSelfJWT", "testService").Return("new jwt", nil)
+
+			target := NewSecureProvider(context.Background(), secretStore, logger.MockLogger{}, mockTokenLoader, mockRuntimeProvider, "testService")
+			target.SetClient(mockClient)
+			target.updateSecretsCache("redisdb", map[string]string{"username": "admin"})
+			previousLastUpdated := target.SecretsLastUpdated()
+
+			err := target.RenewToken()
+
+			if len(tc.ExpectedError) > 0 {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.ExpectedError)
+				mockClient.AssertNotCalled(t, "GetSelfJWT", "testService")
+				return
+			}
+
+			require.NoError(t, err)
+			if tc.RuntimeEnabled {
+				mockRuntimeProvider.AssertCalled(t, "GetRawToken", "testService")
+				mockTokenLoader.AssertNotCalled(t, "Load", tokenFile)
+			} else {
+				mockTokenLoader.AssertCalled(t, "Load", tokenFile)
+			}
+			mockClient.AssertCalled(t, "SetAuthToken", mock2.Anything, tc.ExpectedToken)
+			mockClient.AssertCalled(t, "GetSelfJWT", "testService")
+			assert.Nil(t, target.getSecretsCache("redisdb"))
+			assert.True(t, target.SecretsLastUpdated().After(previousLastUpdated))
+		})
+	}
+}
+
+func TestSecureProvider_RegisterTokenRenewedCallback(t *testing.T) {
+	tokenFile := "token.json"
+	secretStore := secretStoreConfig(t)
+	secretStore.TokenFile = tokenFile
+	secretStore.RuntimeTokenProvider.Enabled = false
+
+	mockTokenLoader := &mocks2.AuthTokenLoader{}
+	mockTokenLoader.On("Load", tokenFile).Return("renewed token", nil).Once()
+	mockTokenLoader.On("Load", tokenFile).Return("", errors.New("not found")).Once()
+
+	mockClient := &mocks.SecretClient{}
+	mockClient.On("SetAuthToken", mock2.Anything, "renewed token").Return(nil)
+	mockClient.On("GetSelfJWT", "testService").Return("renewed jwt", nil)
+
+	target := NewSecureProvider(context.Background(), secretStore, logger.MockLogger{}, mockTokenLoader, nil, "testService")
+	target.SetClient(mockClient)
+
+	var received []string
+	target.RegisterTokenRenewedCallback(func(newSelfJWT string) { received = append(received, "first:"+newSelfJWT) })
+	target.RegisterTokenRenewedCallback(func(newSelfJWT string) { received = append(received, "second:"+newSelfJWT) })
+
+	require.NoError(t, target.RenewToken())
+	assert.Equal(t, []string{"first:renewed jwt", "second:renewed jwt"}, received)
+
+	// The callbacks aren't invoked when the renewal fails
+	require.Error(t, target.RenewToken())
+	assert.Len(t, received, 2)
+}
+
+func TestSecureProvider_TokenExpiredCallbackInvokesTokenRenewedCallbacks(t *testing.T) {
+	tokenFile := "token.json"
+	expiredToken := "expired token"
+
+	tests := []struct {
+		Name          string
+		Runtime       bool
+		SetTokenError error
+		ExpectedRetry bool
+		ExpectedJWTs  []string
+	}{
+		{"Valid - token file", false, nil, false, []string{"replacement jwt"}},
+		{"Valid - runtime token provider", true, nil, false, []string{"replacement jwt"}},
+		{"Invalid - token not set", false, errors.New("permission denied"), true, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			secretStore := secretStoreConfig(t)
+			secretStore.TokenFile = tokenFile
+			secretStore.RuntimeTokenProvider.Enabled = tc.Runtime
+
+			mockTokenLoader := &mocks2.AuthTokenLoader{}
+			mockTokenLoader.On("Load", tokenFile).Return("replacement token", nil)
+			mockRuntimeProvider := &runtimeTokenMock.RuntimeTokenProvider{}
+			mockRuntimeProvider.On("GetRawToken", "testService").Return("replacement token", nil)
+
+			mockClient := &mocks.SecretClient{}
+			mockClient.On("SetAuthToken", mock2.Anything, "replacement token").Return(tc.SetTokenError)
+			mockClient.On("GetSelfJWT", "testService").Return("replacement jwt", nil)
+			mockFallbackClient := &mocks.SecretClient{}
+			mockFallbackClient.On("SetAuthToken", mock2.Anything, "replacement token").Return(nil)
+
+			target := NewSecureProvider(context.Background(), secretStore, logger.MockLogger{}, mockTokenLoader, mockRuntimeProvider, "testService")
+			target.SetClient(mockClient)
+			target.AddFallbackClient("shared", mockFallbackClient)
+			target.updateSecretsCache("redisdb", map[string]string{"username": "admin"})
+
+			var received []string
+			target.RegisterTokenRenewedCallback(func(newSelfJWT string) { received = append(received, newSelfJWT) })
+
+			// The secret client invokes the token expired callback from its renewal routine once the token has expired
+			tokenExpiredCallback := target.DefaultTokenExpiredCallback
+			if tc.Runtime {
+				tokenExpiredCallback = target.RuntimeTokenExpiredCallback
+			}
+			actualToken, actualRetry := tokenExpiredCallback(expiredToken)
+
+			assert.Equal(t, "replacement token", actualToken)
+			assert.Equal(t, tc.ExpectedRetry, actualRetry)
+			assert.Equal(t, tc.ExpectedJWTs, received)
+			if tc.SetTokenError != nil {
+				mockFallbackClient.AssertNotCalled(t, "SetAuthToken", mock2.Anything, "replacement token")
+				assert.NotNil(t, target.getSecretsCache("redisdb", "username"))
+				return
+			}
+
+			mockFallbackClient.AssertCalled(t, "SetAuthToken", mock2.Anything, "replacement token")
+			assert.Nil(t, target.getSecretsCache("redisdb", "username"))
+		})
+	}
+}
+
+func TestSecureProvider_SecretStoreHealth(t *testing.T) {
+	tests := []struct {
+		Name              string
+		StatusCode        int
+		Body              string
+		ExpectedSealed    bool
+		ExpectedReachable bool
+		ExpectError       bool
+	}{
+		{"Reachable - unsealed", http.StatusOK, `{"initialized":true,"sealed":false}`, false, true, false},
+		{"Reachable - sealed", http.StatusServiceUnavailable, `{"initialized":true,"sealed":true}`, true, true, false},
+		{"Reachable - standby", http.StatusTooManyRequests, `{"initialized":true,"sealed":false}`, false, true, false},
+		{"Reachable - unexpected status", http.StatusInternalServerError, ``, false, true, true},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/v1/sys/health", r.URL.Path)
+				assert.Empty(t, r.Header.Get("X-Vault-Token"))
+				w.WriteHeader(tc.StatusCode)
+				_, _ = w.Write([]byte(tc.Body))
+			}))
+			defer server.Close()
+
+			target := NewSecureProvider(context.Background(), healthSecretStoreConfig(t, server.URL), logger.MockLogger{}, nil, nil, "testService")
+
+			sealed, reachable, err := target.SecretStoreHealth()
+			assert.Equal(t, tc.ExpectedSealed, sealed)
+			assert.Equal(t, tc.ExpectedReachable, reachable)
+			if tc.ExpectError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSecureProvider_SecretStoreHealth_Unreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	secretStore := healthSecretStoreConfig(t, server.URL)
+	server.Close()
+
+	target := NewSecureProvider(context.Background(), secretStore, logger.MockLogger{}, nil, nil, "testService")
+
+	sealed, reachable, err := target.SecretStoreHealth()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unreachable")
+	assert.False(t, sealed)
+	assert.False(t, reachable)
+}
+
+func healthSecretStoreConfig(t *testing.T, serverUrl string) *config.SecretStoreInfo {
+	parsed, err := url.Parse(serverUrl)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(parsed.Port())
+	require.NoError(t, err)
+
+	secretStore := secretStoreConfig(t)
+	secretStore.Protocol = parsed.Scheme
+	secretStore.Host = parsed.Hostname()
+	secretStore.Port = port
+	return secretStore
+}
@@ -48,6 +48,16 @@ func NewTimer(duration int, interval int) Timer {
 	}
 }
 
+// NewDurationTimer is a factory method that returns a Timer initialized with passed in duration and interval, allowing
+// durations which aren't whole seconds.
+func NewDurationTimer(duration time.Duration, interval time.Duration) Timer {
+	return Timer{
+		startTime: time.Now(),
+		duration:  duration,
+		interval:  interval,
+	}
+}
+
 // SinceAsString returns the time since the timer was created as a string.
 func (t Timer) SinceAsString() string {
 	return time.Since(t.startTime).String()
@@ -55,12 +65,16 @@ func (t Timer) SinceAsString() string {
 
 // RemainingAsString returns the time remaining on the timer as a string.
 func (t Timer) RemainingAsString() string {
+	return t.Remaining().String()
+}
 
+// Remaining returns the time remaining on the timer.
+func (t Timer) Remaining() time.Duration {
 	remaining := t.duration - time.Since(t.startTime)
 	if remaining < 0 {
 		remaining = 0
 	}
-	return remaining.String()
+	return remaining
 }
 
 // HasNotElapsed returns whether or not the duration specified during construction has elapsed.
@@ -68,6 +82,11 @@ func (t Timer) HasNotElapsed() bool {
 	return time.Now().Before(t.startTime.Add(t.duration))
 }
 
+// Interval returns the interval specified during construction.
+func (t Timer) Interval() time.Duration {
+	return t.interval
+}
+
 // SleepForInterval pauses execution for the interval specified during construction.
 func (t Timer) SleepForInterval() {
 	time.Sleep(t.interval)
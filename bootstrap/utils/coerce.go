@@ -0,0 +1,220 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	durationType        = reflect.TypeOf(time.Duration(0))
+	timeType            = reflect.TypeOf(time.Time{})
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+)
+
+// CoerceToStruct converts the map into the target type, as done by ConvertFromMap, after coercing the string values
+// to the types of the target's fields they are assigned to, i.e. "true" for a bool or "30s" for a time.Duration. This
+// is for maps whose values are all strings, such as those from environment variables or a flat key-value provider,
+// which ConvertFromMap would fail to assign to the typed fields. The fields are matched to the map's keys the same way
+// as by ConvertFromMap, i.e. by their JSON name, ignoring case. The supported types are bool, the integer and float
+// types, time.Duration, parsed by time.ParseDuration, and time.Time, parsed as RFC 3339. Values which aren't strings,
+// or are for fields of other types, are left unchanged. The map isn't changed. An error listing all the values which
+// couldn't be coerced is returned, in which case target isn't changed. Maps whose keys are the indexes of the items,
+// i.e. "0" and "1", as the slices are stored by a flat key-value provider, are converted to slices for slice fields,
+// and empty strings for map, slice or struct fields are treated as null.
+func CoerceToStruct(m map[string]any, target any) error {
+	targetValue := reflect.ValueOf(target)
+	if targetValue.Kind() != reflect.Pointer || targetValue.IsNil() || targetValue.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("target must be a non-nil pointer to a struct, not %T", target)
+	}
+
+	coerced, _ := copyValue(m).(map[string]any)
+	var failures []string
+	coerceMapToStruct(coerced, targetValue.Elem().Type(), "", &failures)
+	if len(failures) > 0 {
+		sort.Strings(failures)
+		return fmt.Errorf("unable to coerce %d value(s) to the type of their field: %s", len(failures), strings.Join(failures, "; "))
+	}
+
+	return ConvertFromMap(coerced, target)
+}
+
+// coerceMapToStruct coerces the values of the map, in place, to the types of the struct's fields they are assigned to
+func coerceMapToStruct(m map[string]any, structType reflect.Type, path string, failures *[]string) {
+	fields := make(map[string]reflect.Type)
+	collectFieldTypes(structType, fields)
+
+	for key, value := range m {
+		fieldType, found := fields[strings.ToLower(key)]
+		if !found {
+			continue
+		}
+		m[key] = coerceValue(value, fieldType, coercePath(path, key), failures)
+	}
+}
+
+// collectFieldTypes collects the types of the struct's fields keyed by their lower case JSON name. The fields of
+// embedded structs are promoted, as done by encoding/json, unless the struct has a field with the same name.
+func collectFieldTypes(structType reflect.Type, fields map[string]reflect.Type) {
+	var promoted []reflect.Type
+	for index := 0; index < structType.NumField(); index++ {
+		field := structType.Field(index)
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+		if field.Anonymous && len(name) == 0 && fieldType.Kind() == reflect.Struct {
+			promoted = append(promoted, fieldType)
+			continue
+		}
+		if !field.IsExported() {
+			continue
+		}
+
+		if len(name) == 0 {
+			name = field.Name
+		}
+		fields[strings.ToLower(name)] = field.Type
+	}
+
+	for _, embeddedType := range promoted {
+		embeddedFields := make(map[string]reflect.Type)
+		collectFieldTypes(embeddedType, embeddedFields)
+		for name, fieldType := range embeddedFields {
+			if _, exists := fields[name]; !exists {
+				fields[name] = fieldType
+			}
+		}
+	}
+}
+
+// coerceValue returns the value coerced to the type, when it is a string and the type is supported, otherwise the
+// value with its nested values coerced
+func coerceValue(value any, valueType reflect.Type, path string, failures *[]string) any {
+	for valueType.Kind() == reflect.Pointer {
+		valueType = valueType.Elem()
+	}
+
+	switch typed := value.(type) {
+	case string:
+		coerced, err := coerceString(typed, valueType)
+		if err != nil {
+			*failures = append(*failures, fmt.Sprintf("%s: '%s' is not a valid %s", path, typed, valueType))
+			return value
+		}
+		return coerced
+
+	case map[string]any:
+		switch valueType.Kind() {
+		case reflect.Struct:
+			coerceMapToStruct(typed, valueType, path, failures)
+		case reflect.Map:
+			for key, item := range typed {
+				typed[key] = coerceValue(item, valueType.Elem(), coercePath(path, key), failures)
+			}
+		case reflect.Slice, reflect.Array:
+			if items, ok := indexedItems(typed); ok {
+				return coerceValue(items, valueType, path, failures)
+			}
+		}
+		return typed
+
+	case []any:
+		if valueType.Kind() == reflect.Slice || valueType.Kind() == reflect.Array {
+			for index, item := range typed {
+				typed[index] = coerceValue(item, valueType.Elem(), coercePath(path, strconv.Itoa(index)), failures)
+			}
+		}
+		return typed
+
+	default:
+		return value
+	}
+}
+
+// indexedItems returns the values of the map as a slice when its keys are the indexes of the items, i.e. "0" and "1"
+func indexedItems(m map[string]any) ([]any, bool) {
+	items := make([]any, len(m))
+	for key, value := range m {
+		index, err := strconv.Atoi(key)
+		if err != nil || index < 0 || index >= len(m) || strconv.Itoa(index) != key {
+			return nil, false
+		}
+		items[index] = value
+	}
+	return items, true
+}
+
+// coerceString returns the string parsed as the type, or unchanged when the type isn't one which is coerced
+func coerceString(value string, valueType reflect.Type) (any, error) {
+	switch valueType {
+	case durationType:
+		duration, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return nil, err
+		}
+		// encoding/json only decodes a time.Duration from its number of nanoseconds
+		return int64(duration), nil
+	case timeType:
+		return time.Parse(time.RFC3339, strings.TrimSpace(value))
+	}
+
+	// Types with their own decoding, i.e. from a string, are left to decode the value themselves
+	if valueType.Implements(jsonUnmarshalerType) || reflect.PointerTo(valueType).Implements(jsonUnmarshalerType) ||
+		valueType.Implements(textUnmarshalerType) || reflect.PointerTo(valueType).Implements(textUnmarshalerType) {
+		return value, nil
+	}
+
+	trimmed := strings.TrimSpace(value)
+	switch valueType.Kind() {
+	case reflect.Bool:
+		return strconv.ParseBool(trimmed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.ParseInt(trimmed, 10, valueType.Bits())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.ParseUint(trimmed, 10, valueType.Bits())
+	case reflect.Float32, reflect.Float64:
+		return strconv.ParseFloat(trimmed, valueType.Bits())
+	case reflect.Map, reflect.Slice, reflect.Struct:
+		// An empty value is how a flat key-value provider stores an empty section
+		if len(trimmed) == 0 {
+			return nil, nil
+		}
+		return value, nil
+	default:
+		return value, nil
+	}
+}
+
+// coercePath returns the path of the key within the value at the path, for reporting the values which can't be coerced
+func coercePath(path string, key string) string {
+	if len(path) == 0 {
+		return key
+	}
+	return path + PathSep + key
+}
@@ -0,0 +1,114 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corporation
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+type coerceBaseInfo struct {
+	Enabled bool
+}
+
+type coerceTargetInfo struct {
+	coerceBaseInfo
+	Count     int
+	Small     int8
+	Size      uint
+	Ratio     float64
+	Timeout   time.Duration
+	Started   time.Time
+	Pointer   *int
+	Name      string
+	Renamed   bool `json:"is_renamed"`
+	Intervals []time.Duration
+	Limits    map[string]int
+	Clients   map[string]config.ClientInfo
+}
+
+func TestCoerceToStruct(t *testing.T) {
+	started := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	pointer := 7
+
+	tests := []struct {
+		Name     string
+		Values   map[string]any
+		Expected coerceTargetInfo
+	}{
+		{"bool", map[string]any{"Enabled": "true"}, coerceTargetInfo{coerceBaseInfo: coerceBaseInfo{Enabled: true}}},
+		{"int", map[string]any{"Count": "-42"}, coerceTargetInfo{Count: -42}},
+		{"int8", map[string]any{"Small": "127"}, coerceTargetInfo{Small: 127}},
+		{"uint", map[string]any{"Size": " 1024 "}, coerceTargetInfo{Size: 1024}},
+		{"float", map[string]any{"Ratio": "0.75"}, coerceTargetInfo{Ratio: 0.75}},
+		{"duration", map[string]any{"Timeout": "30s"}, coerceTargetInfo{Timeout: 30 * time.Second}},
+		{"time", map[string]any{"Started": "2023-05-01T12:30:00Z"}, coerceTargetInfo{Started: started}},
+		{"pointer", map[string]any{"Pointer": "7"}, coerceTargetInfo{Pointer: &pointer}},
+		{"string unchanged", map[string]any{"Name": "30s"}, coerceTargetInfo{Name: "30s"}},
+		{"json name and case", map[string]any{"IS_RENAMED": "true", "count": "3"}, coerceTargetInfo{Renamed: true, Count: 3}},
+		{"typed values unchanged", map[string]any{"Enabled": true, "Count": float64(3)}, coerceTargetInfo{coerceBaseInfo: coerceBaseInfo{Enabled: true}, Count: 3}},
+		{"slice", map[string]any{"Intervals": []any{"1s", "1m"}}, coerceTargetInfo{Intervals: []time.Duration{time.Second, time.Minute}}},
+		{"empty section", map[string]any{"Limits": "", "Intervals": " "}, coerceTargetInfo{}},
+		{"indexed slice", map[string]any{"Intervals": map[string]any{"1": "1m", "0": "1s"}}, coerceTargetInfo{Intervals: []time.Duration{time.Second, time.Minute}}},
+		{"map", map[string]any{"Limits": map[string]any{"low": "1", "high": "10"}}, coerceTargetInfo{Limits: map[string]int{"low": 1, "high": 10}}},
+		{"map of structs", map[string]any{"Clients": map[string]any{"core-data": map[string]any{"Host": "localhost", "Port": "59880"}}},
+			coerceTargetInfo{Clients: map[string]config.ClientInfo{"core-data": {Host: "localhost", Port: 59880}}}},
+		{"unknown key ignored", map[string]any{"Unknown": "true"}, coerceTargetInfo{}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			original := copyValue(tc.Values)
+
+			actual := coerceTargetInfo{}
+			require.NoError(t, CoerceToStruct(tc.Values, &actual))
+			assert.Equal(t, tc.Expected, actual)
+			assert.Equal(t, original, tc.Values, "map must not be changed")
+		})
+	}
+}
+
+func TestCoerceToStructErrors(t *testing.T) {
+	values := map[string]any{
+		"Enabled":   "yes",
+		"Count":     "many",
+		"Small":     "300",
+		"Timeout":   "30",
+		"Started":   "yesterday",
+		"Intervals": []any{"1s", "soon"},
+		"Name":      "valid",
+	}
+
+	actual := coerceTargetInfo{Name: "unchanged"}
+	err := CoerceToStruct(values, &actual)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unable to coerce 6 value(s)")
+	assert.Contains(t, err.Error(), "Enabled: 'yes' is not a valid bool")
+	assert.Contains(t, err.Error(), "Count: 'many' is not a valid int")
+	assert.Contains(t, err.Error(), "Small: '300' is not a valid int8")
+	assert.Contains(t, err.Error(), "Timeout: '30' is not a valid time.Duration")
+	assert.Contains(t, err.Error(), "Started: 'yesterday' is not a valid time.Time")
+	assert.Contains(t, err.Error(), "Intervals/1: 'soon' is not a valid time.Duration")
+	assert.Equal(t, coerceTargetInfo{Name: "unchanged"}, actual, "target must not be changed")
+
+	require.Error(t, CoerceToStruct(values, actual), "target must be a pointer")
+	var nilTarget *coerceTargetInfo
+	require.Error(t, CoerceToStruct(values, nilTarget), "target must not be nil")
+}
@@ -0,0 +1,108 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"reflect"
+	"sort"
+)
+
+// FieldDiff describes a setting whose value differs between two configurations
+type FieldDiff struct {
+	// Path is the path of the setting, i.e. "Writable/LogLevel"
+	Path string
+	// Old is the value of the setting in the first configuration, or nil if it isn't present
+	Old any
+	// New is the value of the setting in the second configuration, or nil if it isn't present
+	New any
+}
+
+// DiffConfig returns the settings whose values differ between the a and b configurations, sorted by path. The
+// configurations are compared as maps, as converted by ConvertToMap, so the values are those of the map, i.e. numbers
+// are float64, and slices are compared as a single setting. a and b are typically interfaces.Configuration instances,
+// which can't be required here as it would be an import cycle.
+func DiffConfig(a, b any) ([]FieldDiff, error) {
+	var aMap, bMap map[string]any
+	if err := ConvertToMap(a, &aMap); err != nil {
+		return nil, err
+	}
+	if err := ConvertToMap(b, &bMap); err != nil {
+		return nil, err
+	}
+
+	return DiffMaps(aMap, bMap), nil
+}
+
+// MaxDiffDepth is the maximum depth of nested maps which DiffMaps compares setting by setting. Maps nested deeper are
+// compared as a single setting, so the recursion is bounded for adversarial or deeply nested configurations.
+const MaxDiffDepth = 32
+
+// DiffMaps returns the settings whose values differ between the previous and updated maps, sorted by path. Settings
+// added or removed are reported with a nil Old or New value respectively.
+func DiffMaps(previous map[string]any, updated map[string]any) []FieldDiff {
+	var diffs []FieldDiff
+	diffMaps(previous, updated, "", 0, &diffs)
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Path < diffs[j].Path
+	})
+	return diffs
+}
+
+// diffMaps adds the differences between the maps, which are at the base path and depth, to the diffs
+func diffMaps(previous map[string]any, updated map[string]any, basePath string, depth int, diffs *[]FieldDiff) {
+	for key, previousValue := range previous {
+		diffValues(previousValue, updated[key], diffPath(basePath, key), depth, diffs)
+	}
+
+	for key, updatedValue := range updated {
+		if _, exists := previous[key]; !exists {
+			diffValues(nil, updatedValue, diffPath(basePath, key), depth, diffs)
+		}
+	}
+}
+
+// diffValues adds the differences between the values at the path to the diffs. Maps are compared setting by
+// setting, so a section which is added or removed reports each of its settings, or the section itself when it is
+// empty. Maps nested deeper than MaxDiffDepth are compared as a single setting.
+func diffValues(previous any, updated any, path string, depth int, diffs *[]FieldDiff) {
+	previousMap, previousIsMap := previous.(map[string]any)
+	updatedMap, updatedIsMap := updated.(map[string]any)
+
+	switch {
+	case depth >= MaxDiffDepth:
+		// Deliberately not recursing any further
+	case previousIsMap && updatedIsMap:
+		diffMaps(previousMap, updatedMap, path, depth+1, diffs)
+		return
+	case previousIsMap && updated == nil && len(previousMap) > 0:
+		diffMaps(previousMap, nil, path, depth+1, diffs)
+		return
+	case previous == nil && updatedIsMap && len(updatedMap) > 0:
+		diffMaps(nil, updatedMap, path, depth+1, diffs)
+		return
+	}
+
+	if !reflect.DeepEqual(previous, updated) {
+		*diffs = append(*diffs, FieldDiff{Path: path, Old: previous, New: updated})
+	}
+}
+
+func diffPath(basePath string, key string) string {
+	if len(basePath) == 0 {
+		return key
+	}
+	return BuildBaseKey(basePath, key)
+}
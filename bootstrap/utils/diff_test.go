@@ -0,0 +1,232 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/edgexfoundry/go-mod-bootstrap/v3/config"
+)
+
+func TestDiffConfig(t *testing.T) {
+	baseline := ConfigurationMockStruct{
+		Writable: WritableInfo{
+			LogLevel: "INFO",
+			StoreAndForward: StoreAndForwardInfo{
+				Enabled:       true,
+				MaxRetryCount: 10,
+			},
+		},
+		Clients: map[string]config.ClientInfo{
+			"core-data": {Host: "localhost", Port: 59880},
+		},
+		Registry: config.RegistryInfo{Host: "localhost", Port: 8500},
+	}
+
+	tests := []struct {
+		Name     string
+		Update   func(c *ConfigurationMockStruct)
+		Expected []FieldDiff
+	}{
+		{"Equal", func(c *ConfigurationMockStruct) {}, nil},
+		{"Nested", func(c *ConfigurationMockStruct) {
+			c.Writable.StoreAndForward.MaxRetryCount = 20
+			c.Writable.LogLevel = "DEBUG"
+		}, []FieldDiff{
+			{Path: "Writable/LogLevel", Old: "INFO", New: "DEBUG"},
+			{Path: "Writable/StoreAndForward/MaxRetryCount", Old: float64(10), New: float64(20)},
+		}},
+		{"Map and slice", func(c *ConfigurationMockStruct) {
+			c.Clients = map[string]config.ClientInfo{
+				"core-data": {Host: "localhost", Port: 59880, UseMessageBus: true},
+			}
+			c.Trigger.SubscribeTopics = []string{"events", "commands"}
+		}, []FieldDiff{
+			{Path: "Clients/core-data/UseMessageBus", Old: false, New: true},
+			{Path: "Trigger/SubscribeTopics", Old: nil, New: []any{"events", "commands"}},
+		}},
+		{"Added and removed sections", func(c *ConfigurationMockStruct) {
+			c.Clients = map[string]config.ClientInfo{
+				"core-metadata": {Host: "metadata", Port: 59881},
+			}
+		}, []FieldDiff{
+			{Path: "Clients/core-data/Host", Old: "localhost", New: nil},
+			{Path: "Clients/core-data/Port", Old: float64(59880), New: nil},
+			{Path: "Clients/core-data/Protocol", Old: "", New: nil},
+			{Path: "Clients/core-data/UseMessageBus", Old: false, New: nil},
+			{Path: "Clients/core-metadata/Host", Old: nil, New: "metadata"},
+			{Path: "Clients/core-metadata/Port", Old: nil, New: float64(59881)},
+			{Path: "Clients/core-metadata/Protocol", Old: nil, New: ""},
+			{Path: "Clients/core-metadata/UseMessageBus", Old: nil, New: false},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			updated := baseline
+			updated.Clients = map[string]config.ClientInfo{"core-data": baseline.Clients["core-data"]}
+			tc.Update(&updated)
+
+			actual, err := DiffConfig(baseline, updated)
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestDiffConfig_SliceChanged(t *testing.T) {
+	previous := map[string]any{"Trigger": map[string]any{"SubscribeTopics": []any{"events"}}}
+	updated := map[string]any{"Trigger": map[string]any{"SubscribeTopics": []any{"events", "commands"}}}
+
+	expected := []FieldDiff{
+		{Path: "Trigger/SubscribeTopics", Old: []any{"events"}, New: []any{"events", "commands"}},
+	}
+	assert.Equal(t, expected, DiffMaps(previous, updated))
+	assert.Empty(t, DiffMaps(updated, updated))
+}
+
+func TestDiffMaps(t *testing.T) {
+	tests := []struct {
+		Name     string
+		Previous map[string]any
+		Updated  map[string]any
+		Expected []FieldDiff
+	}{
+		{"Equal", map[string]any{"LogLevel": "INFO"}, map[string]any{"LogLevel": "INFO"}, nil},
+		{"Added setting", map[string]any{}, map[string]any{"LogLevel": "INFO"}, []FieldDiff{
+			{Path: "LogLevel", Old: nil, New: "INFO"},
+		}},
+		{"Removed setting", map[string]any{"LogLevel": "INFO"}, map[string]any{}, []FieldDiff{
+			{Path: "LogLevel", Old: "INFO", New: nil},
+		}},
+		{"Nested change", map[string]any{"A": map[string]any{"B": map[string]any{"C": 1, "D": 2}}},
+			map[string]any{"A": map[string]any{"B": map[string]any{"C": 1, "D": 3}}}, []FieldDiff{
+				{Path: "A/B/D", Old: 2, New: 3},
+			}},
+		{"Added section", map[string]any{}, map[string]any{"A": map[string]any{"B": map[string]any{"C": 1}}}, []FieldDiff{
+			{Path: "A/B/C", Old: nil, New: 1},
+		}},
+		{"Removed section", map[string]any{"A": map[string]any{"B": 1, "C": 2}}, map[string]any{}, []FieldDiff{
+			{Path: "A/B", Old: 1, New: nil},
+			{Path: "A/C", Old: 2, New: nil},
+		}},
+		{"Added empty section", map[string]any{}, map[string]any{"A": map[string]any{}}, []FieldDiff{
+			{Path: "A", Old: nil, New: map[string]any{}},
+		}},
+		{"Removed empty section", map[string]any{"A": map[string]any{}}, map[string]any{}, []FieldDiff{
+			{Path: "A", Old: map[string]any{}, New: nil},
+		}},
+		{"Section replaced by setting", map[string]any{"A": map[string]any{"B": 1}}, map[string]any{"A": "value"}, []FieldDiff{
+			{Path: "A", Old: map[string]any{"B": 1}, New: "value"},
+		}},
+		{"Setting replaced by section", map[string]any{"A": "value"}, map[string]any{"A": map[string]any{"B": 1}}, []FieldDiff{
+			{Path: "A", Old: "value", New: map[string]any{"B": 1}},
+		}},
+		{"Nil setting removed", map[string]any{"A": nil}, map[string]any{}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			assert.Equal(t, tc.Expected, DiffMaps(tc.Previous, tc.Updated))
+		})
+	}
+}
+
+func TestDiffMaps_MaxDepth(t *testing.T) {
+	keys := make([]string, MaxDiffDepth+10)
+	for i := range keys {
+		keys[i] = "K"
+	}
+
+	previous := nestedMap(keys, "old")
+	updated := nestedMap(keys, "new")
+
+	actual := DiffMaps(previous, updated)
+	require.Len(t, actual, 1)
+	// Maps nested deeper than the max depth are compared as a single setting at the max depth
+	assert.Equal(t, BuildBaseKey(keys[:MaxDiffDepth+1]...), actual[0].Path)
+	assert.Empty(t, DiffMaps(previous, nestedMap(keys, "old")))
+}
+
+// FuzzDiffMaps verifies that changing a single nested setting is reported as exactly that setting, or the section at
+// the max depth when nested deeper
+func FuzzDiffMaps(f *testing.F) {
+	f.Add("Writable/LogLevel", "INFO", "DEBUG", false)
+	f.Add("Writable/StoreAndForward/Enabled", "true", "false", false)
+	f.Add("Writable/InsecureSecrets/DB/SecretData/password", "", "secret", true)
+	f.Add(strings.Repeat("a/", 100)+"b", "x", "y", true)
+
+	f.Fuzz(func(t *testing.T, path string, oldValue string, newValue string, withSibling bool) {
+		keys := strings.Split(path, PathSep)
+		for _, key := range keys {
+			if len(key) == 0 {
+				t.Skip("empty keys aren't valid paths")
+			}
+		}
+
+		previous := nestedMap(keys, oldValue)
+		updated := nestedMap(keys, newValue)
+		if withSibling {
+			// An unchanged sibling at each level mustn't be reported
+			addSiblings(previous)
+			addSiblings(updated)
+		}
+
+		actual := DiffMaps(previous, updated)
+		if oldValue == newValue {
+			assert.Empty(t, actual)
+			return
+		}
+
+		expectedKeys := keys
+		if len(expectedKeys) > MaxDiffDepth+1 {
+			expectedKeys = expectedKeys[:MaxDiffDepth+1]
+		}
+		require.Len(t, actual, 1)
+		assert.Equal(t, BuildBaseKey(expectedKeys...), actual[0].Path)
+	})
+}
+
+// nestedMap returns nested maps with the keys, where the value is the value of the innermost key
+func nestedMap(keys []string, value any) map[string]any {
+	result := map[string]any{keys[len(keys)-1]: value}
+	for i := len(keys) - 2; i >= 0; i-- {
+		result = map[string]any{keys[i]: result}
+	}
+	return result
+}
+
+// addSiblings adds an unchanged sibling setting to each of the nested maps
+func addSiblings(m map[string]any) {
+	for {
+		var next map[string]any
+		for _, value := range m {
+			if nested, ok := value.(map[string]any); ok {
+				next = nested
+			}
+		}
+		if _, exists := m["~sibling"]; !exists {
+			m["~sibling"] = "unchanged"
+		}
+		if next == nil {
+			return
+		}
+		m = next
+	}
+}
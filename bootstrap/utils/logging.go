@@ -0,0 +1,263 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/errors"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+)
+
+// contextLogger wraps a LoggingClient and prefixes every message with its context key/value pairs.
+type contextLogger struct {
+	logger.LoggingClient
+	keyValues []string
+	prefix    string
+}
+
+// NewContextLogger returns a LoggingClient that prefixes every message logged via the passed in client with the
+// passed in key/value pairs, i.e. "[service=core-data operation=Process] message". If the passed in client was
+// itself created by NewContextLogger the key/value pairs are appended to its existing pairs.
+func NewContextLogger(lc logger.LoggingClient, keyValues ...string) logger.LoggingClient {
+	if lc == nil {
+		return nil
+	}
+
+	var existing []string
+	if parent, ok := lc.(*contextLogger); ok {
+		lc = parent.LoggingClient
+		existing = parent.keyValues
+	}
+
+	if len(keyValues)%2 == 1 {
+		// add an empty value to keep k/v pairs correct
+		keyValues = append(keyValues, "")
+	}
+
+	combined := make([]string, 0, len(existing)+len(keyValues))
+	combined = append(combined, existing...)
+	combined = append(combined, keyValues...)
+
+	pairs := make([]string, 0, len(combined)/2)
+	for i := 0; i < len(combined); i += 2 {
+		pairs = append(pairs, fmt.Sprintf("%s=%s", combined[i], combined[i+1]))
+	}
+
+	prefix := ""
+	if len(pairs) > 0 {
+		prefix = "[" + strings.Join(pairs, " ") + "] "
+	}
+
+	return &contextLogger{
+		LoggingClient: lc,
+		keyValues:     combined,
+		prefix:        prefix,
+	}
+}
+
+// withPrefix prepends the context prefix to a plain message
+func (c *contextLogger) withPrefix(msg string) string {
+	return c.prefix + msg
+}
+
+// withFormatPrefix prepends the context prefix to a format string, escaping any format verbs in the prefix.
+// The underlying client doesn't format messages without any args, so no escaping is needed in that case.
+func (c *contextLogger) withFormatPrefix(msg string, args []interface{}) string {
+	if len(args) == 0 {
+		return c.prefix + msg
+	}
+
+	return strings.ReplaceAll(c.prefix, "%", "%%") + msg
+}
+
+// Debug logs a message at the DEBUG severity level
+func (c *contextLogger) Debug(msg string, args ...interface{}) {
+	c.LoggingClient.Debug(c.withPrefix(msg), args...)
+}
+
+// Error logs a message at the ERROR severity level
+func (c *contextLogger) Error(msg string, args ...interface{}) {
+	c.LoggingClient.Error(c.withPrefix(msg), args...)
+}
+
+// Info logs a message at the INFO severity level
+func (c *contextLogger) Info(msg string, args ...interface{}) {
+	c.LoggingClient.Info(c.withPrefix(msg), args...)
+}
+
+// Trace logs a message at the TRACE severity level
+func (c *contextLogger) Trace(msg string, args ...interface{}) {
+	c.LoggingClient.Trace(c.withPrefix(msg), args...)
+}
+
+// Warn logs a message at the WARN severity level
+func (c *contextLogger) Warn(msg string, args ...interface{}) {
+	c.LoggingClient.Warn(c.withPrefix(msg), args...)
+}
+
+// Debugf logs a formatted message at the DEBUG severity level
+func (c *contextLogger) Debugf(msg string, args ...interface{}) {
+	c.LoggingClient.Debugf(c.withFormatPrefix(msg, args), args...)
+}
+
+// Errorf logs a formatted message at the ERROR severity level
+func (c *contextLogger) Errorf(msg string, args ...interface{}) {
+	c.LoggingClient.Errorf(c.withFormatPrefix(msg, args), args...)
+}
+
+// Infof logs a formatted message at the INFO severity level
+func (c *contextLogger) Infof(msg string, args ...interface{}) {
+	c.LoggingClient.Infof(c.withFormatPrefix(msg, args), args...)
+}
+
+// Tracef logs a formatted message at the TRACE severity level
+func (c *contextLogger) Tracef(msg string, args ...interface{}) {
+	c.LoggingClient.Tracef(c.withFormatPrefix(msg, args), args...)
+}
+
+// Warnf logs a formatted message at the WARN severity level
+func (c *contextLogger) Warnf(msg string, args ...interface{}) {
+	c.LoggingClient.Warnf(c.withFormatPrefix(msg, args), args...)
+}
+
+// logLevels are the supported log levels, in order of increasing severity
+var logLevels = []string{models.TraceLog, models.DebugLog, models.InfoLog, models.WarnLog, models.ErrorLog}
+
+// LogFunc adapts a message logged via a LoggingClient created by NewFuncLogger to the host application's logging, i.e.
+// zap or zerolog. The message of the formatted logging methods, i.e. Infof, is already formatted, in which case
+// keyValues is empty, otherwise keyValues are the key/value pairs passed to the logging method, i.e. Info.
+type LogFunc func(logLevel string, msg string, keyValues []any)
+
+// funcLogger is a LoggingClient which logs the messages of at least its log level using its LogFunc
+type funcLogger struct {
+	logFunc  LogFunc
+	mutex    sync.RWMutex
+	logLevel string
+}
+
+// NewFuncLogger returns a LoggingClient which logs the messages of at least the log level using logFunc, so the
+// bootstrap's logging can be routed to the host application's logging by passing the returned client to the DIC as
+// the LoggingClient, rather than the EdgeX logger. The log level is set to INFO when it isn't one of the supported
+// log levels.
+func NewFuncLogger(logLevel string, logFunc LogFunc) logger.LoggingClient {
+	lc := &funcLogger{logFunc: logFunc, logLevel: models.InfoLog}
+	_ = lc.SetLogLevel(logLevel)
+	return lc
+}
+
+// SetLogLevel sets minimum severity log level
+func (l *funcLogger) SetLogLevel(logLevel string) errors.EdgeX {
+	for _, level := range logLevels {
+		if level == logLevel {
+			l.mutex.Lock()
+			l.logLevel = logLevel
+			l.mutex.Unlock()
+			return nil
+		}
+	}
+
+	return errors.NewCommonEdgeX(errors.KindContractInvalid, fmt.Sprintf("invalid log level `%s`", logLevel), nil)
+}
+
+// LogLevel returns the current log level setting
+func (l *funcLogger) LogLevel() string {
+	l.mutex.RLock()
+	defer l.mutex.RUnlock()
+	return l.logLevel
+}
+
+// enabled returns whether the messages of the log level are logged
+func (l *funcLogger) enabled(logLevel string) bool {
+	minimum := l.LogLevel()
+	for _, level := range logLevels {
+		if level == minimum {
+			return true
+		}
+		if level == logLevel {
+			return false
+		}
+	}
+	return true
+}
+
+func (l *funcLogger) log(logLevel string, msg string, keyValues []any) {
+	if l.enabled(logLevel) {
+		l.logFunc(logLevel, msg, keyValues)
+	}
+}
+
+func (l *funcLogger) logf(logLevel string, msg string, args []any) {
+	if !l.enabled(logLevel) {
+		return
+	}
+	if len(args) > 0 {
+		msg = fmt.Sprintf(msg, args...)
+	}
+	l.logFunc(logLevel, msg, nil)
+}
+
+// Debug logs a message at the DEBUG severity level
+func (l *funcLogger) Debug(msg string, args ...interface{}) {
+	l.log(models.DebugLog, msg, args)
+}
+
+// Error logs a message at the ERROR severity level
+func (l *funcLogger) Error(msg string, args ...interface{}) {
+	l.log(models.ErrorLog, msg, args)
+}
+
+// Info logs a message at the INFO severity level
+func (l *funcLogger) Info(msg string, args ...interface{}) {
+	l.log(models.InfoLog, msg, args)
+}
+
+// Trace logs a message at the TRACE severity level
+func (l *funcLogger) Trace(msg string, args ...interface{}) {
+	l.log(models.TraceLog, msg, args)
+}
+
+// Warn logs a message at the WARN severity level
+func (l *funcLogger) Warn(msg string, args ...interface{}) {
+	l.log(models.WarnLog, msg, args)
+}
+
+// Debugf logs a formatted message at the DEBUG severity level
+func (l *funcLogger) Debugf(msg string, args ...interface{}) {
+	l.logf(models.DebugLog, msg, args)
+}
+
+// Errorf logs a formatted message at the ERROR severity level
+func (l *funcLogger) Errorf(msg string, args ...interface{}) {
+	l.logf(models.ErrorLog, msg, args)
+}
+
+// Infof logs a formatted message at the INFO severity level
+func (l *funcLogger) Infof(msg string, args ...interface{}) {
+	l.logf(models.InfoLog, msg, args)
+}
+
+// Tracef logs a formatted message at the TRACE severity level
+func (l *funcLogger) Tracef(msg string, args ...interface{}) {
+	l.logf(models.TraceLog, msg, args)
+}
+
+// Warnf logs a formatted message at the WARN severity level
+func (l *funcLogger) Warnf(msg string, args ...interface{}) {
+	l.logf(models.WarnLog, msg, args)
+}
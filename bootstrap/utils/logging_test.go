@@ -0,0 +1,119 @@
+/*******************************************************************************
+ * Copyright 2023 Intel Corp.
+ *
+ * Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except
+ * in compliance with the License. You may obtain a copy of the License at
+ *
+ * http://www.apache.org/licenses/LICENSE-2.0
+ *
+ * Unless required by applicable law or agreed to in writing, software distributed under the License
+ * is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express
+ * or implied. See the License for the specific language governing permissions and limitations under
+ * the License.
+ *******************************************************************************/
+
+package utils
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/clients/logger"
+	"github.com/edgexfoundry/go-mod-core-contracts/v3/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type capturingLogger struct {
+	logger.MockLogger
+	last string
+}
+
+func (l *capturingLogger) Info(msg string, _ ...interface{}) { l.last = msg }
+func (l *capturingLogger) Infof(msg string, args ...interface{}) {
+	l.last = fmt.Sprintf(msg, args...)
+}
+
+func TestNewContextLogger(t *testing.T) {
+	base := &capturingLogger{}
+
+	lc := NewContextLogger(base, "service", "core-data")
+	lc.Info("plain message")
+	assert.Equal(t, "[service=core-data] plain message", base.last)
+
+	lc = NewContextLogger(lc, "operation", "Process")
+	lc.Infof("formatted %s", "message")
+	assert.Equal(t, "[service=core-data operation=Process] formatted message", base.last)
+
+	// format verbs in the context values must not be interpreted
+	lc = NewContextLogger(base, "correlation-id", "100%d")
+	lc.Infof("value=%d", 5)
+	assert.Equal(t, "[correlation-id=100%d] value=5", base.last)
+
+	// odd number of key/values gets an empty value
+	lc = NewContextLogger(base, "key")
+	lc.Info("message")
+	assert.Equal(t, "[key=] message", base.last)
+
+	assert.Nil(t, NewContextLogger(nil, "key", "value"))
+}
+
+type loggedMessage struct {
+	logLevel  string
+	msg       string
+	keyValues []any
+}
+
+func TestNewFuncLogger(t *testing.T) {
+	var logged []loggedMessage
+	lc := NewFuncLogger(models.InfoLog, func(logLevel string, msg string, keyValues []any) {
+		logged = append(logged, loggedMessage{logLevel, msg, keyValues})
+	})
+
+	lc.Debug("not logged")
+	lc.Info("plain message", "key", "value")
+	lc.Warnf("formatted %s", "message")
+	lc.Errorf("not formatted 100%")
+	assert.Equal(t, []loggedMessage{
+		{models.InfoLog, "plain message", []any{"key", "value"}},
+		{models.WarnLog, "formatted message", nil},
+		{models.ErrorLog, "not formatted 100%", nil},
+	}, logged)
+
+	logged = nil
+	require.NoError(t, lc.SetLogLevel(models.TraceLog))
+	assert.Equal(t, models.TraceLog, lc.LogLevel())
+	lc.Tracef("trace %d", 1)
+	lc.Debug("debug")
+	assert.Equal(t, []loggedMessage{{models.TraceLog, "trace 1", nil}, {models.DebugLog, "debug", nil}}, logged)
+
+	require.Error(t, lc.SetLogLevel("VERBOSE"))
+	assert.Equal(t, models.TraceLog, lc.LogLevel())
+
+	// The context logger works with any LoggingClient
+	logged = nil
+	NewContextLogger(lc, "service", "core-data").Infof("formatted %s", "message")
+	assert.Equal(t, []loggedMessage{{models.InfoLog, "[service=core-data] formatted message", nil}}, logged)
+
+	assert.Equal(t, models.InfoLog, NewFuncLogger("VERBOSE", nil).LogLevel())
+}
+
+// ExampleNewFuncLogger shows how to route the bootstrap's logging to the host application's logging, i.e. zap or
+// zerolog, in place of the fmt.Printf calls, by adding the returned LoggingClient to the DIC.
+func ExampleNewFuncLogger() {
+	lc := NewFuncLogger(models.InfoLog, func(logLevel string, msg string, keyValues []any) {
+		if len(keyValues) > 0 {
+			fmt.Printf("%s: %s %v\n", logLevel, msg, keyValues)
+			return
+		}
+		fmt.Printf("%s: %s\n", logLevel, msg)
+	})
+
+	lc.Debug("Not logged at the INFO log level")
+	lc.Info("Service started", "port", 59880)
+	lc.Warnf("Secret %s not found", "redisdb")
+
+	// Output:
+	// INFO: Service started [port 59880]
+	// WARN: Secret redisdb not found
+}
@@ -17,6 +17,7 @@ package utils
 import (
 	"encoding/json"
 	"fmt"
+	"sort"
 	"strings"
 )
 
@@ -34,6 +35,51 @@ func ConvertToMap(target any, m *map[string]any) error {
 	return nil
 }
 
+// ConvertToMapOmitEmpty is the same as ConvertToMap, except that empty values are omitted from the map following the
+// json omitempty semantics, i.e. false, 0, nil and empty strings, maps and slices. Nested maps that are empty once their
+// empty values are omitted, such as those from structs with only zero values, are also omitted.
+func ConvertToMapOmitEmpty(target any, m *map[string]any) error {
+	if err := ConvertToMap(target, m); err != nil {
+		return err
+	}
+
+	removeEmptyValues(*m)
+	return nil
+}
+
+// removeEmptyValues recursively removes the empty values from the map
+func removeEmptyValues(target map[string]any) {
+	for key, value := range target {
+		if nested, ok := value.(map[string]any); ok {
+			removeEmptyValues(nested)
+		}
+
+		if isEmptyValue(value) {
+			delete(target, key)
+		}
+	}
+}
+
+// isEmptyValue reports whether a value unmarshalled from JSON is empty
+func isEmptyValue(value any) bool {
+	switch v := value.(type) {
+	case nil:
+		return true
+	case bool:
+		return !v
+	case float64:
+		return v == 0
+	case string:
+		return len(v) == 0
+	case []any:
+		return len(v) == 0
+	case map[string]any:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
+
 // ConvertFromMap uses json to marshal and unmarshal a map into a target type
 func ConvertFromMap(m map[string]any, target any) error {
 	jsonBytes, err := json.Marshal(m)
@@ -48,7 +94,9 @@ func ConvertFromMap(m map[string]any, target any) error {
 	return nil
 }
 
-// MergeMaps combines the src map keys and values with the dest map keys and values if the key exists
+// MergeMaps combines the src map keys and values with the dest map keys and values if the key exists.
+// Values from src are copied into dest, so the merged dest never shares maps or slices with src. Otherwise a section
+// that src references in several places, i.e. from a resolved YAML alias, would be changed by later merges into dest.
 func MergeMaps(dest map[string]any, src map[string]any) {
 
 	var exists bool
@@ -56,7 +104,7 @@ func MergeMaps(dest map[string]any, src map[string]any) {
 	for key, value := range src {
 		_, exists = dest[key]
 		if !exists {
-			dest[key] = value
+			dest[key] = copyValue(value)
 			continue
 		}
 
@@ -66,7 +114,27 @@ func MergeMaps(dest map[string]any, src map[string]any) {
 			continue
 		}
 
-		dest[key] = value
+		dest[key] = copyValue(value)
+	}
+}
+
+// copyValue returns a deep copy of the map and slice values found in a map of configuration
+func copyValue(value any) any {
+	switch v := value.(type) {
+	case map[string]any:
+		result := make(map[string]any, len(v))
+		for key, item := range v {
+			result[key] = copyValue(item)
+		}
+		return result
+	case []any:
+		result := make([]any, len(v))
+		for index, item := range v {
+			result[index] = copyValue(item)
+		}
+		return result
+	default:
+		return value
 	}
 }
 
@@ -106,6 +174,108 @@ func removeUnusedSettingsFromMap(target map[string]any, baseKey string, validKey
 	}
 }
 
+// FindUnknownSettings returns the keys, which are full paths beginning with the baseKey, that don't correspond
+// to a setting of src. These are typically keys written to the Configuration Provider by a newer version of the service
+// or tooling, which src can't hold and are dropped when the settings are loaded into it.
+func FindUnknownSettings(src any, baseKey string, keys []string) ([]string, error) {
+	srcMap := make(map[string]any)
+
+	if err := ConvertToMap(src, &srcMap); err != nil {
+		return nil, fmt.Errorf("could not create map from %T: %s", src, err.Error())
+	}
+
+	sectionKeys := make(map[string]any)
+	settingKeys := make(map[string]any)
+	collectSettingKeys(srcMap, baseKey, sectionKeys, settingKeys)
+
+	var unknownKeys []string
+	for _, key := range keys {
+		key = strings.TrimSuffix(key, PathSep)
+		if _, exists := sectionKeys[key]; exists {
+			continue
+		}
+		if !isWithinSetting(key, settingKeys) {
+			unknownKeys = append(unknownKeys, key)
+		}
+	}
+
+	return unknownKeys, nil
+}
+
+// collectSettingKeys adds the full keys of the map's sections to the sectionKeys and of its settings to the settingKeys
+func collectSettingKeys(target map[string]any, baseKey string, sectionKeys map[string]any, settingKeys map[string]any) {
+	for key, value := range target {
+		nextBaseKey := BuildBaseKey(baseKey, key)
+		if sub, ok := value.(map[string]any); ok && len(sub) > 0 {
+			sectionKeys[nextBaseKey] = nil
+			collectSettingKeys(sub, nextBaseKey, sectionKeys, settingKeys)
+			continue
+		}
+		settingKeys[nextBaseKey] = nil
+	}
+}
+
+// isWithinSetting reports whether the key is one of the setting keys or nested within one, such as an element of a
+// slice setting stored under its own key
+func isWithinSetting(key string, settingKeys map[string]any) bool {
+	for {
+		if _, exists := settingKeys[key]; exists {
+			return true
+		}
+
+		index := strings.LastIndex(key, PathSep)
+		if index < 0 {
+			return false
+		}
+		key = key[:index]
+	}
+}
+
+// ExpandMap expands the flat map, whose keys are the paths of the settings with their keys separated by the separator,
+// i.e. "Writable.LogLevel", into nested maps. The values are left as strings. An error is returned when a key is both a
+// setting and a section, i.e. "Writable" and "Writable.LogLevel".
+func ExpandMap(flat map[string]string, separator string) (map[string]any, error) {
+	expanded := make(map[string]any)
+
+	// The keys are expanded in order so conflicts are reported consistently
+	keys := make([]string, 0, len(flat))
+	for key := range flat {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		parts := strings.Split(key, separator)
+		current := expanded
+		for index, part := range parts {
+			if len(part) == 0 {
+				return nil, fmt.Errorf("key '%s' has an empty element", key)
+			}
+
+			if index == len(parts)-1 {
+				if _, exists := current[part]; exists {
+					return nil, fmt.Errorf("key '%s' is both a setting and a section", key)
+				}
+				current[part] = flat[key]
+				break
+			}
+
+			switch next := current[part].(type) {
+			case nil:
+				section := make(map[string]any)
+				current[part] = section
+				current = section
+			case map[string]any:
+				current = next
+			default:
+				return nil, fmt.Errorf("key '%s' is both a setting and a section", strings.Join(parts[:index+1], separator))
+			}
+		}
+	}
+
+	return expanded, nil
+}
+
 // MergeValues combines src with the dest.
 func MergeValues(dest any, src any) error {
 	var ok bool
@@ -43,7 +43,73 @@ type StoreAndForwardInfo struct {
 }
 
 type TriggerInfo struct {
-	Type string
+	Type            string
+	SubscribeTopics []string
+}
+
+func TestConvertToMapOmitEmpty(t *testing.T) {
+	type customInfo struct {
+		Name     string
+		Count    int
+		Enabled  bool
+		Tags     []string
+		Settings map[string]string
+		Nested   StoreAndForwardInfo
+	}
+
+	target := customInfo{
+		Name:     "",
+		Count:    0,
+		Settings: nil,
+		Nested:   StoreAndForwardInfo{RetryInterval: "5m"},
+	}
+
+	tests := []struct {
+		Name      string
+		OmitEmpty bool
+		Expected  map[string]any
+	}{
+		{"Include all", false, map[string]any{
+			"Name":     "",
+			"Count":    float64(0),
+			"Enabled":  false,
+			"Tags":     nil,
+			"Settings": nil,
+			"Nested": map[string]any{
+				"Enabled":       false,
+				"RetryInterval": "5m",
+				"MaxRetryCount": float64(0),
+			},
+		}},
+		{"Omit empty", true, map[string]any{
+			"Nested": map[string]any{
+				"RetryInterval": "5m",
+			},
+		}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			actual := map[string]any{}
+			var err error
+			if tc.OmitEmpty {
+				err = ConvertToMapOmitEmpty(target, &actual)
+			} else {
+				err = ConvertToMap(target, &actual)
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.Expected, actual)
+		})
+	}
+}
+
+func TestConvertToMapOmitEmptyNestedEmpty(t *testing.T) {
+	actual := map[string]any{}
+	err := ConvertToMapOmitEmpty(ConfigurationMockStruct{Trigger: TriggerInfo{Type: "http"}}, &actual)
+	require.NoError(t, err)
+
+	// Structs with only zero values, i.e. Writable and Registry, are also omitted
+	assert.Equal(t, map[string]any{"Trigger": map[string]any{"Type": "http"}}, actual)
 }
 
 func TestMergeMaps(t *testing.T) {
@@ -111,6 +177,32 @@ func TestMergeMaps(t *testing.T) {
 	assert.Equal(t, initialConfig.Registry.Type, actualConfig.Registry.Type)
 }
 
+func TestMergeMapsCopiesSharedValues(t *testing.T) {
+	// The same section referenced from several places, as an anchor referenced by aliases may be
+	shared := map[string]any{"Host": "localhost", "Port": 59881, "Tags": []any{"a", "b"}}
+	src := map[string]any{
+		"Clients": map[string]any{"core-metadata": shared, "core-command": shared},
+	}
+
+	dest := map[string]any{}
+	MergeMaps(dest, src)
+	MergeMaps(dest, map[string]any{
+		"Clients": map[string]any{"core-metadata": map[string]any{"Host": "edgex-core-metadata"}},
+	})
+	dest["Clients"].(map[string]any)["core-command"].(map[string]any)["Tags"].([]any)[0] = "c"
+
+	expected := map[string]any{
+		"Clients": map[string]any{
+			"core-metadata": map[string]any{"Host": "edgex-core-metadata", "Port": 59881, "Tags": []any{"a", "b"}},
+			"core-command":  map[string]any{"Host": "localhost", "Port": 59881, "Tags": []any{"c", "b"}},
+		},
+	}
+	assert.Equal(t, expected, dest)
+
+	// The source must not be changed by merges into the destination
+	assert.Equal(t, map[string]any{"Host": "localhost", "Port": 59881, "Tags": []any{"a", "b"}}, shared)
+}
+
 func TestMergeValues(t *testing.T) {
 	// create the service config
 	serviceConfig := ConfigurationMockStruct{
@@ -192,6 +284,82 @@ func TestRemoveUnusedSettings(t *testing.T) {
 	assertMapSettingValueNotExist(t, actual, "Registry/Type")
 }
 
+func TestFindUnknownSettings(t *testing.T) {
+	testConfig := ConfigurationMockStruct{
+		Writable: WritableInfo{
+			StoreAndForward: StoreAndForwardInfo{
+				Enabled: true,
+			},
+		},
+	}
+
+	keys := []string{
+		"edgex/v3/app-something/Writable/StoreAndForward/Enabled",
+		"edgex/v3/app-something/Writable/StoreAndForward/NewSetting",
+		"edgex/v3/app-something/Writable/",
+		"edgex/v3/app-something/Trigger/Type",
+		"edgex/v3/app-something/Trigger/SubscribeTopics/0",
+		"edgex/v3/app-something/Trigger/NewSetting",
+		"edgex/v3/app-something/NewSection/Enabled",
+	}
+
+	actual, err := FindUnknownSettings(testConfig, "edgex/v3/app-something", keys)
+
+	require.NoError(t, err)
+	expected := []string{
+		"edgex/v3/app-something/Writable/StoreAndForward/NewSetting",
+		"edgex/v3/app-something/Trigger/NewSetting",
+		"edgex/v3/app-something/NewSection/Enabled",
+	}
+	assert.Equal(t, expected, actual)
+}
+
+func TestExpandMap(t *testing.T) {
+	flat := map[string]string{
+		"Writable.LogLevel":                      "INFO",
+		"Writable.StoreAndForward.Enabled":       "true",
+		"Writable.StoreAndForward.MaxRetryCount": "10",
+		"Trigger.Type":                           "edgex-messagebus",
+	}
+
+	expected := map[string]any{
+		"Writable": map[string]any{
+			"LogLevel": "INFO",
+			"StoreAndForward": map[string]any{
+				"Enabled":       "true",
+				"MaxRetryCount": "10",
+			},
+		},
+		"Trigger": map[string]any{
+			"Type": "edgex-messagebus",
+		},
+	}
+
+	actual, err := ExpandMap(flat, ".")
+	require.NoError(t, err)
+	assert.Equal(t, expected, actual)
+}
+
+func TestExpandMapInvalid(t *testing.T) {
+	tests := []struct {
+		Name          string
+		Flat          map[string]string
+		ExpectedError string
+	}{
+		{"Setting then section", map[string]string{"Writable": "x", "Writable.LogLevel": "INFO"}, "key 'Writable' is both a setting and a section"},
+		{"Section then setting", map[string]string{"A.B.C": "x", "A.B": "y"}, "key 'A.B' is both a setting and a section"},
+		{"Empty element", map[string]string{"Writable..LogLevel": "INFO"}, "has an empty element"},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			_, err := ExpandMap(tc.Flat, ".")
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.ExpectedError)
+		})
+	}
+}
+
 func assertMapSettingValueExists(t *testing.T, actual map[string]any, actualPath string) bool {
 	keys := strings.Split(actualPath, PathSep)
 	target := actual
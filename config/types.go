@@ -40,6 +40,15 @@ const (
 	CommonConfigDone = "IsCommonConfigReady"
 )
 
+const (
+	// DefaultSecretStoreTokenEnvVar is the default name of the environment variable the SecretStore token is read
+	// from when neither a TokenFile nor the RuntimeTokenProvider is configured.
+	DefaultSecretStoreTokenEnvVar = "SECRETSTORE_AUTHTOKEN"
+	// DefaultSecretsFilePassphraseEnvVar is the default name of the environment variable the passphrase used to
+	// encrypt the SecretsFile in insecure mode is read from.
+	DefaultSecretsFilePassphraseEnvVar = "SECRETSFILE_PASSPHRASE"
+)
+
 // ServiceInfo contains configuration settings necessary for the basic operation of any EdgeX service.
 type ServiceInfo struct {
 	// HealthCheckInterval is the interval for Registry heal check callback
@@ -64,6 +73,11 @@ type ServiceInfo struct {
 	RequestTimeout string
 	// CORSConfiguration defines the cross-origin resource sharing related settings
 	CORSConfiguration CORSConfigurationInfo
+	// CertPath is the optional path to the PEM encoded certificate used to serve HTTPS. KeyPath must also be set when
+	// this is set. The certificate is reloaded when the file changes, so it can be rotated without a restart.
+	CertPath string
+	// KeyPath is the optional path to the PEM encoded private key for the CertPath certificate.
+	KeyPath string
 }
 
 // HealthCheck is a URL specifying a health check REST endpoint used by the Registry to determine if the
@@ -154,31 +168,81 @@ type SecretStoreInfo struct {
 	RootCaCertPath string
 	ServerName     string
 	Authentication types.AuthenticationInfo
+	// FallbackNamespaces is the optional comma separated list of SecretStore names, i.e. shared secret namespaces,
+	// which are searched in order for a secret not found in the service's own SecretStore (StoreName).
+	FallbackNamespaces string
+	// Instance is the optional name of the service's instance. The StoreName may be a text/template, i.e.
+	// "{{.ServiceKey}}-{{.Instance}}", which is rendered with the service key and Instance, so each instance of a
+	// service can have its own SecretStore.
+	Instance string
 	// TokenFile provides a location to a token file.
 	TokenFile string
+	// TokenEnvVar is the name of the environment variable the token is read from when TokenFile is empty and the
+	// RuntimeTokenProvider is disabled. The token is not read from the environment if this is empty.
+	TokenEnvVar string
 	// SecretsFile is optional Path to JSON file containing secrets to seed into service's SecretStore
 	SecretsFile string
 	// DisableScrubSecretsFile specifies to not scrub secrets file after importing. Service will fail start-up if
 	// not disabled and file can not be written.
 	DisableScrubSecretsFile bool
+	// WatchSecretsFile specifies, when running in insecure mode, to load the secrets from SecretsFile and reload them
+	// each time the file changes. Intended for development so edited secrets are picked up without a restart.
+	WatchSecretsFile bool
+	// SecretsFilePassphraseEnvVar is the name of the environment variable the passphrase used to encrypt the watched
+	// SecretsFile in insecure mode is read from. The file is kept in plaintext if this or the variable's value is empty.
+	SecretsFilePassphraseEnvVar string
+	// SecretsDirectory is the optional path to a directory, i.e. mounted Kubernetes secret volumes, which, when
+	// running in insecure mode, the secrets are loaded from. Each sub-directory is a secretName and each file within
+	// it is a key of the secret, with the file's contents as the value.
+	SecretsDirectory string
+	// SecretsFromEnvironment specifies, when running in insecure mode, to load the secrets from the environment
+	// variables named SECRET_<SECRETNAME>_<KEY>, i.e. SECRET_REDISDB_PASSWORD. Intended for ephemeral environments,
+	// such as test containers, so a secrets file isn't needed.
+	SecretsFromEnvironment bool
 
 	// RuntimeTokenProvider is optional if not using delayed start from spiffe-token provider
 	RuntimeTokenProvider types.RuntimeTokenProviderInfo
 }
 
+// redactedSecretStoreInfo has the SecretStoreInfo fields, but not its methods, so it can be formatted without recursion
+type redactedSecretStoreInfo SecretStoreInfo
+
+// String returns the SecretStoreInfo with the authentication token redacted
+func (s SecretStoreInfo) String() string {
+	return fmt.Sprintf("%+v", s.redacted())
+}
+
+// GoString returns the SecretStoreInfo with the authentication token redacted
+func (s SecretStoreInfo) GoString() string {
+	return strings.Replace(fmt.Sprintf("%#v", s.redacted()), "config.redactedSecretStoreInfo", "config.SecretStoreInfo", 1)
+}
+
+func (s SecretStoreInfo) redacted() redactedSecretStoreInfo {
+	redacted := redactedSecretStoreInfo(s)
+	if len(redacted.Authentication.AuthToken) > 0 {
+		redacted.Authentication.AuthToken = redactedValue
+	}
+
+	return redacted
+}
+
 func NewSecretStoreInfo(serviceKey string) SecretStoreInfo {
 	return SecretStoreInfo{
-		Type:                    secrets.Vault,
-		Protocol:                "http",
-		Host:                    "localhost",
-		Port:                    8200,
-		StoreName:               serviceKey,
-		TokenFile:               fmt.Sprintf("/tmp/edgex/secrets/%s/secrets-token.json", serviceKey),
-		DisableScrubSecretsFile: false,
-		Namespace:               "",
-		RootCaCertPath:          "",
-		ServerName:              "",
-		SecretsFile:             "",
+		Type:                        secrets.Vault,
+		Protocol:                    "http",
+		Host:                        "localhost",
+		Port:                        8200,
+		StoreName:                   serviceKey,
+		TokenFile:                   fmt.Sprintf("/tmp/edgex/secrets/%s/secrets-token.json", serviceKey),
+		TokenEnvVar:                 DefaultSecretStoreTokenEnvVar,
+		SecretsFilePassphraseEnvVar: DefaultSecretsFilePassphraseEnvVar,
+		DisableScrubSecretsFile:     false,
+		Namespace:                   "",
+		RootCaCertPath:              "",
+		ServerName:                  "",
+		FallbackNamespaces:          "",
+		SecretsFile:                 "",
+		SecretsDirectory:            "",
 		Authentication: types.AuthenticationInfo{
 			AuthType:  "X-Vault-Token",
 			AuthToken: "",
@@ -224,6 +288,60 @@ type InsecureSecretsInfo struct {
 	SecretData map[string]string
 }
 
+// redactedValue replaces sensitive values when configuration is formatted, i.e. when logged
+const redactedValue = "***"
+
+// String returns the InsecureSecrets with all the secret values redacted
+func (s InsecureSecrets) String() string {
+	return fmt.Sprintf("%v", s.redacted())
+}
+
+// GoString returns the InsecureSecrets with all the secret values redacted
+func (s InsecureSecrets) GoString() string {
+	goString := fmt.Sprintf("%#v", s.redacted())
+	goString = strings.Replace(goString, "map[string]config.redactedInsecureSecretsInfo", "config.InsecureSecrets", 1)
+	return strings.ReplaceAll(goString, "config.redactedInsecureSecretsInfo", "config.InsecureSecretsInfo")
+}
+
+// redacted returns a copy of the InsecureSecrets that doesn't implement fmt.Stringer and has the secret values redacted
+func (s InsecureSecrets) redacted() map[string]redactedInsecureSecretsInfo {
+	if s == nil {
+		return nil
+	}
+
+	redacted := make(map[string]redactedInsecureSecretsInfo, len(s))
+	for key, info := range s {
+		redacted[key] = info.redacted()
+	}
+
+	return redacted
+}
+
+// redactedInsecureSecretsInfo has the InsecureSecretsInfo fields, but not its methods, so it can be formatted without recursion
+type redactedInsecureSecretsInfo InsecureSecretsInfo
+
+// String returns the InsecureSecretsInfo with the secret values redacted. The secret keys are kept.
+func (i InsecureSecretsInfo) String() string {
+	return fmt.Sprintf("%+v", i.redacted())
+}
+
+// GoString returns the InsecureSecretsInfo with the secret values redacted. The secret keys are kept.
+func (i InsecureSecretsInfo) GoString() string {
+	return strings.Replace(fmt.Sprintf("%#v", i.redacted()), "config.redactedInsecureSecretsInfo", "config.InsecureSecretsInfo", 1)
+}
+
+func (i InsecureSecretsInfo) redacted() redactedInsecureSecretsInfo {
+	redacted := redactedInsecureSecretsInfo{SecretName: i.SecretName}
+	if i.SecretData != nil {
+		redacted.SecretData = make(map[string]string, len(i.SecretData))
+		for key := range i.SecretData {
+			redacted.SecretData[key] = redactedValue
+		}
+	}
+
+	return redacted
+}
+
 // ClientsCollection is a collection of Client information for communicating to dependent clients.
 type ClientsCollection map[string]*ClientInfo
 
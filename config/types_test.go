@@ -15,8 +15,10 @@
 package config
 
 import (
+	"fmt"
 	"testing"
 
+	"github.com/edgexfoundry/go-mod-secrets/v3/pkg/types"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -51,3 +53,61 @@ func TestTelemetryInfo_MetricEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestSecretsRedactedWhenFormatted(t *testing.T) {
+	password := "MySuperSecretPassword"
+	token := "s.MySuperSecretToken"
+
+	insecureSecrets := InsecureSecrets{
+		"DB": {
+			SecretName: "redisdb",
+			SecretData: map[string]string{"username": "admin", "password": password},
+		},
+	}
+
+	secretStoreInfo := NewSecretStoreInfo("core-data")
+	secretStoreInfo.Authentication = types.AuthenticationInfo{AuthType: "X-Vault-Token", AuthToken: token}
+
+	// Secrets nested in other structs must also be redacted
+	writable := struct {
+		LogLevel        string
+		InsecureSecrets InsecureSecrets
+	}{
+		LogLevel:        "INFO",
+		InsecureSecrets: insecureSecrets,
+	}
+
+	tests := []struct {
+		Name              string
+		Value             any
+		Sensitive         []string
+		ExpectedRendered  []string
+		ExpectedTypeNames []string
+	}{
+		{"InsecureSecrets", insecureSecrets, []string{password, "admin"}, []string{"DB", "redisdb", "username", "password", redactedValue}, []string{"config.InsecureSecrets{", "config.InsecureSecretsInfo{"}},
+		{"InsecureSecretsInfo", insecureSecrets["DB"], []string{password, "admin"}, []string{"redisdb", "username", "password", redactedValue}, []string{"config.InsecureSecretsInfo{"}},
+		{"SecretStoreInfo", secretStoreInfo, []string{token}, []string{"core-data", "localhost", "8200", "X-Vault-Token", redactedValue}, []string{"config.SecretStoreInfo{"}},
+		{"Nested InsecureSecrets", writable, []string{password, "admin"}, []string{"INFO", "redisdb", redactedValue}, nil},
+		{"Pointer SecretStoreInfo", &secretStoreInfo, []string{token}, []string{"core-data", redactedValue}, nil},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.Name, func(t *testing.T) {
+			for _, format := range []string{"%v", "%+v", "%s", "%#v"} {
+				rendered := fmt.Sprintf(format, tc.Value)
+				for _, sensitive := range tc.Sensitive {
+					assert.NotContains(t, rendered, sensitive, "format %s leaked secret material", format)
+				}
+				for _, expected := range tc.ExpectedRendered {
+					assert.Contains(t, rendered, expected, "format %s", format)
+				}
+			}
+
+			goString := fmt.Sprintf("%#v", tc.Value)
+			for _, typeName := range tc.ExpectedTypeNames {
+				assert.Contains(t, goString, typeName)
+			}
+			assert.NotContains(t, goString, "redacted")
+		})
+	}
+}